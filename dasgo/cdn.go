@@ -0,0 +1,251 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Image Formats
+// https://discord.com/developers/docs/reference#image-formatting-image-formats
+const (
+	FlagImageFormatJPEG   = "jpg"
+	FlagImageFormatPNG    = "png"
+	FlagImageFormatWEBP   = "webp"
+	FlagImageFormatGIF    = "gif"
+	FlagImageFormatLOTTIE = "json"
+)
+
+// Image Sizes
+// https://discord.com/developers/docs/reference#image-formatting
+const (
+	FlagImageSizeMIN = 16
+	FlagImageSizeMAX = 4096
+)
+
+// Image Hashes
+// https://discord.com/developers/docs/reference#image-formatting
+const (
+	// FlagImageHashAnimatedPrefix represents the prefix of an animated image's hash.
+	FlagImageHashAnimatedPrefix = "a_"
+)
+
+// ErrNoImage represents an error returned when a CDN URL is built for an image that is not set.
+var ErrNoImage = errors.New("image is not set")
+
+var (
+	// imageFormatsStatic represents the image formats of a CDN Endpoint for static images.
+	imageFormatsStatic = []string{FlagImageFormatPNG, FlagImageFormatJPEG, FlagImageFormatWEBP}
+
+	// imageFormatsAnimated represents the image formats of a CDN Endpoint for animated images.
+	imageFormatsAnimated = []string{FlagImageFormatPNG, FlagImageFormatJPEG, FlagImageFormatWEBP, FlagImageFormatGIF}
+)
+
+// IsAnimatedHash returns whether an image hash refers to an animated image.
+func IsAnimatedHash(hash string) bool {
+	return strings.HasPrefix(hash, FlagImageHashAnimatedPrefix)
+}
+
+// cdnURL returns the URL of an image at a CDN Endpoint with the given parameters (i.e "{user.id}", "1").
+//
+// An empty format uses GIF for animated images (when supported) and PNG otherwise,
+// while a zero size uses the default size of the image.
+func cdnURL(endpoint string, params []string, animated bool, format string, size int, formats []string) (string, error) {
+	if format == "" {
+		format = FlagImageFormatPNG
+		if animated && contains(formats, FlagImageFormatGIF) {
+			format = FlagImageFormatGIF
+		}
+	}
+
+	if !contains(formats, format) {
+		return "", fmt.Errorf("error building CDN URL for %s: image format %q is not one of %v", endpoint, format, formats)
+	}
+
+	if format == FlagImageFormatGIF && !animated {
+		return "", fmt.Errorf("error building CDN URL for %s: image format %q requires an animated image", endpoint, format)
+	}
+
+	if size != 0 && (size < FlagImageSizeMIN || size > FlagImageSizeMAX || size&(size-1) != 0) {
+		return "", fmt.Errorf("error building CDN URL for %s: image size %d is not a power of 2 between %d and %d",
+			endpoint, size, FlagImageSizeMIN, FlagImageSizeMAX)
+	}
+
+	url := CDNEndpointBaseURL + strings.NewReplacer(params...).Replace(endpoint) + "." + format
+	if size != 0 && format != FlagImageFormatLOTTIE {
+		url += "?size=" + strconv.Itoa(size)
+	}
+
+	return url, nil
+}
+
+// contains returns whether a slice of strings contains s.
+func contains(slice []string, s string) bool {
+	for _, element := range slice {
+		if element == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// snowflakeString returns the string representation of a snowflake.
+func snowflakeString(s Snowflake) string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// DefaultAvatarURL returns the URL of a user's default avatar (PNG).
+//
+// The default avatar is determined by the user's discriminator, or by the user's ID
+// when the user does not have a discriminator (i.e "0").
+func (u *User) DefaultAvatarURL() string {
+	index := (uint64(u.ID) >> FlagSnowflakeTimestampShift) % 6
+	if discriminator, err := strconv.ParseUint(u.Discriminator, 10, 64); err == nil && discriminator != 0 {
+		index = discriminator % 5
+	}
+
+	url, _ := cdnURL(CDNEndpointDefaultUserAvatar, []string{"{index}", strconv.FormatUint(index, 10)},
+		false, FlagImageFormatPNG, 0, []string{FlagImageFormatPNG})
+
+	return url
+}
+
+// AvatarURL returns the URL of a user's avatar with an Image Format and size,
+// or the URL of the user's default avatar when the user does not have an avatar.
+func (u *User) AvatarURL(format string, size int) (string, error) {
+	if u.Avatar == nil || *u.Avatar == "" {
+		return u.DefaultAvatarURL(), nil
+	}
+
+	return cdnURL(CDNEndpointUserAvatar, []string{"{user.id}", snowflakeString(u.ID), "{user.avatar}", *u.Avatar},
+		IsAnimatedHash(*u.Avatar), format, size, imageFormatsAnimated)
+}
+
+// BannerURL returns the URL of a user's banner with an Image Format and size.
+func (u *User) BannerURL(format string, size int) (string, error) {
+	if u.Banner == nil || *u.Banner == "" {
+		return "", fmt.Errorf("error building user banner URL: %w", ErrNoImage)
+	}
+
+	return cdnURL(CDNEndpointUserBanner, []string{"{user.id}", snowflakeString(u.ID), "{user.banner}", *u.Banner},
+		IsAnimatedHash(*u.Banner), format, size, imageFormatsAnimated)
+}
+
+// AvatarURL returns the URL of a guild member's guild avatar with an Image Format and size,
+// or the URL of the member's user avatar when the member does not have a guild avatar.
+//
+// The member's GuildID must be set to build the URL of a guild avatar.
+func (m *GuildMember) AvatarURL(format string, size int) (string, error) {
+	if m.Avatar == nil || *m.Avatar == "" {
+		if m.User == nil {
+			return "", fmt.Errorf("error building guild member avatar URL: %w", ErrNoImage)
+		}
+
+		return m.User.AvatarURL(format, size)
+	}
+
+	if m.User == nil {
+		return "", errors.New("error building guild member avatar URL: the member's user is not set")
+	}
+
+	return cdnURL(CDNEndpointGuildMemberAvatar,
+		[]string{"{guild.id}", snowflakeString(m.GuildID), "{user.id}", snowflakeString(m.User.ID), "{member.avatar}", *m.Avatar},
+		IsAnimatedHash(*m.Avatar), format, size, imageFormatsAnimated)
+}
+
+// IconURL returns the URL of a guild's icon with an Image Format and size.
+func (g *Guild) IconURL(format string, size int) (string, error) {
+	if g.Icon == "" {
+		return "", fmt.Errorf("error building guild icon URL: %w", ErrNoImage)
+	}
+
+	return cdnURL(CDNEndpointGuildIcon, []string{"{guild.id}", snowflakeString(g.ID), "{guild.icon}", g.Icon},
+		IsAnimatedHash(g.Icon), format, size, imageFormatsAnimated)
+}
+
+// SplashURL returns the URL of a guild's splash with an Image Format and size.
+func (g *Guild) SplashURL(format string, size int) (string, error) {
+	if g.Splash == "" {
+		return "", fmt.Errorf("error building guild splash URL: %w", ErrNoImage)
+	}
+
+	return cdnURL(CDNEndpointGuildSplash, []string{"{guild.id}", snowflakeString(g.ID), "{guild.splash}", g.Splash},
+		false, format, size, imageFormatsStatic)
+}
+
+// DiscoverySplashURL returns the URL of a guild's discovery splash with an Image Format and size.
+func (g *Guild) DiscoverySplashURL(format string, size int) (string, error) {
+	if g.DiscoverySplash == "" {
+		return "", fmt.Errorf("error building guild discovery splash URL: %w", ErrNoImage)
+	}
+
+	return cdnURL(CDNEndpointGuildDiscoverySplash,
+		[]string{"{guild.id}", snowflakeString(g.ID), "{guild.discovery_splash}", g.DiscoverySplash},
+		false, format, size, imageFormatsStatic)
+}
+
+// BannerURL returns the URL of a guild's banner with an Image Format and size.
+func (g *Guild) BannerURL(format string, size int) (string, error) {
+	if g.Banner == nil || *g.Banner == "" {
+		return "", fmt.Errorf("error building guild banner URL: %w", ErrNoImage)
+	}
+
+	return cdnURL(CDNEndpointGuildBanner, []string{"{guild.id}", snowflakeString(g.ID), "{guild.banner}", *g.Banner},
+		IsAnimatedHash(*g.Banner), format, size, imageFormatsAnimated)
+}
+
+// IconURL returns the URL of a role's icon with an Image Format and size.
+func (r *Role) IconURL(format string, size int) (string, error) {
+	if r.Icon == nil || *r.Icon == "" {
+		return "", fmt.Errorf("error building role icon URL: %w", ErrNoImage)
+	}
+
+	return cdnURL(CDNEndpointRoleIcon, []string{"{role.id}", snowflakeString(r.ID), "{role.icon}", *r.Icon},
+		false, format, size, imageFormatsStatic)
+}
+
+// URL returns the URL of a custom emoji with an Image Format and size.
+func (e *Emoji) URL(format string, size int) (string, error) {
+	if e.ID == 0 {
+		return "", fmt.Errorf("error building emoji URL: %w (the emoji is a standard emoji)", ErrNoImage)
+	}
+
+	animated := e.Animated != nil && *e.Animated
+
+	return cdnURL(CDNEndpointCustomEmoji, []string{"{emoji.id}", snowflakeString(e.ID)},
+		animated, format, size, imageFormatsAnimated)
+}
+
+// URL returns the URL of a sticker with a size, using the Image Format of the sticker's Sticker Format Type.
+//
+// The size of a Lottie sticker is ignored.
+func (s *Sticker) URL(size int) (string, error) {
+	var format string
+	switch s.FormatType {
+	case FlagStickerFormatTypePNG, FlagStickerFormatTypeAPNG:
+		format = FlagImageFormatPNG
+	case FlagStickerFormatTypeLOTTIE:
+		format = FlagImageFormatLOTTIE
+	case FlagStickerFormatTypeGIF:
+		format = FlagImageFormatGIF
+	default:
+		return "", fmt.Errorf("error building sticker URL: unknown sticker format type %d", s.FormatType)
+	}
+
+	return cdnURL(CDNEndpointSticker, []string{"{sticker.id}", snowflakeString(s.ID)},
+		format == FlagImageFormatGIF, format, size, []string{format})
+}
+
+// ImageURL returns the URL of a guild scheduled event's cover image with an Image Format and size.
+func (e *GuildScheduledEvent) ImageURL(format string, size int) (string, error) {
+	if e.Image == "" {
+		return "", fmt.Errorf("error building guild scheduled event image URL: %w", ErrNoImage)
+	}
+
+	return cdnURL(CDNEndpointGuildScheduledEventCover,
+		[]string{"{scheduled_event.id}", snowflakeString(e.ID), "{scheduled_event.cover_image}", e.Image},
+		false, format, size, imageFormatsStatic)
+}
@@ -136,6 +136,7 @@ var (
 		FlagGatewayCloseEventCodeRateLimited.Code:          &FlagGatewayCloseEventCodeRateLimited,
 		FlagGatewayCloseEventCodeSessionTimed.Code:         &FlagGatewayCloseEventCodeSessionTimed,
 		FlagGatewayCloseEventCodeInvalidShard.Code:         &FlagGatewayCloseEventCodeInvalidShard,
+		FlagGatewayCloseEventCodeShardingRequired.Code:     &FlagGatewayCloseEventCodeShardingRequired,
 		FlagGatewayCloseEventCodeInvalidAPIVersion.Code:    &FlagGatewayCloseEventCodeInvalidAPIVersion,
 		FlagGatewayCloseEventCodeInvalidIntent.Code:        &FlagGatewayCloseEventCodeInvalidIntent,
 		FlagGatewayCloseEventCodeDisallowedIntent.Code:     &FlagGatewayCloseEventCodeDisallowedIntent,
@@ -291,6 +292,13 @@ var (
 
 // JSON Error Codes
 // https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+//
+// The codes that are handled by dasgo are named.
+const (
+	FlagJSONErrorCodeUnknownMessage            = 10008
+	FlagJSONErrorCodeMessageTooOldToBulkDelete = 50034
+)
+
 var (
 	JSONErrorCodes = map[int]string{
 		0:      "General error (such as a malformed request body, amongst other things)",
@@ -0,0 +1,144 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCollectorTimeout represents an error returned when a collector times out.
+var ErrCollectorTimeout = errors.New("collector timed out")
+
+// Collector represents the options used to collect events of type T from a Dispatcher.
+type Collector[T any] struct {
+	// Filter determines whether an event is collected (when non-nil).
+	Filter func(*T) bool
+
+	// Max represents the number of events to collect (when non-zero).
+	Max int
+
+	// IdleTimeout represents the maximum duration between collected events (when non-zero).
+	IdleTimeout time.Duration
+
+	// Timeout represents the maximum duration of the collection (when non-zero).
+	Timeout time.Duration
+}
+
+// Collect collects events of type T that are dispatched by d until the collector
+// collects Max events, times out, or the context is done.
+//
+// The collected events are returned with ErrCollectorTimeout when the collector times out
+// before collecting Max events, or before collecting any events when Max is zero.
+// The handler of the collector is unsubscribed before Collect returns.
+//
+// Events are collected when they're dispatched rather than when they're handled (without middleware),
+// so Collect can be called from an event handler in every Dispatch Mode.
+func Collect[T any](ctx context.Context, d *Dispatcher, c Collector[T]) ([]*T, error) {
+	var mu sync.Mutex
+	var pending []*T
+	notify := make(chan struct{}, 1)
+
+	// the observer must not block, since it's called by Dispatch.
+	remove := observe(d, c.Filter, func(event *T) {
+		mu.Lock()
+		pending = append(pending, event)
+		mu.Unlock()
+
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	})
+	defer remove()
+
+	var timeout, idle <-chan time.Time
+	if c.Timeout > 0 {
+		timer := time.NewTimer(c.Timeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	var idleTimer *time.Timer
+	if c.IdleTimeout > 0 {
+		idleTimer = time.NewTimer(c.IdleTimeout)
+		defer idleTimer.Stop()
+
+		idle = idleTimer.C
+	}
+
+	var collected []*T
+	for {
+		select {
+		case <-notify:
+			mu.Lock()
+			events := pending
+			pending = nil
+			mu.Unlock()
+
+			for _, event := range events {
+				collected = append(collected, event)
+				if len(collected) == c.Max {
+					return collected, nil
+				}
+			}
+
+			if idleTimer != nil {
+				if !idleTimer.Stop() {
+					select {
+					case <-idleTimer.C:
+					default:
+					}
+				}
+
+				idleTimer.Reset(c.IdleTimeout)
+			}
+
+		case <-timeout:
+			return collected, collectorTimeout(c.Max, collected)
+		case <-idle:
+			return collected, collectorTimeout(c.Max, collected)
+		case <-ctx.Done():
+			return collected, ctx.Err()
+		}
+	}
+}
+
+// collectorTimeout returns the error of a collector that timed out.
+func collectorTimeout[T any](max int, collected []*T) error {
+	if max == 0 && len(collected) != 0 {
+		return nil
+	}
+
+	return ErrCollectorTimeout
+}
+
+// WaitFor waits for the next event of type T that matches the filter (when non-nil).
+func WaitFor[T any](ctx context.Context, d *Dispatcher, filter func(*T) bool, timeout time.Duration) (*T, error) {
+	events, err := Collect(ctx, d, Collector[T]{Filter: filter, Max: 1, Timeout: timeout})
+	if err != nil {
+		return nil, err
+	}
+
+	return events[0], nil
+}
+
+// WaitForReaction waits for the next reaction added to a message by a user (or any user when userID is 0).
+func WaitForReaction(ctx context.Context, d *Dispatcher, messageID, userID Snowflake, timeout time.Duration) (*MessageReactionAdd, error) {
+	return WaitFor(ctx, d, func(e *MessageReactionAdd) bool {
+		return e.MessageID == messageID && (userID == 0 || e.UserID == userID)
+	}, timeout)
+}
+
+// WaitForComponent waits for the next message component interaction with a custom ID that starts with prefix.
+func WaitForComponent(ctx context.Context, d *Dispatcher, prefix string, timeout time.Duration) (*InteractionCreate, error) {
+	return WaitFor(ctx, d, func(e *InteractionCreate) bool {
+		return e.Interaction != nil &&
+			e.Type == FlagInteractionTypeMESSAGE_COMPONENT &&
+			e.Data.CustomID != nil &&
+			strings.HasPrefix(*e.Data.CustomID, prefix)
+	}, timeout)
+}
@@ -0,0 +1,232 @@
+package dasgo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// collectResult represents the result of a collector.
+type collectResult[T any] struct {
+	events []*T
+	err    error
+}
+
+// waitObserved waits until an observer of events of type T is subscribed to d.
+func waitObserved[T any](t *testing.T, d *Dispatcher) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		d.mu.RLock()
+		n := len(d.observers[reflect.TypeOf((*T)(nil))])
+		d.mu.RUnlock()
+
+		if n != 0 {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("timed out waiting for the collector to subscribe")
+}
+
+// collectInHandler calls Collect from the Ready handler of a SERIAL Dispatcher, which blocks
+// the queue of the dispatcher until Collect returns.
+func collectInHandler[T any](t *testing.T, ctx context.Context, c Collector[T]) (*Dispatcher, <-chan collectResult[T]) {
+	t.Helper()
+
+	d := NewDispatcher(FlagDispatchModeSERIAL)
+	results := make(chan collectResult[T], 1)
+	HandleOnce(d, func(*Ready) {
+		events, err := Collect(ctx, d, c)
+		results <- collectResult[T]{events: events, err: err}
+	})
+
+	d.Dispatch(&Ready{})
+	waitObserved[T](t, d)
+
+	return d, results
+}
+
+// receive receives the result of a collector.
+func receive[T any](t *testing.T, results <-chan collectResult[T]) collectResult[T] {
+	t.Helper()
+
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the collector")
+	}
+
+	return collectResult[T]{}
+}
+
+func reaction(messageID Snowflake) *MessageReactionAdd {
+	return &MessageReactionAdd{MessageID: messageID}
+}
+
+func TestCollectMax(t *testing.T) {
+	d, results := collectInHandler(t, context.Background(), Collector[MessageReactionAdd]{
+		Filter: func(e *MessageReactionAdd) bool { return e.MessageID != 0 },
+		Max:    2,
+	})
+
+	d.Dispatch(reaction(0))
+	d.Dispatch(reaction(1))
+	d.Dispatch(reaction(2))
+	d.Dispatch(reaction(3))
+
+	result := receive(t, results)
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+
+	if len(result.events) != 2 || result.events[0].MessageID != 1 || result.events[1].MessageID != 2 {
+		t.Fatalf("expected the reactions of messages 1 and 2, got %v", result.events)
+	}
+
+	// the queue is unblocked once Collect returns, and the collector is unsubscribed.
+	waitTimeout(t, d.Wait)
+
+	d.mu.RLock()
+	observers := len(d.observers)
+	d.mu.RUnlock()
+
+	if observers != 0 {
+		t.Fatal("expected the collector to be unsubscribed")
+	}
+}
+
+func TestCollectTimeout(t *testing.T) {
+	d, results := collectInHandler(t, context.Background(), Collector[MessageReactionAdd]{
+		Max:     3,
+		Timeout: 50 * time.Millisecond,
+	})
+
+	d.Dispatch(reaction(1))
+
+	result := receive(t, results)
+	if !errors.Is(result.err, ErrCollectorTimeout) || len(result.events) != 1 {
+		t.Fatalf("expected 1 reaction with ErrCollectorTimeout, got %d (%v)", len(result.events), result.err)
+	}
+
+	// a collector without Max returns the events it collected before it times out.
+	d, results = collectInHandler(t, context.Background(), Collector[MessageReactionAdd]{
+		Timeout: 50 * time.Millisecond,
+	})
+
+	d.Dispatch(reaction(1))
+	d.Dispatch(reaction(2))
+
+	if result := receive(t, results); result.err != nil || len(result.events) != 2 {
+		t.Fatalf("expected 2 reactions, got %d (%v)", len(result.events), result.err)
+	}
+
+	_, results = collectInHandler(t, context.Background(), Collector[MessageReactionAdd]{
+		Timeout: 10 * time.Millisecond,
+	})
+
+	if result := receive(t, results); !errors.Is(result.err, ErrCollectorTimeout) || len(result.events) != 0 {
+		t.Fatalf("expected no reactions with ErrCollectorTimeout, got %d (%v)", len(result.events), result.err)
+	}
+}
+
+func TestCollectIdleTimeout(t *testing.T) {
+	d, results := collectInHandler(t, context.Background(), Collector[MessageReactionAdd]{
+		IdleTimeout: 200 * time.Millisecond,
+		Timeout:     5 * time.Second,
+	})
+
+	// each event resets the idle timeout.
+	start := time.Now()
+	for i := 1; i <= 3; i++ {
+		d.Dispatch(reaction(Snowflake(i)))
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	result := receive(t, results)
+	if result.err != nil || len(result.events) != 3 {
+		t.Fatalf("expected 3 reactions, got %d (%v)", len(result.events), result.err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected the idle timeout to be reset by each event, returned after %v", elapsed)
+	}
+
+	_, results = collectInHandler(t, context.Background(), Collector[MessageReactionAdd]{
+		Max:         1,
+		IdleTimeout: 10 * time.Millisecond,
+	})
+
+	if result := receive(t, results); !errors.Is(result.err, ErrCollectorTimeout) {
+		t.Fatalf("expected ErrCollectorTimeout, got %v", result.err)
+	}
+}
+
+func TestCollectContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d, results := collectInHandler(t, ctx, Collector[MessageReactionAdd]{})
+
+	d.Dispatch(reaction(1))
+	cancel()
+
+	result := receive(t, results)
+	if !errors.Is(result.err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", result.err)
+	}
+}
+
+func TestWaitForInHandler(t *testing.T) {
+	for _, mode := range []Flag{FlagDispatchModeSERIAL, FlagDispatchModeGUILD, FlagDispatchModePARALLEL} {
+		d := NewDispatcher(mode)
+
+		reactions := make(chan *MessageReactionAdd, 1)
+		components := make(chan *InteractionCreate, 1)
+		HandleOnce(d, func(*GuildMemberAdd) {
+			e, err := WaitForReaction(context.Background(), d, 1, 2, 5*time.Second)
+			if err != nil {
+				t.Error(err)
+			}
+
+			reactions <- e
+
+			interaction, err := WaitForComponent(context.Background(), d, "confirm:", 5*time.Second)
+			if err != nil {
+				t.Error(err)
+			}
+
+			components <- interaction
+		})
+
+		// the events are dispatched to the same guild as the handler's event.
+		d.Dispatch(testEvent(5, 0))
+		waitObserved[MessageReactionAdd](t, d)
+
+		d.Dispatch(&MessageReactionAdd{MessageID: 1, UserID: 3, GuildID: 5})
+		d.Dispatch(&MessageReactionAdd{MessageID: 1, UserID: 2, GuildID: 5})
+
+		if e := <-reactions; e == nil || e.UserID != 2 {
+			t.Fatalf("expected the reaction of user 2 in mode %d, got %v", mode, e)
+		}
+
+		waitObserved[InteractionCreate](t, d)
+
+		customID := "confirm:1"
+		d.Dispatch(&InteractionCreate{Interaction: &Interaction{
+			Type:    FlagInteractionTypeMESSAGE_COMPONENT,
+			GuildID: 5,
+			Data:    InteractionData{CustomID: &customID},
+		}})
+
+		if e := <-components; e == nil || *e.Data.CustomID != customID {
+			t.Fatalf("expected the component interaction in mode %d, got %v", mode, e)
+		}
+
+		waitTimeout(t, d.Wait)
+	}
+}
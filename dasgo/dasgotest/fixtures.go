@@ -0,0 +1,650 @@
+// Package dasgotest provides fake Discord servers for testing.
+package dasgotest
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+	"github.com/switchupcb/dasgo/dasgo/internal/jsonfield"
+)
+
+// Diff Kinds
+const (
+	FlagDiffKindDROPPED = "dropped"
+	FlagDiffKindRENAMED = "renamed"
+	FlagDiffKindCHANGED = "changed"
+)
+
+// fixtureFiles represents the fixture corpus, which maps the name of each type to a
+// real-shaped JSON example of it (or to the name of another fixture with the same shape).
+//
+//go:embed fixtures/*.json
+var fixtureFiles embed.FS
+
+// fixtureTypes maps each fixture file to the types of its fixtures.
+//
+// TestFixtureTypes checks these types against the structs declared in the source file
+// of each fixture file, except query string structs (i.e RedirectURL), which are not
+// encoded as JSON.
+var fixtureTypes = map[string]map[string]func() interface{}{
+	"resources.json": {
+		"ApplicationCommand":                      func() interface{} { return new(dasgo.ApplicationCommand) },
+		"ApplicationCommandOption":                func() interface{} { return new(dasgo.ApplicationCommandOption) },
+		"ApplicationCommandOptionChoice":          func() interface{} { return new(dasgo.ApplicationCommandOptionChoice) },
+		"ApplicationCommandInteractionDataOption": func() interface{} { return new(dasgo.ApplicationCommandInteractionDataOption) },
+		"GuildApplicationCommandPermissions":      func() interface{} { return new(dasgo.GuildApplicationCommandPermissions) },
+		"ApplicationCommandPermissions":           func() interface{} { return new(dasgo.ApplicationCommandPermissions) },
+		"ActionsRow":                              func() interface{} { return new(dasgo.ActionsRow) },
+		"Button":                                  func() interface{} { return new(dasgo.Button) },
+		"SelectMenu":                              func() interface{} { return new(dasgo.SelectMenu) },
+		"SelectMenuOption":                        func() interface{} { return new(dasgo.SelectMenuOption) },
+		"TextInput":                               func() interface{} { return new(dasgo.TextInput) },
+		"Interaction":                             func() interface{} { return new(dasgo.Interaction) },
+		"InteractionData":                         func() interface{} { return new(dasgo.InteractionData) },
+		"ResolvedData":                            func() interface{} { return new(dasgo.ResolvedData) },
+		"MessageInteraction":                      func() interface{} { return new(dasgo.MessageInteraction) },
+		"InteractionResponse":                     func() interface{} { return new(dasgo.InteractionResponse) },
+		"Messages":                                func() interface{} { return new(dasgo.Messages) },
+		"Autocomplete":                            func() interface{} { return new(dasgo.Autocomplete) },
+		"ModalSubmitInteractionData":              func() interface{} { return new(dasgo.ModalSubmitInteractionData) },
+		"Application":                             func() interface{} { return new(dasgo.Application) },
+		"InstallParams":                           func() interface{} { return new(dasgo.InstallParams) },
+		"AuditLog":                                func() interface{} { return new(dasgo.AuditLog) },
+		"AuditLogEntry":                           func() interface{} { return new(dasgo.AuditLogEntry) },
+		"AuditLogOptions":                         func() interface{} { return new(dasgo.AuditLogOptions) },
+		"AuditLogChange":                          func() interface{} { return new(dasgo.AuditLogChange) },
+		"Channel":                                 func() interface{} { return new(dasgo.Channel) },
+		"Message":                                 func() interface{} { return new(dasgo.Message) },
+		"MessageActivity":                         func() interface{} { return new(dasgo.MessageActivity) },
+		"MessageReference":                        func() interface{} { return new(dasgo.MessageReference) },
+		"FollowedChannel":                         func() interface{} { return new(dasgo.FollowedChannel) },
+		"Reaction":                                func() interface{} { return new(dasgo.Reaction) },
+		"PermissionOverwrite":                     func() interface{} { return new(dasgo.PermissionOverwrite) },
+		"ThreadMetadata":                          func() interface{} { return new(dasgo.ThreadMetadata) },
+		"ThreadMember":                            func() interface{} { return new(dasgo.ThreadMember) },
+		"Embed":                                   func() interface{} { return new(dasgo.Embed) },
+		"EmbedThumbnail":                          func() interface{} { return new(dasgo.EmbedThumbnail) },
+		"EmbedVideo":                              func() interface{} { return new(dasgo.EmbedVideo) },
+		"EmbedImage":                              func() interface{} { return new(dasgo.EmbedImage) },
+		"EmbedProvider":                           func() interface{} { return new(dasgo.EmbedProvider) },
+		"EmbedAuthor":                             func() interface{} { return new(dasgo.EmbedAuthor) },
+		"EmbedFooter":                             func() interface{} { return new(dasgo.EmbedFooter) },
+		"EmbedField":                              func() interface{} { return new(dasgo.EmbedField) },
+		"Attachment":                              func() interface{} { return new(dasgo.Attachment) },
+		"ChannelMention":                          func() interface{} { return new(dasgo.ChannelMention) },
+		"AllowedMentions":                         func() interface{} { return new(dasgo.AllowedMentions) },
+		"Emoji":                                   func() interface{} { return new(dasgo.Emoji) },
+		"Guild":                                   func() interface{} { return new(dasgo.Guild) },
+		"GuildPreview":                            func() interface{} { return new(dasgo.GuildPreview) },
+		"GuildWidgetSettings":                     func() interface{} { return new(dasgo.GuildWidgetSettings) },
+		"GuildWidget":                             func() interface{} { return new(dasgo.GuildWidget) },
+		"GuildMember":                             func() interface{} { return new(dasgo.GuildMember) },
+		"Integration":                             func() interface{} { return new(dasgo.Integration) },
+		"IntegrationAccount":                      func() interface{} { return new(dasgo.IntegrationAccount) },
+		"IntegrationApplication":                  func() interface{} { return new(dasgo.IntegrationApplication) },
+		"Ban":                                     func() interface{} { return new(dasgo.Ban) },
+		"WelcomeScreen":                           func() interface{} { return new(dasgo.WelcomeScreen) },
+		"WelcomeScreenChannel":                    func() interface{} { return new(dasgo.WelcomeScreenChannel) },
+		"GuildScheduledEvent":                     func() interface{} { return new(dasgo.GuildScheduledEvent) },
+		"GuildScheduledEventEntityMetadata":       func() interface{} { return new(dasgo.GuildScheduledEventEntityMetadata) },
+		"GuildScheduledEventUser":                 func() interface{} { return new(dasgo.GuildScheduledEventUser) },
+		"GuildTemplate":                           func() interface{} { return new(dasgo.GuildTemplate) },
+		"Invite":                                  func() interface{} { return new(dasgo.Invite) },
+		"InviteStageInstance":                     func() interface{} { return new(dasgo.InviteStageInstance) },
+		"InviteMetadata":                          func() interface{} { return new(dasgo.InviteMetadata) },
+		"StageInstance":                           func() interface{} { return new(dasgo.StageInstance) },
+		"Sticker":                                 func() interface{} { return new(dasgo.Sticker) },
+		"StickerItem":                             func() interface{} { return new(dasgo.StickerItem) },
+		"StickerPack":                             func() interface{} { return new(dasgo.StickerPack) },
+		"User":                                    func() interface{} { return new(dasgo.User) },
+		"Connection":                              func() interface{} { return new(dasgo.Connection) },
+		"VoiceState":                              func() interface{} { return new(dasgo.VoiceState) },
+		"VoiceRegion":                             func() interface{} { return new(dasgo.VoiceRegion) },
+		"Webhook":                                 func() interface{} { return new(dasgo.Webhook) },
+		"Role":                                    func() interface{} { return new(dasgo.Role) },
+		"RoleTags":                                func() interface{} { return new(dasgo.RoleTags) },
+		"Team":                                    func() interface{} { return new(dasgo.Team) },
+		"TeamMember":                              func() interface{} { return new(dasgo.TeamMember) },
+		"ClientStatus":                            func() interface{} { return new(dasgo.ClientStatus) },
+		"Activity":                                func() interface{} { return new(dasgo.Activity) },
+		"ActivityTimestamps":                      func() interface{} { return new(dasgo.ActivityTimestamps) },
+		"ActivityEmoji":                           func() interface{} { return new(dasgo.ActivityEmoji) },
+		"ActivityParty":                           func() interface{} { return new(dasgo.ActivityParty) },
+		"ActivityAssets":                          func() interface{} { return new(dasgo.ActivityAssets) },
+		"ActivityAssetImage":                      func() interface{} { return new(dasgo.ActivityAssetImage) },
+		"ActivitySecrets":                         func() interface{} { return new(dasgo.ActivitySecrets) },
+	},
+	"events.json": {
+		"Hello":                               func() interface{} { return new(dasgo.Hello) },
+		"Ready":                               func() interface{} { return new(dasgo.Ready) },
+		"Resumed":                             func() interface{} { return new(dasgo.Resumed) },
+		"Reconnect":                           func() interface{} { return new(dasgo.Reconnect) },
+		"InvalidSession":                      func() interface{} { return new(dasgo.InvalidSession) },
+		"ApplicationCommandPermissionsUpdate": func() interface{} { return new(dasgo.ApplicationCommandPermissionsUpdate) },
+		"ChannelCreate":                       func() interface{} { return new(dasgo.ChannelCreate) },
+		"ChannelUpdate":                       func() interface{} { return new(dasgo.ChannelUpdate) },
+		"ChannelDelete":                       func() interface{} { return new(dasgo.ChannelDelete) },
+		"ThreadCreate":                        func() interface{} { return new(dasgo.ThreadCreate) },
+		"ThreadUpdate":                        func() interface{} { return new(dasgo.ThreadUpdate) },
+		"ThreadDelete":                        func() interface{} { return new(dasgo.ThreadDelete) },
+		"ThreadListSync":                      func() interface{} { return new(dasgo.ThreadListSync) },
+		"ThreadMemberUpdate":                  func() interface{} { return new(dasgo.ThreadMemberUpdate) },
+		"ThreadMembersUpdate":                 func() interface{} { return new(dasgo.ThreadMembersUpdate) },
+		"ChannelPinsUpdate":                   func() interface{} { return new(dasgo.ChannelPinsUpdate) },
+		"GuildCreate":                         func() interface{} { return new(dasgo.GuildCreate) },
+		"GuildUpdate":                         func() interface{} { return new(dasgo.GuildUpdate) },
+		"GuildDelete":                         func() interface{} { return new(dasgo.GuildDelete) },
+		"GuildBanAdd":                         func() interface{} { return new(dasgo.GuildBanAdd) },
+		"GuildBanRemove":                      func() interface{} { return new(dasgo.GuildBanRemove) },
+		"GuildEmojisUpdate":                   func() interface{} { return new(dasgo.GuildEmojisUpdate) },
+		"GuildStickersUpdate":                 func() interface{} { return new(dasgo.GuildStickersUpdate) },
+		"GuildIntegrationsUpdate":             func() interface{} { return new(dasgo.GuildIntegrationsUpdate) },
+		"GuildMemberAdd":                      func() interface{} { return new(dasgo.GuildMemberAdd) },
+		"GuildMemberRemove":                   func() interface{} { return new(dasgo.GuildMemberRemove) },
+		"GuildMemberUpdate":                   func() interface{} { return new(dasgo.GuildMemberUpdate) },
+		"GuildMembersChunk":                   func() interface{} { return new(dasgo.GuildMembersChunk) },
+		"GuildRoleCreate":                     func() interface{} { return new(dasgo.GuildRoleCreate) },
+		"GuildRoleUpdate":                     func() interface{} { return new(dasgo.GuildRoleUpdate) },
+		"GuildRoleDelete":                     func() interface{} { return new(dasgo.GuildRoleDelete) },
+		"GuildScheduledEventCreate":           func() interface{} { return new(dasgo.GuildScheduledEventCreate) },
+		"GuildScheduledEventUpdate":           func() interface{} { return new(dasgo.GuildScheduledEventUpdate) },
+		"GuildScheduledEventDelete":           func() interface{} { return new(dasgo.GuildScheduledEventDelete) },
+		"GuildScheduledEventUserAdd":          func() interface{} { return new(dasgo.GuildScheduledEventUserAdd) },
+		"GuildScheduledEventUserRemove":       func() interface{} { return new(dasgo.GuildScheduledEventUserRemove) },
+		"IntegrationCreate":                   func() interface{} { return new(dasgo.IntegrationCreate) },
+		"IntegrationUpdate":                   func() interface{} { return new(dasgo.IntegrationUpdate) },
+		"IntegrationDelete":                   func() interface{} { return new(dasgo.IntegrationDelete) },
+		"InteractionCreate":                   func() interface{} { return new(dasgo.InteractionCreate) },
+		"InviteCreate":                        func() interface{} { return new(dasgo.InviteCreate) },
+		"InviteDelete":                        func() interface{} { return new(dasgo.InviteDelete) },
+		"MessageCreate":                       func() interface{} { return new(dasgo.MessageCreate) },
+		"MessageUpdate":                       func() interface{} { return new(dasgo.MessageUpdate) },
+		"MessageDelete":                       func() interface{} { return new(dasgo.MessageDelete) },
+		"MessageDeleteBulk":                   func() interface{} { return new(dasgo.MessageDeleteBulk) },
+		"MessageReactionAdd":                  func() interface{} { return new(dasgo.MessageReactionAdd) },
+		"MessageReactionRemove":               func() interface{} { return new(dasgo.MessageReactionRemove) },
+		"MessageReactionRemoveAll":            func() interface{} { return new(dasgo.MessageReactionRemoveAll) },
+		"MessageReactionRemoveEmoji":          func() interface{} { return new(dasgo.MessageReactionRemoveEmoji) },
+		"PresenceUpdate":                      func() interface{} { return new(dasgo.PresenceUpdate) },
+		"StageInstanceCreate":                 func() interface{} { return new(dasgo.StageInstanceCreate) },
+		"StageInstanceUpdate":                 func() interface{} { return new(dasgo.StageInstanceUpdate) },
+		"StageInstanceDelete":                 func() interface{} { return new(dasgo.StageInstanceDelete) },
+		"TypingStart":                         func() interface{} { return new(dasgo.TypingStart) },
+		"UserUpdate":                          func() interface{} { return new(dasgo.UserUpdate) },
+		"VoiceStateUpdate":                    func() interface{} { return new(dasgo.VoiceStateUpdate) },
+		"VoiceServerUpdate":                   func() interface{} { return new(dasgo.VoiceServerUpdate) },
+		"WebhooksUpdate":                      func() interface{} { return new(dasgo.WebhooksUpdate) },
+	},
+	"responses.json": {
+		"ListPublicArchivedThreadsResponse":           func() interface{} { return new(dasgo.ListPublicArchivedThreadsResponse) },
+		"ListPrivateArchivedThreadsResponse":          func() interface{} { return new(dasgo.ListPrivateArchivedThreadsResponse) },
+		"ListJoinedPrivateArchivedThreadsResponse":    func() interface{} { return new(dasgo.ListJoinedPrivateArchivedThreadsResponse) },
+		"ListActiveGuildThreadsResponse":              func() interface{} { return new(dasgo.ListActiveGuildThreadsResponse) },
+		"CurrentAuthorizationInformationResponse":     func() interface{} { return new(dasgo.CurrentAuthorizationInformationResponse) },
+		"GetGatewayResponse":                          func() interface{} { return new(dasgo.GetGatewayResponse) },
+		"GetGatewayBotResponse":                       func() interface{} { return new(dasgo.GetGatewayBotResponse) },
+		"AccessTokenResponse":                         func() interface{} { return new(dasgo.AccessTokenResponse) },
+		"ClientCredentialsAccessTokenResponse":        func() interface{} { return new(dasgo.ClientCredentialsAccessTokenResponse) },
+		"WebhookTokenResponse":                        func() interface{} { return new(dasgo.WebhookTokenResponse) },
+		"ExtendedBotAuthorizationAccessTokenResponse": func() interface{} { return new(dasgo.ExtendedBotAuthorizationAccessTokenResponse) },
+		"ErrorResponse":                               func() interface{} { return new(dasgo.ErrorResponse) },
+	},
+	"gateway.json": {
+		"GatewayPayload":               func() interface{} { return new(dasgo.GatewayPayload) },
+		"SessionStartLimit":            func() interface{} { return new(dasgo.SessionStartLimit) },
+		"Identify":                     func() interface{} { return new(dasgo.Identify) },
+		"IdentifyConnectionProperties": func() interface{} { return new(dasgo.IdentifyConnectionProperties) },
+		"Resume":                       func() interface{} { return new(dasgo.Resume) },
+		"Heartbeat":                    func() interface{} { return new(dasgo.Heartbeat) },
+		"GuildRequestMembers":          func() interface{} { return new(dasgo.GuildRequestMembers) },
+		"GatewayVoiceStateUpdate":      func() interface{} { return new(dasgo.GatewayVoiceStateUpdate) },
+		"GatewayPresenceUpdate":        func() interface{} { return new(dasgo.GatewayPresenceUpdate) },
+	},
+}
+
+// Diff represents a difference between a JSON fixture and its re-encoded value.
+type Diff struct {
+	// Path represents the JSON path of the difference (i.e author.public_flags).
+	Path string
+
+	// Kind represents the Diff Kind (dropped, renamed, or changed).
+	Kind string
+
+	// Field represents the JSON name of the field that a renamed key likely refers to.
+	Field string
+
+	Original interface{}
+	Encoded  interface{}
+}
+
+// String returns a description of the difference.
+func (d *Diff) String() string {
+	switch d.Kind {
+	case FlagDiffKindDROPPED:
+		return fmt.Sprintf("%s: dropped %s", d.Path, formatValue(d.Original))
+	case FlagDiffKindRENAMED:
+		return fmt.Sprintf("%s: renamed (the field is named %q) and dropped %s", d.Path, d.Field, formatValue(d.Original))
+	default:
+		return fmt.Sprintf("%s: changed from %s to %s", d.Path, formatValue(d.Original), formatValue(d.Encoded))
+	}
+}
+
+// formatValue returns the JSON representation of a decoded value.
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// RoundTrip decodes JSON data into v, re-encodes v, and returns the semantic
+// differences between the data and its re-encoded value.
+//
+// Keys that are not decoded into a field of v are dropped (or renamed when a field
+// with a similar name exists), while keys with a different value are changed.
+// Null values are equivalent to missing keys and zero values, timestamps are compared
+// by time, and keys that are only present in the re-encoded value are ignored.
+func RoundTrip(data []byte, v interface{}) ([]*Diff, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("error decoding fixture: %w", err)
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding fixture: %w", err)
+	}
+
+	original, err := decodeValue(data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding fixture: %w", err)
+	}
+
+	reencoded, err := decodeValue(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding encoded fixture: %w", err)
+	}
+
+	var diffs []*Diff
+	compare(&diffs, "", original, reencoded, reflect.ValueOf(v))
+
+	return diffs, nil
+}
+
+// Fixture returns the JSON fixture of a type (i.e "User"), or nil when the type does not have a fixture.
+func Fixture(name string) []byte {
+	corpus, err := loadFixtures()
+	if err != nil {
+		return nil
+	}
+
+	for _, fixtures := range corpus {
+		if data, ok := fixtures[name]; ok {
+			return data
+		}
+	}
+
+	return nil
+}
+
+// CheckFixtures round-trips the fixture of every type, and returns an error that describes
+// the types without a fixture, the fixtures without a type, and the differences of each fixture.
+//
+// CheckFixtures is called from a test:
+//
+//	if err := dasgotest.CheckFixtures(); err != nil {
+//		t.Fatal(err)
+//	}
+func CheckFixtures() error {
+	corpus, err := loadFixtures()
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, file := range sortedKeys(fixtureTypes) {
+		types, fixtures := fixtureTypes[file], corpus[file]
+
+		for _, name := range sortedKeys(types) {
+			data, ok := fixtures[name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing fixture in %s", name, file))
+				continue
+			}
+
+			diffs, err := RoundTrip(data, types[name]())
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+
+			for _, diff := range diffs {
+				problems = append(problems, fmt.Sprintf("%s: %s", name, diff))
+			}
+		}
+
+		for _, name := range sortedKeys(fixtures) {
+			if _, ok := types[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: fixture in %s without a type", name, file))
+			}
+		}
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("error checking fixtures:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+// loadFixtures returns the fixtures of each fixture file with references resolved.
+func loadFixtures() (map[string]map[string]json.RawMessage, error) {
+	entries, err := fixtureFiles.ReadDir("fixtures")
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixtures: %w", err)
+	}
+
+	corpus := make(map[string]map[string]json.RawMessage, len(entries))
+	all := make(map[string]json.RawMessage)
+	for _, entry := range entries {
+		data, err := fixtureFiles.ReadFile(path.Join("fixtures", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading fixtures: %w", err)
+		}
+
+		var fixtures map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fixtures); err != nil {
+			return nil, fmt.Errorf("error reading fixtures %s: %w", entry.Name(), err)
+		}
+
+		for name, fixture := range fixtures {
+			if _, ok := all[name]; ok {
+				return nil, fmt.Errorf("error reading fixtures %s: duplicate fixture %s", entry.Name(), name)
+			}
+
+			all[name] = fixture
+		}
+
+		corpus[entry.Name()] = fixtures
+	}
+
+	for _, fixtures := range corpus {
+		for name, fixture := range fixtures {
+			// a reference is followed until a fixture is found.
+			for references := 0; len(fixture) != 0 && fixture[0] == '"'; references++ {
+				var reference string
+				if err := json.Unmarshal(fixture, &reference); err != nil {
+					return nil, fmt.Errorf("error reading fixture %s: %w", name, err)
+				}
+
+				referenced, ok := all[reference]
+				if !ok || references == len(all) {
+					return nil, fmt.Errorf("error reading fixture %s: invalid reference %q", name, reference)
+				}
+
+				fixture = referenced
+			}
+
+			fixtures[name] = fixture
+		}
+	}
+
+	return corpus, nil
+}
+
+// decodeValue decodes JSON data with numbers represented as json.Number.
+func decodeValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// compare appends the differences between an original and re-encoded JSON value to diffs,
+// using the Go value that the original value is decoded into to determine its fields.
+func compare(diffs *[]*Diff, path string, original, encoded interface{}, v reflect.Value) {
+	v = jsonfield.Indirect(v)
+
+	switch o := original.(type) {
+	case map[string]interface{}:
+		e, ok := encoded.(map[string]interface{})
+		if !ok {
+			if !isZero(original) || !isZero(encoded) {
+				*diffs = append(*diffs, &Diff{Path: path, Kind: FlagDiffKindCHANGED, Original: original, Encoded: encoded})
+			}
+
+			return
+		}
+
+		fields := jsonfield.Values(v)
+
+		for _, key := range sortedKeys(o) {
+			value, keyPath := o[key], joinPath(path, key)
+			if value == nil {
+				continue
+			}
+
+			switch {
+			case fields != nil:
+				field, ok := fields[key]
+				if !ok {
+					// keys that are retained by the encoding of a type (i.e MarshalJSON) are not dropped.
+					if encodedValue, ok := e[key]; !ok || !equalValue(value, encodedValue) {
+						*diffs = append(*diffs, droppedKey(keyPath, key, value, o, fields))
+					}
+
+					continue
+				}
+
+				compare(diffs, keyPath, value, e[key], field)
+			case v.IsValid() && v.Kind() == reflect.Map:
+				compare(diffs, keyPath, value, e[key], mapValue(v, key))
+			default:
+				compare(diffs, keyPath, value, e[key], reflect.Value{})
+			}
+		}
+	case []interface{}:
+		e, ok := encoded.([]interface{})
+		if !ok || len(e) != len(o) {
+			if !isZero(original) || !isZero(encoded) {
+				*diffs = append(*diffs, &Diff{Path: path, Kind: FlagDiffKindCHANGED, Original: original, Encoded: encoded})
+			}
+
+			return
+		}
+
+		for i := range o {
+			var element reflect.Value
+			if v.IsValid() && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) &&
+				v.Type().Elem().Kind() != reflect.Uint8 && i < v.Len() {
+				element = v.Index(i)
+			}
+
+			compare(diffs, path+"["+strconv.Itoa(i)+"]", o[i], e[i], element)
+		}
+	default:
+		if !equalValue(original, encoded) {
+			*diffs = append(*diffs, &Diff{Path: path, Kind: FlagDiffKindCHANGED, Original: original, Encoded: encoded})
+		}
+	}
+}
+
+// droppedKey returns the difference of a key that is not decoded into a field.
+func droppedKey(path, key string, value interface{}, original map[string]interface{}, fields map[string]reflect.Value) *Diff {
+	renamed, distance := "", 0
+	for _, name := range sortedKeys(fields) {
+		if _, ok := original[name]; ok {
+			continue
+		}
+
+		d := editDistance(key, name)
+		if d <= 2 && d < len(key)/2 && (renamed == "" || d < distance) {
+			renamed, distance = name, d
+		}
+	}
+
+	if renamed != "" {
+		return &Diff{Path: path, Kind: FlagDiffKindRENAMED, Field: renamed, Original: value}
+	}
+
+	return &Diff{Path: path, Kind: FlagDiffKindDROPPED, Original: value}
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// minInt returns the minimum of integers.
+func minInt(x int, y ...int) int {
+	for _, n := range y {
+		if n < x {
+			x = n
+		}
+	}
+
+	return x
+}
+
+// equalValue determines whether two decoded JSON values are semantically equal.
+func equalValue(a, b interface{}) bool {
+	if isZero(a) && isZero(b) {
+		return true
+	}
+
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+
+		if x == y {
+			return true
+		}
+
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+
+		return errX == nil && errY == nil && fx == fy
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return false
+		}
+
+		if x == y {
+			return true
+		}
+
+		tx, errX := time.Parse(time.RFC3339Nano, x)
+		ty, errY := time.Parse(time.RFC3339Nano, y)
+
+		return errX == nil && errY == nil && tx.Equal(ty)
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	case map[string]interface{}, []interface{}:
+		var diffs []*Diff
+		compare(&diffs, "", a, b, reflect.Value{})
+
+		return len(diffs) == 0
+	}
+
+	return false
+}
+
+// isZero determines whether a decoded JSON value is equivalent to a missing value.
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		if v == "" {
+			return true
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, v)
+
+		return err == nil && t.IsZero()
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// mapValue returns the value of a map by the JSON representation of its key.
+func mapValue(v reflect.Value, key string) reflect.Value {
+	iterator := v.MapRange()
+	for iterator.Next() {
+		if fmt.Sprint(iterator.Key().Interface()) == key {
+			return iterator.Value()
+		}
+	}
+
+	return reflect.Zero(v.Type().Elem())
+}
+
+// joinPath returns the JSON path of a key.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// sortedKeys returns the keys of a map in order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,99 @@
+package dasgotest
+
+import (
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fixtureSources maps each fixture file to the dasgo source file that defines its types.
+var fixtureSources = map[string]string{
+	"resources.json": "resources.go",
+	"events.json":    "events.go",
+	"responses.json": "responses.go",
+	"gateway.json":   "gateway.go",
+}
+
+// fixtureExclusions represents the structs of the fixture sources without a fixture.
+//
+// Query string structs are not encoded as JSON.
+var fixtureExclusions = map[string]bool{
+	"GatewayURLQueryString": true,
+	"RedirectURI":           true,
+	"RedirectURL":           true,
+}
+
+// parseStructs returns the names of the exported structs declared in a Go source file.
+func parseStructs(t *testing.T, filename string) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(gotoken.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != gotoken.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if _, ok := spec.Type.(*ast.StructType); ok && spec.Name.IsExported() {
+				names = append(names, spec.Name.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+func TestFixtureTypes(t *testing.T) {
+	if len(fixtureSources) != len(fixtureTypes) {
+		t.Fatalf("expected a source for each of the %d fixture files, got %d", len(fixtureTypes), len(fixtureSources))
+	}
+
+	var problems []string
+	for file, source := range fixtureSources {
+		types, ok := fixtureTypes[file]
+		if !ok {
+			t.Fatalf("expected fixture file %s to have types", file)
+		}
+
+		declared := make(map[string]bool)
+		for _, name := range parseStructs(t, filepath.Join("..", source)) {
+			declared[name] = true
+
+			if _, ok := types[name]; !ok && !fixtureExclusions[name] {
+				problems = append(problems, name+": missing from the types of "+file)
+			}
+
+			if _, ok := types[name]; ok && fixtureExclusions[name] {
+				problems = append(problems, name+": excluded, but in the types of "+file)
+			}
+		}
+
+		for name := range types {
+			if !declared[name] {
+				problems = append(problems, name+": in the types of "+file+", but not declared in "+source)
+			}
+		}
+	}
+
+	if len(problems) != 0 {
+		sort.Strings(problems)
+		t.Fatalf("expected the fixture types to match the declared structs:\n%s", strings.Join(problems, "\n"))
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	if err := CheckFixtures(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,605 @@
+// Package dasgotest provides fake Discord servers for testing.
+package dasgotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+const (
+	// gatewayRateLimit represents the number of payloads a connection can send per gatewayRateLimitWindow.
+	gatewayRateLimit       = 120
+	gatewayRateLimitWindow = time.Minute
+
+	// gatewayChunkSize represents the maximum number of members in a Guild Members Chunk.
+	gatewayChunkSize = 1000
+
+	// gatewayIntents represents every valid intent (up to GUILD_SCHEDULED_EVENTS).
+	gatewayIntents = dasgo.BitFlag(1<<17 - 1)
+)
+
+// Gateway represents a fake Discord Gateway server, which sends scripted events to its connections.
+//
+// Clients connect to Gateway.WebsocketURL(), which can be returned from a Server's
+// Get Gateway endpoints by setting Server.GatewayURL.
+type Gateway struct {
+	*httptest.Server
+
+	// World represents the guilds sent to identified connections (when non-nil).
+	World *World
+
+	// Token represents the bot token required to Identify or Resume (when non-empty).
+	Token string
+
+	// HeartbeatInterval represents the heartbeat interval sent in the Hello payload.
+	HeartbeatInterval time.Duration
+
+	// Shards represents the number of shards required to Identify (when non-zero).
+	Shards int
+
+	// DisallowedIntents represents the intents that close the connection with a Disallowed Intents close code.
+	DisallowedIntents dasgo.BitFlag
+
+	mu       sync.Mutex
+	conns    map[*gatewayConn]bool
+	sessions map[string]*gatewaySession
+	received []*dasgo.GatewayPayload
+	readies  int
+	changed  chan struct{}
+}
+
+// gatewaySession represents a session of the Gateway, which outlives its connections until invalidated.
+type gatewaySession struct {
+	id       string
+	sequence int
+
+	// history represents the dispatched payloads of the session, which are replayed on Resume.
+	history []*gatewayPayload
+
+	// conn represents the connection of the session (or nil when disconnected).
+	conn *gatewayConn
+}
+
+// gatewayConn represents a connection to the Gateway.
+type gatewayConn struct {
+	ws      *wsConn
+	session *gatewaySession
+
+	// payloads represents the number of payloads received since window.
+	payloads int
+	window   time.Time
+}
+
+// gatewayPayload represents a payload sent by the Gateway.
+type gatewayPayload struct {
+	Op        int         `json:"op"`
+	Data      interface{} `json:"d"`
+	Sequence  *int        `json:"s"`
+	EventName *string     `json:"t"`
+}
+
+// NewGateway starts and returns a new Gateway for a World (when non-nil).
+//
+// The caller should call Close when finished to shut it down.
+func NewGateway(world *World, token string) *Gateway {
+	g := &Gateway{
+		World:             world,
+		Token:             token,
+		HeartbeatInterval: 41250 * time.Millisecond,
+		conns:             make(map[*gatewayConn]bool),
+		sessions:          make(map[string]*gatewaySession),
+		changed:           make(chan struct{}),
+	}
+
+	g.Server = httptest.NewServer(http.HandlerFunc(g.serveHTTP))
+
+	return g
+}
+
+// WebsocketURL returns the URL used to connect to the Gateway.
+func (g *Gateway) WebsocketURL() string {
+	return "ws" + strings.TrimPrefix(g.URL, "http")
+}
+
+// Close closes every connection and shuts down the Gateway.
+func (g *Gateway) Close() {
+	g.mu.Lock()
+	for conn := range g.conns {
+		conn.ws.close(1001, "")
+	}
+	g.mu.Unlock()
+
+	g.Server.Close()
+}
+
+// Received returns the payloads received by the Gateway.
+func (g *Gateway) Received() []*dasgo.GatewayPayload {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]*dasgo.GatewayPayload(nil), g.received...)
+}
+
+// WaitReady waits until the Gateway has sent a total of n Ready or Resumed events.
+func (g *Gateway) WaitReady(ctx context.Context, n int) error {
+	for {
+		g.mu.Lock()
+		readies, changed := g.readies, g.changed
+		g.mu.Unlock()
+
+		if readies >= n {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting for ready: %w", ctx.Err())
+		}
+	}
+}
+
+// ready records a Ready or Resumed event.
+func (g *Gateway) ready() {
+	g.readies++
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// Dispatch dispatches an event to every session.
+//
+// Events dispatched to disconnected sessions are sent when the session is resumed.
+func (g *Gateway) Dispatch(eventName string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error encoding %s event: %w", eventName, err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ids := make([]string, 0, len(g.sessions))
+	for id := range g.sessions {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		g.dispatch(g.sessions[id], eventName, json.RawMessage(encoded))
+	}
+
+	return nil
+}
+
+// dispatch dispatches an event to a session.
+func (g *Gateway) dispatch(session *gatewaySession, eventName string, data interface{}) {
+	session.sequence++
+	sequence := session.sequence
+	payload := &gatewayPayload{Op: dasgo.FlagGatewayOpcodeDispatch, Data: data, Sequence: &sequence, EventName: &eventName}
+	session.history = append(session.history, payload)
+
+	if session.conn != nil {
+		g.send(session.conn, payload)
+	}
+}
+
+// send sends a payload to a connection.
+func (g *Gateway) send(conn *gatewayConn, payload *gatewayPayload) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeUnknownError.Code)
+		return
+	}
+
+	if err := conn.ws.writeText(data); err != nil {
+		g.detach(conn)
+	}
+}
+
+// Reconnect sends a Reconnect payload to every connection.
+func (g *Gateway) Reconnect() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for conn := range g.conns {
+		g.send(conn, &gatewayPayload{Op: dasgo.FlagGatewayOpcodeReconnect})
+	}
+}
+
+// InvalidateSession sends an Invalid Session payload to every connection.
+//
+// The sessions of the connections are invalidated when the payload is not resumable.
+func (g *Gateway) InvalidateSession(resumable bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for conn := range g.conns {
+		g.send(conn, &gatewayPayload{Op: dasgo.FlagGatewayOpcodeInvalidSession, Data: resumable})
+
+		if !resumable && conn.session != nil {
+			delete(g.sessions, conn.session.id)
+			conn.session.conn = nil
+			conn.session = nil
+		}
+	}
+}
+
+// Disconnect closes every connection with a close code.
+//
+// Sessions are invalidated when the close code does not allow reconnection.
+func (g *Gateway) Disconnect(code int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for conn := range g.conns {
+		g.disconnect(conn, code)
+	}
+}
+
+// disconnect closes a connection with a close code.
+func (g *Gateway) disconnect(conn *gatewayConn, code int) {
+	reason := ""
+	if closeCode, ok := dasgo.GatewayCloseEventCodes[code]; ok {
+		reason = closeCode.Description
+		if !closeCode.Reconnect && conn.session != nil {
+			delete(g.sessions, conn.session.id)
+		}
+	}
+
+	conn.ws.close(code, reason)
+	g.detach(conn)
+}
+
+// detach detaches a closed connection from the Gateway and its session.
+func (g *Gateway) detach(conn *gatewayConn) {
+	if conn.session != nil && conn.session.conn == conn {
+		conn.session.conn = nil
+	}
+
+	delete(g.conns, conn)
+}
+
+// serveHTTP serves a connection.
+func (g *Gateway) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+
+	conn := &gatewayConn{ws: ws, window: time.Now()}
+
+	g.mu.Lock()
+	g.conns[conn] = true
+
+	query := r.URL.Query()
+	switch {
+	case query.Get("v") != "" && query.Get("v") != dasgo.VersionDiscordAPI:
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeInvalidAPIVersion.Code)
+	case query.Get("encoding") != "" && query.Get("encoding") != "json":
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeDecodeError.Code)
+	default:
+		g.send(conn, &gatewayPayload{
+			Op:   dasgo.FlagGatewayOpcodeHello,
+			Data: map[string]int64{"heartbeat_interval": g.HeartbeatInterval.Milliseconds()},
+		})
+	}
+	g.mu.Unlock()
+
+	for {
+		message, err := ws.readMessage()
+
+		g.mu.Lock()
+		if err != nil {
+			g.detach(conn)
+			g.mu.Unlock()
+
+			return
+		}
+
+		if !g.conns[conn] {
+			g.mu.Unlock()
+			return
+		}
+
+		g.handle(conn, message)
+		g.mu.Unlock()
+	}
+}
+
+// handle handles a payload received from a connection.
+func (g *Gateway) handle(conn *gatewayConn, message []byte) {
+	if now := time.Now(); now.Sub(conn.window) >= gatewayRateLimitWindow {
+		conn.payloads, conn.window = 0, now
+	}
+
+	conn.payloads++
+	if conn.payloads > gatewayRateLimit {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeRateLimited.Code)
+		return
+	}
+
+	payload := new(dasgo.GatewayPayload)
+	if err := json.Unmarshal(message, payload); err != nil || payload.Op == nil {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeDecodeError.Code)
+		return
+	}
+
+	g.received = append(g.received, payload)
+
+	switch *payload.Op {
+	case dasgo.FlagGatewayOpcodeHeartbeat:
+		g.send(conn, &gatewayPayload{Op: dasgo.FlagGatewayOpcodeHeartbeatACK})
+
+	case dasgo.FlagGatewayOpcodeIdentify:
+		g.identify(conn, payload.Data)
+
+	case dasgo.FlagGatewayOpcodeResume:
+		g.resume(conn, payload.Data)
+
+	case dasgo.FlagGatewayOpcodePresenceUpdate,
+		dasgo.FlagGatewayOpcodeVoiceStateUpdate,
+		dasgo.FlagGatewayOpcodeRequestGuildMembers:
+		if conn.session == nil {
+			g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeNotAuthenticated.Code)
+			return
+		}
+
+		if *payload.Op == dasgo.FlagGatewayOpcodeRequestGuildMembers {
+			g.requestGuildMembers(conn, payload.Data)
+		}
+
+	default:
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeUnknownOpcode.Code)
+	}
+}
+
+// authenticate returns whether a token (with or without the Bot prefix) is valid.
+func (g *Gateway) authenticate(token string) bool {
+	return g.Token == "" || strings.TrimPrefix(token, "Bot ") == g.Token
+}
+
+// readyEvent represents the Ready event sent by the Gateway.
+type readyEvent struct {
+	*dasgo.Ready
+	Guilds []*unavailableGuild `json:"guilds"`
+}
+
+// unavailableGuild represents an Unavailable Guild Object.
+type unavailableGuild struct {
+	ID          dasgo.Snowflake `json:"id"`
+	Unavailable bool            `json:"unavailable"`
+}
+
+// identify handles an Identify payload, which creates a new session.
+func (g *Gateway) identify(conn *gatewayConn, data json.RawMessage) {
+	if conn.session != nil {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeAlreadyAuthenticated.Code)
+		return
+	}
+
+	identify := new(dasgo.Identify)
+	if err := json.Unmarshal(data, identify); err != nil {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeDecodeError.Code)
+		return
+	}
+
+	switch {
+	case !g.authenticate(identify.Token):
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeAuthenticationFailed.Code)
+		return
+	case identify.Intents&^gatewayIntents != 0:
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeInvalidIntent.Code)
+		return
+	case identify.Intents&g.DisallowedIntents != 0:
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeDisallowedIntent.Code)
+		return
+	case identify.Shard == nil && g.Shards > 1:
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeShardingRequired.Code)
+		return
+	case identify.Shard != nil &&
+		(identify.Shard[1] < 1 || identify.Shard[0] < 0 || identify.Shard[0] >= identify.Shard[1] ||
+			g.Shards != 0 && identify.Shard[1] != g.Shards):
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeInvalidShard.Code)
+		return
+	}
+
+	session := &gatewaySession{id: token(), conn: conn}
+	g.sessions[session.id] = session
+	conn.session = session
+
+	var guilds []*dasgo.Guild
+	if g.World != nil {
+		g.World.mu.Lock()
+		defer g.World.mu.Unlock()
+
+		for _, guild := range values(g.World.guilds) {
+			if identify.Shard == nil || int((uint64(guild.ID)>>22)%uint64(identify.Shard[1])) == identify.Shard[0] {
+				guilds = append(guilds, guild)
+			}
+		}
+	}
+
+	version, _ := strconv.Atoi(dasgo.VersionDiscordAPI)
+	ready := &readyEvent{
+		Ready: &dasgo.Ready{
+			Version:          version,
+			SessionID:        session.id,
+			ResumeGatewayURL: g.WebsocketURL(),
+			Shard:            identify.Shard,
+		},
+		Guilds: []*unavailableGuild{},
+	}
+
+	if g.World != nil {
+		ready.User = g.World.user
+	}
+
+	for _, guild := range guilds {
+		ready.Guilds = append(ready.Guilds, &unavailableGuild{ID: guild.ID, Unavailable: true})
+	}
+
+	g.dispatch(session, dasgo.FlagGatewayEventNameReady, ready)
+	g.ready()
+
+	if identify.Intents&dasgo.FlagIntentGUILDS == 0 {
+		return
+	}
+
+	for _, guild := range guilds {
+		g.dispatch(session, dasgo.FlagGatewayEventNameGuildCreate, g.guildCreate(guild))
+	}
+}
+
+// guildCreate returns the Guild Create event of a guild.
+func (g *Gateway) guildCreate(guild *dasgo.Guild) *dasgo.GuildCreate {
+	event := &dasgo.GuildCreate{
+		Guild:       g.World.guild(guild.ID),
+		Members:     values(g.World.members[guild.ID]),
+		Channels:    []*dasgo.Channel{},
+		MemberCount: len(g.World.members[guild.ID]),
+	}
+
+	if member, ok := g.World.members[guild.ID][g.World.user.ID]; ok {
+		event.JoinedAt = member.JoinedAt
+	}
+
+	for _, channel := range values(g.World.channels) {
+		if channel.GuildID == guild.ID {
+			event.Channels = append(event.Channels, channel)
+		}
+	}
+
+	return event
+}
+
+// resume handles a Resume payload, which replays the events that were missed by a session.
+func (g *Gateway) resume(conn *gatewayConn, data json.RawMessage) {
+	if conn.session != nil {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeAlreadyAuthenticated.Code)
+		return
+	}
+
+	resume := new(dasgo.Resume)
+	if err := json.Unmarshal(data, resume); err != nil {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeDecodeError.Code)
+		return
+	}
+
+	if !g.authenticate(resume.Token) {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeAuthenticationFailed.Code)
+		return
+	}
+
+	session, ok := g.sessions[resume.SessionID]
+	if !ok {
+		g.send(conn, &gatewayPayload{Op: dasgo.FlagGatewayOpcodeInvalidSession, Data: false})
+		return
+	}
+
+	if int(resume.Seq) > session.sequence {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeInvalidSeq.Code)
+		return
+	}
+
+	// a session is resumed by one connection at a time.
+	if session.conn != nil {
+		g.disconnect(session.conn, dasgo.FlagGatewayCloseEventCodeUnknownError.Code)
+	}
+
+	session.conn = conn
+	conn.session = session
+
+	for _, payload := range session.history {
+		if *payload.Sequence > int(resume.Seq) {
+			g.send(conn, payload)
+		}
+	}
+
+	g.dispatch(session, dasgo.FlagGatewayEventNameResumed, nil)
+	g.ready()
+}
+
+// requestGuildMembers handles a Request Guild Members payload, which responds with Guild Members Chunk events.
+func (g *Gateway) requestGuildMembers(conn *gatewayConn, data json.RawMessage) {
+	request := new(dasgo.GuildRequestMembers)
+	if err := json.Unmarshal(data, request); err != nil {
+		g.disconnect(conn, dasgo.FlagGatewayCloseEventCodeDecodeError.Code)
+		return
+	}
+
+	if g.World == nil {
+		return
+	}
+
+	g.World.mu.Lock()
+	defer g.World.mu.Unlock()
+
+	var members []*dasgo.GuildMember
+	var notFound []dasgo.Snowflake
+	if len(request.UserIDs) != 0 {
+		for _, id := range request.UserIDs {
+			if member, ok := g.World.members[request.GuildID][id]; ok {
+				members = append(members, member)
+			} else {
+				notFound = append(notFound, id)
+			}
+		}
+	} else {
+		query := ""
+		if request.Query != nil {
+			query = strings.ToLower(*request.Query)
+		}
+
+		for _, member := range values(g.World.members[request.GuildID]) {
+			if request.Limit != 0 && len(members) == int(request.Limit) {
+				break
+			}
+
+			if strings.HasPrefix(strings.ToLower(member.User.Username), query) ||
+				member.Nick != nil && strings.HasPrefix(strings.ToLower(*member.Nick), query) {
+				members = append(members, member)
+			}
+		}
+	}
+
+	count := (len(members) + gatewayChunkSize - 1) / gatewayChunkSize
+	if count == 0 {
+		count = 1
+	}
+
+	for i := 0; i < count; i++ {
+		chunk := &dasgo.GuildMembersChunk{
+			GuildID:    request.GuildID,
+			Members:    []*dasgo.GuildMember{},
+			ChunkIndex: i,
+			ChunkCount: count,
+			Nonce:      request.Nonce,
+		}
+
+		if start := i * gatewayChunkSize; start < len(members) {
+			end := start + gatewayChunkSize
+			if end > len(members) {
+				end = len(members)
+			}
+
+			chunk.Members = members[start:end]
+		}
+
+		if i == count-1 {
+			chunk.NotFound = notFound
+		}
+
+		g.dispatch(conn.session, dasgo.FlagGatewayEventNameGuildMembersChunk, chunk)
+	}
+}
@@ -0,0 +1,458 @@
+package dasgotest
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+// wsClient represents the client side of a minimal WebSocket connection used by tests.
+type wsClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// dial connects to a Gateway.
+func dial(t *testing.T, g *Gateway) *wsClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(g.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, g.URL+"/?v="+dasgo.VersionDiscordAPI+"&encoding=json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", base64.StdEncoding.EncodeToString(key))
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := req.Write(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101, got %d", resp.StatusCode)
+	}
+
+	return &wsClient{conn: conn, reader: reader}
+}
+
+// readFrame reads an unmasked frame from the server.
+func (c *wsClient) readFrame(t *testing.T) (byte, []byte) {
+	t.Helper()
+
+	opcode, payload, err := c.next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return opcode, payload
+}
+
+// next reads the opcode and payload of the next unmasked frame from the server.
+func (c *wsClient) next() (byte, []byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return 0, nil, err
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return 0, nil, err
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return 0, nil, err
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+
+	return header[0] & 0x0F, payload, nil
+}
+
+// read reads the next payload from the server.
+func (c *wsClient) read(t *testing.T) *dasgo.GatewayPayload {
+	t.Helper()
+
+	opcode, data := c.readFrame(t)
+	if opcode == wsOpcodeClose {
+		t.Fatalf("expected a payload, got close code %d", binary.BigEndian.Uint16(data))
+	}
+
+	payload := new(dasgo.GatewayPayload)
+	if err := json.Unmarshal(data, payload); err != nil {
+		t.Fatal(err)
+	}
+
+	return payload
+}
+
+// readClose reads the close frame from the server and returns its close code and reason.
+func (c *wsClient) readClose(t *testing.T) (int, string) {
+	t.Helper()
+
+	for {
+		opcode, data := c.readFrame(t)
+		if opcode == wsOpcodeClose {
+			if len(data) < 2 {
+				t.Fatal("expected a close code")
+			}
+
+			return int(binary.BigEndian.Uint16(data)), string(data[2:])
+		}
+	}
+}
+
+// write writes a masked payload to the server.
+func (c *wsClient) write(t *testing.T, op int, data interface{}) {
+	t.Helper()
+
+	if err := c.send(op, data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// send writes a masked payload to the server.
+func (c *wsClient) send(op int, data interface{}) error {
+	message, err := json.Marshal(map[string]interface{}{"op": op, "d": data})
+	if err != nil {
+		return err
+	}
+
+	frame := []byte{0x80 | wsOpcodeText}
+	switch length := len(message); {
+	case length < 126:
+		frame = append(frame, 0x80|byte(length))
+	default:
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	}
+
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for i, b := range message {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err = c.conn.Write(frame)
+
+	return err
+}
+
+// expect reads the next payload and fails when its opcode, event name, or sequence is unexpected.
+func (c *wsClient) expect(t *testing.T, op int, eventName string, sequence int) *dasgo.GatewayPayload {
+	t.Helper()
+
+	payload := c.read(t)
+	if *payload.Op != op || payload.EventName != eventName || payload.SequenceNumber != sequence {
+		t.Fatalf("expected op %d %q (s %d), got op %d %q (s %d)",
+			op, eventName, sequence, *payload.Op, payload.EventName, payload.SequenceNumber)
+	}
+
+	return payload
+}
+
+// identify connects and identifies to a Gateway, and returns the connection and session ID.
+func identify(t *testing.T, g *Gateway, intents dasgo.BitFlag) (*wsClient, string) {
+	t.Helper()
+
+	c := dial(t, g)
+	c.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+	c.write(t, dasgo.FlagGatewayOpcodeIdentify, &dasgo.Identify{Token: "Bot token", Intents: intents})
+
+	var ready dasgo.Ready
+	if err := json.Unmarshal(c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameReady, 1).Data, &ready); err != nil {
+		t.Fatal(err)
+	}
+
+	if ready.SessionID == "" || ready.ResumeGatewayURL != g.WebsocketURL() {
+		t.Fatalf("expected a session and resume URL, got %q and %q", ready.SessionID, ready.ResumeGatewayURL)
+	}
+
+	return c, ready.SessionID
+}
+
+func TestGatewayIdentify(t *testing.T) {
+	world := NewWorld("dasgo")
+	guild := world.AddGuild(&dasgo.Guild{Name: "guild"})
+
+	g := NewGateway(world, "token")
+	defer g.Close()
+
+	c := dial(t, g)
+
+	var hello dasgo.Hello
+	if err := json.Unmarshal(c.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0).Data, &hello); err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Duration(hello.HeartbeatInterval) != g.HeartbeatInterval {
+		t.Fatalf("expected a heartbeat interval of %v, got %v", g.HeartbeatInterval, time.Duration(hello.HeartbeatInterval))
+	}
+
+	c.write(t, dasgo.FlagGatewayOpcodeIdentify, &dasgo.Identify{Token: "Bot token", Intents: dasgo.FlagIntentGUILDS})
+
+	var ready struct {
+		User   *dasgo.User         `json:"user"`
+		Guilds []*unavailableGuild `json:"guilds"`
+	}
+
+	if err := json.Unmarshal(c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameReady, 1).Data, &ready); err != nil {
+		t.Fatal(err)
+	}
+
+	if ready.User == nil || ready.User.Username != "dasgo" {
+		t.Errorf("expected the user of the world, got %v", ready.User)
+	}
+
+	if len(ready.Guilds) != 1 || ready.Guilds[0].ID != guild.ID || !ready.Guilds[0].Unavailable {
+		t.Errorf("expected guild %d to be unavailable, got %v", guild.ID, ready.Guilds)
+	}
+
+	var guildCreate dasgo.GuildCreate
+	if err := json.Unmarshal(c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameGuildCreate, 2).Data, &guildCreate); err != nil {
+		t.Fatal(err)
+	}
+
+	if guildCreate.Guild == nil || guildCreate.ID != guild.ID || guildCreate.Name != "guild" {
+		t.Errorf("expected the Guild Create of guild %d, got %v", guild.ID, guildCreate.Guild)
+	}
+
+	c.write(t, dasgo.FlagGatewayOpcodeHeartbeat, 2)
+	c.expect(t, dasgo.FlagGatewayOpcodeHeartbeatACK, "", 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := g.WaitReady(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	received := g.Received()
+	if len(received) != 2 || *received[0].Op != dasgo.FlagGatewayOpcodeIdentify || *received[1].Op != dasgo.FlagGatewayOpcodeHeartbeat {
+		t.Fatalf("expected the Identify and Heartbeat payloads to be received, got %d payloads", len(received))
+	}
+}
+
+func TestGatewayIdentifyClose(t *testing.T) {
+	shard := func(id, count int) *[2]int { return &[2]int{id, count} }
+
+	tests := []struct {
+		name     string
+		shards   int
+		identify *dasgo.Identify
+		code     dasgo.GatewayCloseEventCode
+	}{
+		{
+			name:     "authentication failed",
+			identify: &dasgo.Identify{Token: "Bot wrong"},
+			code:     dasgo.FlagGatewayCloseEventCodeAuthenticationFailed,
+		},
+		{
+			name:     "invalid intent",
+			identify: &dasgo.Identify{Token: "token", Intents: 1 << 20},
+			code:     dasgo.FlagGatewayCloseEventCodeInvalidIntent,
+		},
+		{
+			name:     "disallowed intent",
+			identify: &dasgo.Identify{Token: "token", Intents: dasgo.FlagIntentGUILD_MEMBERS},
+			code:     dasgo.FlagGatewayCloseEventCodeDisallowedIntent,
+		},
+		{
+			name:     "sharding required",
+			shards:   2,
+			identify: &dasgo.Identify{Token: "token"},
+			code:     dasgo.FlagGatewayCloseEventCodeShardingRequired,
+		},
+		{
+			name:     "invalid shard",
+			shards:   2,
+			identify: &dasgo.Identify{Token: "token", Shard: shard(2, 2)},
+			code:     dasgo.FlagGatewayCloseEventCodeInvalidShard,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGateway(nil, "token")
+			defer g.Close()
+
+			g.Shards = test.shards
+			g.DisallowedIntents = dasgo.FlagIntentGUILD_MEMBERS
+
+			c := dial(t, g)
+			c.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+			c.write(t, dasgo.FlagGatewayOpcodeIdentify, test.identify)
+
+			if code, reason := c.readClose(t); code != test.code.Code || reason != test.code.Description {
+				t.Fatalf("expected close code %d (%s), got %d (%s)", test.code.Code, test.code.Description, code, reason)
+			}
+		})
+	}
+
+	// a connection identifies once.
+	g := NewGateway(nil, "token")
+	defer g.Close()
+
+	c, _ := identify(t, g, 0)
+	c.write(t, dasgo.FlagGatewayOpcodeIdentify, &dasgo.Identify{Token: "token"})
+
+	if code, _ := c.readClose(t); code != dasgo.FlagGatewayCloseEventCodeAlreadyAuthenticated.Code {
+		t.Fatalf("expected close code %d, got %d", dasgo.FlagGatewayCloseEventCodeAlreadyAuthenticated.Code, code)
+	}
+}
+
+func TestGatewayResume(t *testing.T) {
+	g := NewGateway(nil, "token")
+	defer g.Close()
+
+	c, sessionID := identify(t, g, 0)
+
+	g.Disconnect(dasgo.FlagGatewayCloseEventCodeUnknownError.Code)
+	if code, _ := c.readClose(t); code != dasgo.FlagGatewayCloseEventCodeUnknownError.Code {
+		t.Fatalf("expected close code %d, got %d", dasgo.FlagGatewayCloseEventCodeUnknownError.Code, code)
+	}
+
+	// events dispatched to a disconnected session are replayed on resume.
+	for _, content := range []string{"a", "b"} {
+		if err := g.Dispatch(dasgo.FlagGatewayEventNameMessageCreate, &dasgo.Message{Content: content}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c = dial(t, g)
+	c.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+	c.write(t, dasgo.FlagGatewayOpcodeResume, &dasgo.Resume{Token: "token", SessionID: sessionID, Seq: 1})
+
+	for i, content := range []string{"a", "b"} {
+		var message dasgo.Message
+		if err := json.Unmarshal(c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameMessageCreate, i+2).Data, &message); err != nil {
+			t.Fatal(err)
+		}
+
+		if message.Content != content {
+			t.Fatalf("expected message %q, got %q", content, message.Content)
+		}
+	}
+
+	c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameResumed, 4)
+
+	// events are sent to the resumed connection.
+	if err := g.Dispatch(dasgo.FlagGatewayEventNameMessageCreate, &dasgo.Message{Content: "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameMessageCreate, 5)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := g.WaitReady(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// a session is resumed by one connection at a time.
+	resumed := dial(t, g)
+	resumed.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+	resumed.write(t, dasgo.FlagGatewayOpcodeResume, &dasgo.Resume{Token: "token", SessionID: sessionID, Seq: 5})
+	resumed.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameResumed, 6)
+
+	if code, _ := c.readClose(t); code != dasgo.FlagGatewayCloseEventCodeUnknownError.Code {
+		t.Fatalf("expected the previous connection to be closed, got %d", code)
+	}
+
+	// a sequence ahead of the session is invalid.
+	invalid := dial(t, g)
+	invalid.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+	invalid.write(t, dasgo.FlagGatewayOpcodeResume, &dasgo.Resume{Token: "token", SessionID: sessionID, Seq: 100})
+
+	if code, _ := invalid.readClose(t); code != dasgo.FlagGatewayCloseEventCodeInvalidSeq.Code {
+		t.Fatalf("expected close code %d, got %d", dasgo.FlagGatewayCloseEventCodeInvalidSeq.Code, code)
+	}
+
+	// an unknown session is not resumable.
+	unknown := dial(t, g)
+	unknown.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+	unknown.write(t, dasgo.FlagGatewayOpcodeResume, &dasgo.Resume{Token: "token", SessionID: "unknown"})
+
+	if payload := unknown.expect(t, dasgo.FlagGatewayOpcodeInvalidSession, "", 0); string(payload.Data) != "false" {
+		t.Fatalf("expected a non-resumable Invalid Session, got %s", payload.Data)
+	}
+}
+
+func TestGatewayDisconnect(t *testing.T) {
+	tests := []struct {
+		code      dasgo.GatewayCloseEventCode
+		resumable bool
+	}{
+		{code: dasgo.FlagGatewayCloseEventCodeSessionTimed, resumable: true},
+		{code: dasgo.FlagGatewayCloseEventCodeAuthenticationFailed, resumable: false},
+	}
+
+	for _, test := range tests {
+		g := NewGateway(nil, "token")
+		defer g.Close()
+
+		c, sessionID := identify(t, g, 0)
+
+		g.Disconnect(test.code.Code)
+		if code, reason := c.readClose(t); code != test.code.Code || reason != test.code.Description {
+			t.Fatalf("expected close code %d (%s), got %d (%s)", test.code.Code, test.code.Description, code, reason)
+		}
+
+		c = dial(t, g)
+		c.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+		c.write(t, dasgo.FlagGatewayOpcodeResume, &dasgo.Resume{Token: "token", SessionID: sessionID, Seq: 1})
+
+		if test.resumable {
+			c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameResumed, 2)
+		} else if payload := c.expect(t, dasgo.FlagGatewayOpcodeInvalidSession, "", 0); string(payload.Data) != "false" {
+			t.Fatalf("expected the session to be invalidated by close code %d, got %s", test.code.Code, payload.Data)
+		}
+	}
+}
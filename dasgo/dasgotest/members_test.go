@@ -0,0 +1,405 @@
+package dasgotest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+// shard represents a Gateway connection of a bot, which dispatches the events it receives.
+type shard struct {
+	*wsClient
+
+	mu       sync.Mutex
+	requests []*dasgo.GuildRequestMembers
+
+	// sendCommand replaces the command that is sent to the Gateway (when non-nil).
+	sendCommand func(request *dasgo.GuildRequestMembers) error
+}
+
+// SendCommand sends a Gateway Command to the Gateway.
+func (s *shard) SendCommand(ctx context.Context, op int, command dasgo.Command) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if request, ok := command.(*dasgo.GuildRequestMembers); ok {
+		copied := *request
+		s.requests = append(s.requests, &copied)
+		if s.sendCommand != nil {
+			return s.sendCommand(request)
+		}
+	}
+
+	return s.send(op, command)
+}
+
+// guildRequests returns the IDs of the guilds that are requested with the shard.
+func (s *shard) guildRequests() []dasgo.Snowflake {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]dasgo.Snowflake, len(s.requests))
+	for i, request := range s.requests {
+		ids[i] = request.GuildID
+	}
+
+	return ids
+}
+
+// connect identifies a shard to a Gateway, and dispatches the events it receives with a dispatcher.
+func connect(t *testing.T, g *Gateway, d *dasgo.Dispatcher, shardID, shards int) *shard {
+	t.Helper()
+
+	c := dial(t, g)
+	c.expect(t, dasgo.FlagGatewayOpcodeHello, "", 0)
+
+	identify := &dasgo.Identify{Token: "Bot token", Intents: dasgo.BitFlag(dasgo.FlagIntentGUILD_MEMBERS)}
+	if shards > 1 {
+		identify.Shard = &[2]int{shardID, shards}
+	}
+
+	c.write(t, dasgo.FlagGatewayOpcodeIdentify, identify)
+	c.expect(t, dasgo.FlagGatewayOpcodeDispatch, dasgo.FlagGatewayEventNameReady, 1)
+
+	if err := c.conn.SetDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for {
+			opcode, data, err := c.next()
+			if err != nil || opcode == wsOpcodeClose {
+				return
+			}
+
+			payload := new(dasgo.GatewayPayload)
+			if json.Unmarshal(data, payload) != nil || payload.Op == nil || *payload.Op != dasgo.FlagGatewayOpcodeDispatch {
+				continue
+			}
+
+			if event, err := dasgo.DecodeEvent(payload.EventName, payload.Data); err == nil {
+				d.Dispatch(event)
+			}
+		}
+	}()
+
+	t.Cleanup(func() {
+		c.conn.Close()
+		<-done
+	})
+
+	return &shard{wsClient: c}
+}
+
+// memberWorld returns a World with a guild of n members, whose usernames are "a" or "b"
+// followed by an index.
+func memberWorld(n int) (*World, *dasgo.Guild) {
+	w := NewWorld("dasgo")
+	guild := w.AddGuild(&dasgo.Guild{Name: "guild"})
+	for i := 0; i < n; i++ {
+		w.AddMember(guild.ID, &dasgo.GuildMember{User: &dasgo.User{Username: fmt.Sprintf("%c%d", 'a'+i%2, i)}})
+	}
+
+	return w, guild
+}
+
+// memberChunker returns a MemberChunker with a shard connected to a Gateway of a World.
+func memberChunker(t *testing.T, w *World, mode dasgo.Flag) (*Gateway, *dasgo.MemberChunker, *shard) {
+	t.Helper()
+
+	g := NewGateway(w, "token")
+	t.Cleanup(g.Close)
+
+	d := dasgo.NewDispatcher(mode)
+	s := connect(t, g, d, 0, 1)
+
+	return g, dasgo.NewMemberChunker(d, s), s
+}
+
+// chunk returns a Guild Members Chunk event of a request.
+func chunk(request *dasgo.GuildRequestMembers, index, count int, members ...*dasgo.GuildMember) *dasgo.GuildMembersChunk {
+	if members == nil {
+		members = []*dasgo.GuildMember{}
+	}
+
+	return &dasgo.GuildMembersChunk{
+		GuildID:    request.GuildID,
+		Members:    members,
+		ChunkIndex: index,
+		ChunkCount: count,
+		Nonce:      request.Nonce,
+	}
+}
+
+func TestMemberChunkerRequest(t *testing.T) {
+	w, guild := memberWorld(2500)
+	g, chunker, s := memberChunker(t, w, dasgo.FlagDispatchModeSERIAL)
+
+	members, err := chunker.Request(context.Background(), &dasgo.GuildRequestMembers{GuildID: guild.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := len(w.members[guild.ID]); len(members.Members) != want || members.GuildID != guild.ID {
+		t.Fatalf("expected %d members from 3 chunks, got %d", want, len(members.Members))
+	}
+
+	// the request queries every member with a nonce.
+	request := s.requests[0]
+	if request.Nonce == nil || len(*request.Nonce) > dasgo.FlagGuildRequestMembersLimitNonce ||
+		request.Query == nil || *request.Query != "" {
+		t.Fatalf("expected a request with a nonce and an empty query, got %+v", request)
+	}
+
+	var received int
+	for _, payload := range g.Received() {
+		if *payload.Op == dasgo.FlagGatewayOpcodeRequestGuildMembers {
+			received++
+		}
+	}
+
+	if received != 1 {
+		t.Fatalf("expected 1 Request Guild Members payload, got %d", received)
+	}
+
+	// members that aren't found are returned.
+	members, err = chunker.Request(context.Background(), &dasgo.GuildRequestMembers{
+		GuildID: guild.ID,
+		UserIDs: []dasgo.Snowflake{members.Members[0].User.ID, 1},
+	})
+	if err != nil || len(members.Members) != 1 || len(members.NotFound) != 1 || members.NotFound[0] != 1 {
+		t.Fatalf("expected 1 member and 1 missing user, got %+v (%v)", members, err)
+	}
+
+	if _, err := chunker.Request(context.Background(), &dasgo.GuildRequestMembers{
+		GuildID: guild.ID,
+		UserIDs: make([]dasgo.Snowflake, dasgo.FlagGuildRequestMembersLimitUserIDs+1),
+	}); err == nil {
+		t.Fatal("expected an error for a request that exceeds the user ID limit")
+	}
+}
+
+func TestMemberChunkerNonce(t *testing.T) {
+	w, guild := memberWorld(100)
+	_, chunker, _ := memberChunker(t, w, dasgo.FlagDispatchModePARALLEL)
+
+	// concurrent requests of the same guild receive the chunks of their own nonce.
+	queries := []string{"a", "b", "a1", "b1"}
+	results := make([]*dasgo.GuildMembers, len(queries))
+	errs := make([]error, len(queries))
+
+	var wg sync.WaitGroup
+	for i := range queries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = chunker.Request(context.Background(), &dasgo.GuildRequestMembers{GuildID: guild.ID, Query: &queries[i]})
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, query := range queries {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+
+		var want int
+		for _, member := range w.members[guild.ID] {
+			if len(member.User.Username) >= len(query) && member.User.Username[:len(query)] == query {
+				want++
+			}
+		}
+
+		if len(results[i].Members) != want {
+			t.Fatalf("expected %d members for query %q, got %d", want, query, len(results[i].Members))
+		}
+
+		for _, member := range results[i].Members {
+			if member.User.Username[:len(query)] != query {
+				t.Fatalf("expected members matching query %q, got %q", query, member.User.Username)
+			}
+		}
+	}
+}
+
+func TestMemberChunkerStream(t *testing.T) {
+	w, guild := memberWorld(2)
+	g, chunker, s := memberChunker(t, w, dasgo.FlagDispatchModeSERIAL)
+
+	var members []*dasgo.GuildMember
+	for _, member := range w.members[guild.ID] {
+		members = append(members, member)
+	}
+
+	// the Gateway sends a duplicate chunk, and a chunk of another nonce.
+	other := "other"
+	s.sendCommand = func(request *dasgo.GuildRequestMembers) error {
+		for _, chunk := range []*dasgo.GuildMembersChunk{
+			chunk(request, 0, 2, members[0]),
+			chunk(request, 0, 2, members[0]),
+			chunk(&dasgo.GuildRequestMembers{GuildID: guild.ID, Nonce: &other}, 1, 2, members[1]),
+			chunk(request, 1, 2, members[1]),
+		} {
+			if err := g.Dispatch(dasgo.FlagGatewayEventNameGuildMembersChunk, chunk); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var chunks []int
+	err := chunker.Stream(context.Background(), &dasgo.GuildRequestMembers{GuildID: guild.ID}, func(chunk *dasgo.GuildMembersChunk) {
+		if chunk.Nonce == nil || *chunk.Nonce == other {
+			t.Errorf("expected a chunk of the request, got nonce %v", chunk.Nonce)
+		}
+
+		chunks = append(chunks, chunk.ChunkIndex)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !sort.IntsAreSorted(chunks) || len(chunks) != 2 {
+		t.Fatalf("expected chunks 0 and 1 once, got %v", chunks)
+	}
+}
+
+func TestMemberChunkerTimeout(t *testing.T) {
+	w, guild := memberWorld(2)
+	g, chunker, s := memberChunker(t, w, dasgo.FlagDispatchModeSERIAL)
+	chunker.Timeout = 50 * time.Millisecond
+
+	// the Gateway sends the first of two chunks.
+	s.sendCommand = func(request *dasgo.GuildRequestMembers) error {
+		return g.Dispatch(dasgo.FlagGatewayEventNameGuildMembersChunk, chunk(request, 0, 2))
+	}
+
+	var chunks int
+	err := chunker.Stream(context.Background(), &dasgo.GuildRequestMembers{GuildID: guild.ID}, func(*dasgo.GuildMembersChunk) { chunks++ })
+	if !errors.Is(err, dasgo.ErrMemberChunkTimeout) || chunks != 1 {
+		t.Fatalf("expected ErrMemberChunkTimeout after 1 chunk, got %d chunks (%v)", chunks, err)
+	}
+
+	// a canceled context stops waiting for chunks.
+	chunker.Timeout = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := chunker.Request(ctx, &dasgo.GuildRequestMembers{GuildID: guild.ID}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// a command that isn't sent is returned.
+	s.sendCommand = func(*dasgo.GuildRequestMembers) error { return errors.New("connection closed") }
+	if _, err := chunker.Request(context.Background(), &dasgo.GuildRequestMembers{GuildID: guild.ID}); err == nil {
+		t.Fatal("expected the send error")
+	}
+}
+
+func TestMemberChunkerHandler(t *testing.T) {
+	for _, mode := range []dasgo.Flag{dasgo.FlagDispatchModeSERIAL, dasgo.FlagDispatchModeGUILD, dasgo.FlagDispatchModePARALLEL} {
+		w, guild := memberWorld(1500)
+		g, chunker, _ := memberChunker(t, w, mode)
+
+		// members are requested from a handler, which blocks the queue of the guild's events.
+		result := make(chan int, 1)
+		dasgo.Handle(chunker.Dispatcher, func(e *dasgo.GuildCreate) {
+			members, err := chunker.Request(context.Background(), &dasgo.GuildRequestMembers{GuildID: e.ID})
+			if err != nil {
+				t.Error(err)
+			}
+
+			result <- len(members.Members)
+		})
+
+		if err := g.Dispatch(dasgo.FlagGatewayEventNameGuildCreate, guild); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case n := <-result:
+			if want := len(w.members[guild.ID]); n != want {
+				t.Fatalf("expected %d members in mode %d, got %d", want, mode, n)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out requesting members from a handler in mode %d", mode)
+		}
+	}
+}
+
+func TestMemberChunkerRequestGuilds(t *testing.T) {
+	w := NewWorld("dasgo")
+
+	var guilds []dasgo.Snowflake
+	for i := 0; i < 6; i++ {
+		guild := w.AddGuild(&dasgo.Guild{Name: fmt.Sprint("guild ", i)})
+		for j := 0; j < i; j++ {
+			w.AddMember(guild.ID, &dasgo.GuildMember{})
+		}
+
+		guilds = append(guilds, guild.ID)
+	}
+
+	g := NewGateway(w, "token")
+	g.Shards = 2
+	t.Cleanup(g.Close)
+
+	d := dasgo.NewDispatcher(dasgo.FlagDispatchModeSERIAL)
+	shards := []*shard{connect(t, g, d, 0, 2), connect(t, g, d, 1, 2)}
+	chunker := dasgo.NewMemberChunker(d, shards[0], shards[1])
+
+	counts := make(map[dasgo.Snowflake]int)
+	err := chunker.RequestGuilds(context.Background(), guilds, false, func(guildID dasgo.Snowflake, members *dasgo.GuildMembers, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		counts[guildID] = len(members.Members)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each guild is requested once with the shard that receives its events.
+	for _, id := range guilds {
+		if want := len(w.members[id]); counts[id] != want {
+			t.Fatalf("expected %d members in guild %d, got %d", want, id, counts[id])
+		}
+	}
+
+	for shardID, s := range shards {
+		for _, id := range s.guildRequests() {
+			if dasgo.ShardID(id, len(shards)) != shardID {
+				t.Fatalf("expected guild %d to be requested with shard %d", id, dasgo.ShardID(id, len(shards)))
+			}
+		}
+	}
+
+	if n := len(shards[0].guildRequests()) + len(shards[1].guildRequests()); n != len(guilds) {
+		t.Fatalf("expected %d requests, got %d", len(guilds), n)
+	}
+
+	// a canceled context stops the requests.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := chunker.RequestGuilds(ctx, guilds, false, func(dasgo.Snowflake, *dasgo.GuildMembers, error) {
+		t.Error("expected no results after the context is canceled")
+	}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
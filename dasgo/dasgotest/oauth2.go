@@ -0,0 +1,197 @@
+// Package dasgotest provides fake Discord servers for testing.
+package dasgotest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+// accessTokenExpiry represents the duration that an access token is valid for.
+const accessTokenExpiry = 7 * 24 * time.Hour
+
+// Grant represents an authorization that a user grants to the World's application
+// (as if the user approved an authorization URL), which is exchanged for an access token.
+type Grant struct {
+	// RedirectURI represents the redirect URI of the authorization, which must be
+	// sent with the authorization code.
+	RedirectURI string
+
+	Scopes []string
+
+	// GuildID represents the guild that the bot is added to (bot scope).
+	GuildID dasgo.Snowflake
+
+	// ChannelID represents the channel that an incoming webhook is created in (webhook.incoming scope).
+	ChannelID dasgo.Snowflake
+
+	// accessToken and refreshToken represent the tokens issued for the grant.
+	accessToken  string
+	refreshToken string
+}
+
+// tokenResponse represents a response from the OAuth2 token endpoint, which contains
+// the guild (bot scope) or webhook (webhook.incoming scope) of an authorization.
+type tokenResponse struct {
+	*dasgo.AccessTokenResponse
+	Guild   *dasgo.Guild   `json:"guild,omitempty"`
+	Webhook *dasgo.Webhook `json:"webhook,omitempty"`
+}
+
+// Authorize authorizes a grant and returns its authorization code, which is sent to the
+// redirect URI of the grant (i.e ?code=CODE&state=STATE).
+func (s *Server) Authorize(grant *Grant) string {
+	copied := *grant
+	copied.Scopes = append([]string(nil), grant.Scopes...)
+
+	code := token()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.grants[code] = &copied
+
+	return code
+}
+
+// ValidAccessToken returns whether an access token is issued and not revoked.
+func (s *Server) ValidAccessToken(accessToken string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grant, ok := s.tokens[accessToken]
+
+	return ok && grant.accessToken == accessToken
+}
+
+// oauth2Error returns an OAuth2 error response.
+func oauth2Error(status int, code string) (int, interface{}) {
+	return status, &dasgo.OAuth2Error{Code: code}
+}
+
+// authenticateClient returns whether a request is sent with the client credentials of the
+// World's application (in the form or with HTTP Basic authentication).
+func (s *Server) authenticateClient(r *request, form url.Values) bool {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = form.Get("client_id"), form.Get("client_secret")
+	}
+
+	return clientID == strconv.FormatUint(uint64(s.World.user.ID), 10) &&
+		(s.ClientSecret == "" || clientSecret == s.ClientSecret)
+}
+
+// token handles the OAuth2 token endpoint (Access Token Exchange, Refresh Token Exchange,
+// and Client Credentials Token Request).
+func (s *Server) token(r *request) (int, interface{}) {
+	form, err := url.ParseQuery(string(r.body))
+	if err != nil {
+		return oauth2Error(http.StatusBadRequest, "invalid_request")
+	}
+
+	if !s.authenticateClient(r, form) {
+		return oauth2Error(http.StatusUnauthorized, "invalid_client")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var grant *Grant
+	switch form.Get("grant_type") {
+	case dasgo.FlagOAuth2GrantTypeAuthorizationCode:
+		var ok bool
+		if grant, ok = s.grants[form.Get("code")]; !ok || grant.RedirectURI != form.Get("redirect_uri") {
+			return oauth2Error(http.StatusBadRequest, "invalid_grant")
+		}
+
+		delete(s.grants, form.Get("code"))
+
+	case dasgo.FlagOAuth2GrantTypeRefreshToken:
+		var ok bool
+		if grant, ok = s.tokens[form.Get("refresh_token")]; !ok || grant.refreshToken != form.Get("refresh_token") {
+			return oauth2Error(http.StatusBadRequest, "invalid_grant")
+		}
+
+		delete(s.tokens, grant.accessToken)
+		delete(s.tokens, grant.refreshToken)
+
+	case dasgo.FlagOAuth2GrantTypeClientCredentials:
+		grant = &Grant{Scopes: strings.Fields(form.Get("scope"))}
+
+	default:
+		return oauth2Error(http.StatusBadRequest, "unsupported_grant_type")
+	}
+
+	grant.accessToken = token()
+	s.tokens[grant.accessToken] = grant
+
+	response := &tokenResponse{
+		AccessTokenResponse: &dasgo.AccessTokenResponse{
+			AccessToken: grant.accessToken,
+			TokenType:   "Bearer",
+			ExpiresIn:   dasgo.Seconds(accessTokenExpiry),
+			Scope:       strings.Join(grant.Scopes, " "),
+		},
+	}
+
+	// client credentials are not refreshed.
+	if form.Get("grant_type") != dasgo.FlagOAuth2GrantTypeClientCredentials {
+		grant.refreshToken = token()
+		s.tokens[grant.refreshToken] = grant
+		response.RefreshToken = grant.refreshToken
+	}
+
+	// the guild and webhook of an authorization are only returned when it is exchanged.
+	if form.Get("grant_type") == dasgo.FlagOAuth2GrantTypeAuthorizationCode {
+		for _, scope := range grant.Scopes {
+			switch scope {
+			case dasgo.FlagOAuth2ScopeBot:
+				response.Guild = s.World.guild(grant.GuildID)
+			case dasgo.FlagOAuth2ScopeWebhookIncoming:
+				channel, ok := s.World.channels[grant.ChannelID]
+				if !ok {
+					return oauth2Error(http.StatusBadRequest, "invalid_grant")
+				}
+
+				name := "dasgo"
+				webhook := &dasgo.Webhook{ChannelID: &channel.ID, User: s.World.user, Name: &name}
+				if channel.GuildID != 0 {
+					guildID := channel.GuildID
+					webhook.GuildID = &guildID
+				}
+
+				s.World.createWebhook(webhook)
+				response.Webhook = webhook
+			}
+		}
+	}
+
+	return http.StatusOK, response
+}
+
+// revokeToken handles the OAuth2 token revocation endpoint.
+func (s *Server) revokeToken(r *request) (int, interface{}) {
+	form, err := url.ParseQuery(string(r.body))
+	if err != nil {
+		return oauth2Error(http.StatusBadRequest, "invalid_request")
+	}
+
+	if !s.authenticateClient(r, form) {
+		return oauth2Error(http.StatusUnauthorized, "invalid_client")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// revoking either token of a grant revokes both tokens (RFC 7009).
+	if grant, ok := s.tokens[form.Get("token")]; ok {
+		delete(s.tokens, grant.accessToken)
+		delete(s.tokens, grant.refreshToken)
+	}
+
+	return http.StatusOK, nil
+}
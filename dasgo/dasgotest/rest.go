@@ -0,0 +1,557 @@
+// Package dasgotest provides fake Discord servers for testing.
+package dasgotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+// Server represents a fake Discord REST API server that serves the endpoints of a World.
+//
+// Requests are made to Server.BaseURL() in place of dasgo.EndpointBaseURL.
+type Server struct {
+	*httptest.Server
+
+	// World represents the entities served by the server.
+	World *World
+
+	// Token represents the bot token required in the Authorization header (when non-empty).
+	Token string
+
+	// GatewayURL represents the URL returned from the Get Gateway endpoints.
+	GatewayURL string
+
+	// ClientSecret represents the client secret of the World's application, which is
+	// required by the OAuth2 token endpoints (when non-empty).
+	ClientSecret string
+
+	mu         sync.Mutex
+	handlers   map[string]http.HandlerFunc
+	rateLimits map[string]RateLimit
+	buckets    map[string]*bucket
+	queued     []*queuedRateLimit
+	requests   []*Request
+	grants     map[string]*Grant
+	tokens     map[string]*Grant
+}
+
+// Request represents a request received by a Server.
+type Request struct {
+	Method string
+
+	// Endpoint represents the matched endpoint (i.e dasgo.EndpointCreateMessage).
+	Endpoint string
+
+	// Params represents the path parameters of the endpoint (i.e "channel.id").
+	Params map[string]string
+
+	Header http.Header
+	Query  map[string][]string
+	Body   []byte
+}
+
+// RateLimit represents a rate limit applied to the requests of a route.
+//
+// Each route is limited per major parameter (channel, guild, or webhook).
+type RateLimit struct {
+	// Limit represents the number of requests allowed per Window.
+	Limit  int
+	Window time.Duration
+}
+
+// bucket represents the state of a rate limit bucket.
+type bucket struct {
+	hash      string
+	remaining int
+	reset     time.Time
+}
+
+// queuedRateLimit represents a 429 response returned from the next matching request.
+type queuedRateLimit struct {
+	method     string
+	endpoint   string
+	retryAfter time.Duration
+	global     bool
+}
+
+// NewServer starts and returns a new Server for a World (or a new World when nil).
+//
+// The caller should call Close when finished to shut it down.
+func NewServer(world *World, token string) *Server {
+	if world == nil {
+		world = NewWorld("dasgo")
+	}
+
+	s := &Server{
+		World:      world,
+		Token:      token,
+		GatewayURL: "wss://gateway.discord.gg",
+		handlers:   make(map[string]http.HandlerFunc),
+		rateLimits: make(map[string]RateLimit),
+		buckets:    make(map[string]*bucket),
+		grants:     make(map[string]*Grant),
+		tokens:     make(map[string]*Grant),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// BaseURL returns the URL used in place of dasgo.EndpointBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/v" + dasgo.VersionDiscordAPI + "/"
+}
+
+// routeKey returns the key of a route.
+func routeKey(method, endpoint string) string {
+	return method + " " + endpoint
+}
+
+// Handle replaces the handler of an endpoint (i.e dasgo.EndpointGetGuildAuditLog),
+// which is used to script responses or serve endpoints the World does not model.
+func (s *Server) Handle(method, endpoint string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[routeKey(method, endpoint)] = handler
+}
+
+// SetRateLimit sets the rate limit of an endpoint, or every endpoint when method and endpoint are empty.
+func (s *Server) SetRateLimit(method, endpoint string, rateLimit RateLimit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimits[routeKey(method, endpoint)] = rateLimit
+}
+
+// QueueRateLimit responds to the next request of an endpoint (or any endpoint when method
+// and endpoint are empty) with a 429 Too Many Requests response.
+func (s *Server) QueueRateLimit(method, endpoint string, retryAfter time.Duration, global bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queued = append(s.queued, &queuedRateLimit{method: method, endpoint: endpoint, retryAfter: retryAfter, global: global})
+}
+
+// Requests returns the requests received by the server.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request(nil), s.requests...)
+}
+
+// route represents an endpoint served by a Server.
+type route struct {
+	method   string
+	endpoint string
+	segments []string
+	params   int
+	handler  func(s *Server, r *request) (int, interface{})
+}
+
+// request represents a request to a route.
+type request struct {
+	*http.Request
+	params map[string]string
+	body   []byte
+}
+
+// routes represents the routes served by a Server, sorted by specificity.
+var routes []*route
+
+// addRoute adds a route.
+func addRoute(method, endpoint string, handler func(s *Server, r *request) (int, interface{})) {
+	r := &route{method: method, endpoint: endpoint, segments: strings.Split(endpoint, "/"), handler: handler}
+	for _, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") {
+			r.params++
+		}
+	}
+
+	routes = append(routes, r)
+
+	// literal segments take precedence over parameters (i.e members/@me over members/{user.id}).
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].params < routes[j].params })
+}
+
+// match returns the path parameters of a path when it matches the route.
+func (r *route) match(path []string) (map[string]string, bool) {
+	if len(path) != len(r.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string, r.params)
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") {
+			params[strings.Trim(segment, "{}")] = path[i]
+			continue
+		}
+
+		if segment != path[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// match returns the route of a request path along with its parameters and handler override.
+//
+// Handler overrides take precedence over routes, so they're able to serve endpoints without a route.
+// allowed is true when the path matches a route with a different method.
+func (s *Server) match(method string, path []string) (matched *route, params map[string]string, handler http.HandlerFunc, allowed bool) {
+	for key, override := range s.handlers {
+		overrideMethod, endpoint, _ := strings.Cut(key, " ")
+		overrideRoute := &route{method: overrideMethod, endpoint: endpoint, segments: strings.Split(endpoint, "/")}
+		if p, ok := overrideRoute.match(path); ok && overrideMethod == method {
+			return overrideRoute, p, override, true
+		}
+	}
+
+	for _, route := range routes {
+		p, ok := route.match(path)
+		if !ok {
+			continue
+		}
+
+		allowed = true
+		if route.method == method {
+			return route, p, nil, true
+		}
+	}
+
+	return nil, nil, nil, allowed
+}
+
+// serveHTTP serves a request.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	prefix := "/api/v" + dasgo.VersionDiscordAPI + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+	s.mu.Lock()
+	matched, params, handler, allowed := s.match(r.Method, path)
+	s.mu.Unlock()
+
+	if matched == nil {
+		if allowed {
+			writeError(w, http.StatusMethodNotAllowed)
+		} else {
+			writeError(w, http.StatusNotFound)
+		}
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, &Request{
+		Method:   r.Method,
+		Endpoint: matched.endpoint,
+		Params:   params,
+		Header:   r.Header.Clone(),
+		Query:    r.URL.Query(),
+		Body:     body,
+	})
+	s.mu.Unlock()
+
+	// webhook and OAuth2 token endpoints are authorized by their token and client credentials.
+	if s.Token != "" && !strings.Contains(matched.endpoint, "{webhook.token}") &&
+		!strings.HasPrefix(matched.endpoint, dasgo.EndpointTokenURL) &&
+		r.Header.Get("Authorization") != "Bot "+s.Token {
+		writeError(w, http.StatusUnauthorized)
+		return
+	}
+
+	if !s.rateLimit(w, matched, params) {
+		return
+	}
+
+	if handler != nil {
+		handler(w, r)
+		return
+	}
+
+	s.World.mu.Lock()
+	status, response := matched.handler(s, &request{Request: r, params: params, body: body})
+	s.World.mu.Unlock()
+
+	writeJSON(w, status, response)
+}
+
+// rateLimit applies the rate limits of a route and returns whether the request is allowed.
+func (s *Server) rateLimit(w http.ResponseWriter, matched *route, params map[string]string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, queued := range s.queued {
+		if queued.method == "" && queued.endpoint == "" ||
+			queued.method == matched.method && queued.endpoint == matched.endpoint {
+			s.queued = append(s.queued[:i], s.queued[i+1:]...)
+			scope := "user"
+			if queued.global {
+				scope = "global"
+				w.Header().Set(dasgo.FlagRateLimitHeaderGlobal, "true")
+			}
+
+			writeTooManyRequests(w, queued.retryAfter, queued.global, scope)
+
+			return false
+		}
+	}
+
+	key := routeKey(matched.method, matched.endpoint)
+	limit, ok := s.rateLimits[key]
+	if !ok {
+		if limit, ok = s.rateLimits[routeKey("", "")]; !ok {
+			return true
+		}
+	}
+
+	major := params["channel.id"] + params["guild.id"] + params["webhook.id"] + params["webhook.token"]
+	b, ok := s.buckets[key+" "+major]
+	now := time.Now()
+	if !ok {
+		hash := fnv.New64a()
+		hash.Write([]byte(key))
+		b = &bucket{hash: strconv.FormatUint(hash.Sum64(), 16)}
+		s.buckets[key+" "+major] = b
+	}
+
+	if !now.Before(b.reset) {
+		b.remaining = limit.Limit
+		b.reset = now.Add(limit.Window)
+	}
+
+	resetAfter := b.reset.Sub(now)
+	w.Header().Set(dasgo.FlagRateLimitHeaderLimit, strconv.Itoa(limit.Limit))
+	w.Header().Set(dasgo.FlagRateLimitHeaderReset, strconv.FormatFloat(float64(b.reset.UnixMilli())/1000, 'f', 3, 64))
+	w.Header().Set(dasgo.FlagRateLimitHeaderResetAfter, strconv.FormatFloat(resetAfter.Seconds(), 'f', 3, 64))
+	w.Header().Set(dasgo.FlagRateLimitHeaderBucket, b.hash)
+
+	if b.remaining <= 0 {
+		w.Header().Set(dasgo.FlagRateLimitHeaderRemaining, "0")
+		writeTooManyRequests(w, resetAfter, false, "user")
+
+		return false
+	}
+
+	b.remaining--
+	w.Header().Set(dasgo.FlagRateLimitHeaderRemaining, strconv.Itoa(b.remaining))
+
+	return true
+}
+
+// writeTooManyRequests writes a 429 Too Many Requests response.
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration, global bool, scope string) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.Header().Set(dasgo.FlagRateLimitHeaderScope, scope)
+	writeJSON(w, http.StatusTooManyRequests, &dasgo.RateLimitResponse{
+		Message:    "You are being rate limited.",
+		RetryAfter: retryAfter.Seconds(),
+		Global:     global,
+	})
+}
+
+// writeJSON writes a JSON response, or an empty response when the body is nil.
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		status, body = httpError(http.StatusInternalServerError)
+		data, _ = json.Marshal(body)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// writeError writes a general error response for an HTTP status code.
+func writeError(w http.ResponseWriter, status int) {
+	status, body := httpError(status)
+	writeJSON(w, status, body)
+}
+
+// httpError returns a general error response for an HTTP status code.
+func httpError(status int) (int, interface{}) {
+	return status, &dasgo.ErrorResponse{Message: fmt.Sprintf("%d: %s", status, http.StatusText(status))}
+}
+
+// apiError returns an error response with a JSON Error Code.
+func apiError(status, code int) (int, interface{}) {
+	return status, &dasgo.ErrorResponse{Code: code, Message: dasgo.JSONErrorCodes[code]}
+}
+
+// formError returns an Invalid Form Body error response for an error (i.e dasgo.ValidationErrors).
+func formError(err error) (int, interface{}) {
+	errs := make(map[string]interface{})
+
+	var validationErrors dasgo.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		validationErrors = dasgo.ValidationErrors{{Message: err.Error()}}
+	}
+
+	for _, validationError := range validationErrors {
+		node := errs
+		for _, key := range pathKeys(validationError.Path) {
+			child, ok := node[key].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[key] = child
+			}
+
+			node = child
+		}
+
+		fieldErrors, _ := node["_errors"].([]interface{})
+		node["_errors"] = append(fieldErrors, map[string]string{"code": "BASE_TYPE_INVALID", "message": validationError.Message})
+	}
+
+	data, _ := json.Marshal(errs)
+	status, body := apiError(http.StatusBadRequest, 50035)
+	body.(*dasgo.ErrorResponse).Message = "Invalid Form Body"
+	body.(*dasgo.ErrorResponse).Errors = data
+
+	return status, body
+}
+
+// pathKeyRegex matches the keys of a validation error path (i.e options[1].name).
+var pathKeyRegex = regexp.MustCompile(`[^.\[\]]+`)
+
+// pathKeys returns the keys of a validation error path.
+func pathKeys(path string) []string {
+	return pathKeyRegex.FindAllString(path, -1)
+}
+
+// snowflake returns the snowflake of a path parameter.
+func (r *request) snowflake(param string) dasgo.Snowflake {
+	id, _ := strconv.ParseUint(r.params[param], 10, 64)
+	return dasgo.Snowflake(id)
+}
+
+// querySnowflake returns the snowflake of a query parameter, or nil when it's not present.
+func (r *request) querySnowflake(param string) *dasgo.Snowflake {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	snowflake := dasgo.Snowflake(id)
+
+	return &snowflake
+}
+
+// queryInt returns the integer of a query parameter clamped to [min, max], or def when it's not present.
+func (r *request) queryInt(param string, def, min, max int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(param))
+	if err != nil {
+		return def
+	}
+
+	if value < min {
+		return min
+	}
+
+	if value > max {
+		return max
+	}
+
+	return value
+}
+
+// payload returns the JSON payload of the request, which is contained in
+// the payload_json field of a multipart/form-data request.
+func (r *request) payload() []byte {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.body
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil
+	}
+
+	return []byte(r.FormValue("payload_json"))
+}
+
+// decode decodes the JSON payload of the request.
+func (r *request) decode(v interface{}) error {
+	if err := json.Unmarshal(r.payload(), v); err != nil {
+		return fmt.Errorf("error decoding request body: %w", err)
+	}
+
+	return nil
+}
+
+// patch applies the JSON payload of the request to a copy of an entity.
+//
+// Fields that are not present in the payload are retained, while null fields are cleared.
+func patch[T any](r *request, entity *T) (*T, error) {
+	current, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding entity: %w", err)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(current, &fields); err != nil {
+		return nil, fmt.Errorf("error decoding entity: %w", err)
+	}
+
+	var updates map[string]json.RawMessage
+	if err := r.decode(&updates); err != nil {
+		return nil, err
+	}
+
+	for key, value := range updates {
+		fields[key] = value
+	}
+
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding entity: %w", err)
+	}
+
+	patched := new(T)
+	if err := json.Unmarshal(merged, patched); err != nil {
+		return nil, fmt.Errorf("error decoding request body: %w", err)
+	}
+
+	return patched, nil
+}
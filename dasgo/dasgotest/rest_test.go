@@ -0,0 +1,276 @@
+package dasgotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+// do sends a request to an endpoint of a server with the server's bot token.
+func do(t *testing.T, s *Server, method, path string, body interface{}) (*http.Response, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, s.BaseURL()+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Authorization", "Bot "+s.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp, data
+}
+
+// idString returns the string of a snowflake.
+func idString(id dasgo.Snowflake) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// endpoint returns the path of an endpoint with its parameters replaced.
+func endpoint(e string, params ...string) string {
+	return strings.NewReplacer(params...).Replace(e)
+}
+
+func TestServerAuthorization(t *testing.T) {
+	s := NewServer(nil, "token")
+	defer s.Close()
+
+	if resp, _ := do(t, s, http.MethodGet, dasgo.EndpointGetCurrentUser, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, s.BaseURL()+dasgo.EndpointGetCurrentUser, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Authorization", "Bot wrong")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a wrong token, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	s := NewServer(nil, "token")
+	defer s.Close()
+
+	a := s.World.AddChannel(&dasgo.Channel{Name: "a"})
+	b := s.World.AddChannel(&dasgo.Channel{Name: "b"})
+	s.SetRateLimit(http.MethodGet, dasgo.EndpointGetChannel, RateLimit{Limit: 2, Window: time.Minute})
+
+	path := func(channel *dasgo.Channel) string {
+		return endpoint(dasgo.EndpointGetChannel, "{channel.id}", idString(channel.ID))
+	}
+
+	var bucket string
+	for i, remaining := range []string{"1", "0"} {
+		resp, _ := do(t, s, http.MethodGet, path(a), nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected request %d to succeed, got %d", i, resp.StatusCode)
+		}
+
+		if got := resp.Header.Get(dasgo.FlagRateLimitHeaderRemaining); got != remaining {
+			t.Errorf("expected %s remaining requests, got %s", remaining, got)
+		}
+
+		if got := resp.Header.Get(dasgo.FlagRateLimitHeaderLimit); got != "2" {
+			t.Errorf("expected a limit of 2, got %s", got)
+		}
+
+		bucket = resp.Header.Get(dasgo.FlagRateLimitHeaderBucket)
+	}
+
+	resp, data := do(t, s, http.MethodGet, path(a), nil)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", resp.StatusCode)
+	}
+
+	var rateLimited dasgo.RateLimitResponse
+	if err := json.Unmarshal(data, &rateLimited); err != nil {
+		t.Fatal(err)
+	}
+
+	if rateLimited.Global || rateLimited.RetryAfter <= 0 || resp.Header.Get("Retry-After") == "" ||
+		resp.Header.Get(dasgo.FlagRateLimitHeaderScope) != "user" {
+		t.Errorf("expected a user rate limit with a retry after, got %s (%v)", data, resp.Header)
+	}
+
+	// each major parameter has its own bucket, which shares the hash of the route.
+	resp, _ = do(t, s, http.MethodGet, path(b), nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the bucket of another channel to be separate, got %d", resp.StatusCode)
+	}
+
+	if got := resp.Header.Get(dasgo.FlagRateLimitHeaderBucket); got != bucket || bucket == "" {
+		t.Errorf("expected bucket %q, got %q", bucket, got)
+	}
+
+	// other routes aren't limited.
+	if resp, _ := do(t, s, http.MethodGet, dasgo.EndpointGetCurrentUser, nil); resp.StatusCode != http.StatusOK ||
+		resp.Header.Get(dasgo.FlagRateLimitHeaderBucket) != "" {
+		t.Fatalf("expected an unlimited route, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerQueueRateLimit(t *testing.T) {
+	s := NewServer(nil, "token")
+	defer s.Close()
+
+	s.QueueRateLimit("", "", 1500*time.Millisecond, true)
+
+	resp, data := do(t, s, http.MethodGet, dasgo.EndpointGetCurrentUser, nil)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", resp.StatusCode)
+	}
+
+	if resp.Header.Get(dasgo.FlagRateLimitHeaderGlobal) != "true" || resp.Header.Get(dasgo.FlagRateLimitHeaderScope) != "global" ||
+		resp.Header.Get("Retry-After") != "2" {
+		t.Errorf("expected a global rate limit, got %v", resp.Header)
+	}
+
+	var rateLimited dasgo.RateLimitResponse
+	if err := json.Unmarshal(data, &rateLimited); err != nil || !rateLimited.Global || rateLimited.RetryAfter != 1.5 {
+		t.Errorf("expected a global rate limit of 1.5 seconds, got %s (%v)", data, err)
+	}
+
+	// a queued rate limit applies to a single request.
+	if resp, _ := do(t, s, http.MethodGet, dasgo.EndpointGetCurrentUser, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerInvalidFormBody(t *testing.T) {
+	s := NewServer(nil, "token")
+	defer s.Close()
+
+	guild := s.World.AddGuild(&dasgo.Guild{Name: "guild"})
+	path := endpoint(dasgo.EndpointCreateGuildChannel, "{guild.id}", idString(guild.ID))
+
+	resp, data := do(t, s, http.MethodPost, path, map[string]interface{}{"name": ""})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+
+	var errorResponse dasgo.ErrorResponse
+	if err := json.Unmarshal(data, &errorResponse); err != nil {
+		t.Fatal(err)
+	}
+
+	if errorResponse.Code != 50035 || errorResponse.Message != "Invalid Form Body" {
+		t.Errorf("expected an Invalid Form Body error, got %d: %s", errorResponse.Code, errorResponse.Message)
+	}
+
+	var errs struct {
+		Name struct {
+			Errors []struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"_errors"`
+		} `json:"name"`
+	}
+
+	if err := json.Unmarshal(errorResponse.Errors, &errs); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs.Name.Errors) != 1 || errs.Name.Errors[0].Code != "BASE_TYPE_INVALID" || errs.Name.Errors[0].Message == "" {
+		t.Fatalf("expected an error at name, got %s", errorResponse.Errors)
+	}
+
+	// nested paths are nested objects.
+	status, body := formError(dasgo.ValidationErrors{{Path: "options[1].name", Message: "invalid"}})
+	data, _ = json.Marshal(body)
+	if want := `"errors":{"options":{"1":{"name":{"_errors":[{"code":"BASE_TYPE_INVALID","message":"invalid"}]}}}}`; status != http.StatusBadRequest ||
+		!strings.Contains(string(data), want) {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+}
+
+func TestServerPatch(t *testing.T) {
+	s := NewServer(nil, "token")
+	defer s.Close()
+
+	topic, nsfw := "topic", true
+	channel := s.World.AddChannel(&dasgo.Channel{Name: "general", Topic: &topic, NSFW: &nsfw})
+	path := endpoint(dasgo.EndpointModifyChannel, "{channel.id}", idString(channel.ID))
+
+	resp, data := do(t, s, http.MethodPatch, path, map[string]interface{}{
+		"id":    "1",
+		"name":  "renamed",
+		"topic": nil,
+	})
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, data)
+	}
+
+	modified := s.World.Channel(channel.ID)
+	if modified == nil {
+		t.Fatal("expected the channel to retain its ID")
+	}
+
+	if modified.Name != "renamed" {
+		t.Errorf("expected the name to be modified, got %q", modified.Name)
+	}
+
+	if modified.Topic != nil {
+		t.Errorf("expected a null topic to be cleared, got %q", *modified.Topic)
+	}
+
+	if modified.NSFW == nil || !*modified.NSFW {
+		t.Error("expected an absent field to be retained")
+	}
+
+	if resp, _ := do(t, s, http.MethodPatch, endpoint(dasgo.EndpointModifyChannel, "{channel.id}", "1"), nil); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown channel, got %d", resp.StatusCode)
+	}
+}
+
+func TestWorldSnowflake(t *testing.T) {
+	w := NewWorld("dasgo")
+
+	// more snowflakes than the 12-bit increment are generated within the same millisecond.
+	last := dasgo.Snowflake(0)
+	for i := 0; i < 10000; i++ {
+		id := w.snowflake()
+		if id <= last {
+			t.Fatalf("expected snowflake %d to be greater than %d", id, last)
+		}
+
+		last = id
+	}
+}
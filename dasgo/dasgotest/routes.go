@@ -0,0 +1,1757 @@
+// Package dasgotest provides fake Discord servers for testing.
+package dasgotest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+const (
+	// maxContentLength represents the maximum number of characters in the content of a message.
+	maxContentLength = 2000
+
+	// maxPins represents the maximum number of pinned messages in a channel.
+	maxPins = 50
+
+	// maxRoles represents the maximum number of roles in a guild.
+	maxRoles = 250
+
+	// bulkDeleteAge represents the maximum age of a message deleted by Bulk Delete Messages.
+	bulkDeleteAge = 14 * 24 * time.Hour
+)
+
+func init() {
+	// users
+	addRoute(http.MethodGet, dasgo.EndpointGetCurrentUser, (*Server).getCurrentUser)
+	addRoute(http.MethodGet, dasgo.EndpointGetUser, (*Server).getUser)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyCurrentUser, (*Server).modifyCurrentUser)
+	addRoute(http.MethodGet, dasgo.EndpointGetCurrentUserGuilds, (*Server).getCurrentUserGuilds)
+	addRoute(http.MethodGet, dasgo.EndpointGetCurrentUserGuildMember, (*Server).getCurrentUserGuildMember)
+	addRoute(http.MethodDelete, dasgo.EndpointLeaveGuild, (*Server).leaveGuild)
+	addRoute(http.MethodPost, dasgo.EndpointCreateDM, (*Server).createDM)
+
+	// gateway
+	addRoute(http.MethodGet, dasgo.EndpointGetGateway, (*Server).getGateway)
+	addRoute(http.MethodGet, dasgo.EndpointGetGatewayBot, (*Server).getGatewayBot)
+
+	// guilds
+	addRoute(http.MethodPost, dasgo.EndpointCreateGuild, (*Server).createGuild)
+	addRoute(http.MethodGet, dasgo.EndpointGetGuild, (*Server).getGuild)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyGuild, (*Server).modifyGuild)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteGuild, (*Server).deleteGuild)
+	addRoute(http.MethodGet, dasgo.EndpointGetGuildChannels, (*Server).getGuildChannels)
+	addRoute(http.MethodPost, dasgo.EndpointCreateGuildChannel, (*Server).createGuildChannel)
+	addRoute(http.MethodGet, dasgo.EndpointListGuildMembers, (*Server).listGuildMembers)
+	addRoute(http.MethodGet, dasgo.EndpointSearchGuildMembers, (*Server).searchGuildMembers)
+	addRoute(http.MethodGet, dasgo.EndpointGetGuildMember, (*Server).getGuildMember)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyGuildMember, (*Server).modifyGuildMember)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyCurrentMember, (*Server).modifyCurrentMember)
+	addRoute(http.MethodDelete, dasgo.EndpointRemoveGuildMember, (*Server).removeGuildMember)
+	addRoute(http.MethodPut, dasgo.EndpointAddGuildMemberRole, (*Server).addGuildMemberRole)
+	addRoute(http.MethodDelete, dasgo.EndpointRemoveGuildMemberRole, (*Server).removeGuildMemberRole)
+	addRoute(http.MethodGet, dasgo.EndpointGetGuildRoles, (*Server).getGuildRoles)
+	addRoute(http.MethodPost, dasgo.EndpointCreateGuildRole, (*Server).createGuildRole)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyGuildRole, (*Server).modifyGuildRole)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteGuildRole, (*Server).deleteGuildRole)
+	addRoute(http.MethodGet, dasgo.EndpointGetGuildWebhooks, (*Server).getGuildWebhooks)
+
+	// channels
+	addRoute(http.MethodGet, dasgo.EndpointGetChannel, (*Server).getChannel)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyChannel, (*Server).modifyChannel)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteCloseChannel, (*Server).deleteChannel)
+	addRoute(http.MethodGet, dasgo.EndpointGetChannelMessages, (*Server).getChannelMessages)
+	addRoute(http.MethodGet, dasgo.EndpointGetChannelMessage, (*Server).getChannelMessage)
+	addRoute(http.MethodPost, dasgo.EndpointCreateMessage, (*Server).createChannelMessage)
+	addRoute(http.MethodPatch, dasgo.EndpointEditMessage, (*Server).editChannelMessage)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteMessage, (*Server).deleteChannelMessage)
+	addRoute(http.MethodPost, dasgo.EndpointBulkDeleteMessages, (*Server).bulkDeleteMessages)
+	addRoute(http.MethodGet, dasgo.EndpointGetPinnedMessages, (*Server).getPinnedMessages)
+	addRoute(http.MethodPut, dasgo.EndpointPinMessage, (*Server).pinMessage)
+	addRoute(http.MethodDelete, dasgo.EndpointUnpinMessage, (*Server).unpinMessage)
+	addRoute(http.MethodPost, dasgo.EndpointTriggerTypingIndicator, (*Server).triggerTypingIndicator)
+	addRoute(http.MethodPost, dasgo.EndpointCreateWebhook, (*Server).createWebhook)
+	addRoute(http.MethodGet, dasgo.EndpointGetChannelWebhooks, (*Server).getChannelWebhooks)
+
+	// webhooks
+	addRoute(http.MethodGet, dasgo.EndpointGetWebhook, (*Server).getWebhook)
+	addRoute(http.MethodGet, dasgo.EndpointGetWebhookwithToken, (*Server).getWebhook)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyWebhook, (*Server).modifyWebhook)
+	addRoute(http.MethodPatch, dasgo.EndpointModifyWebhookwithToken, (*Server).modifyWebhook)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteWebhook, (*Server).deleteWebhook)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteWebhookwithToken, (*Server).deleteWebhook)
+	addRoute(http.MethodPost, dasgo.EndpointExecuteWebhook, (*Server).executeWebhook)
+	addRoute(http.MethodGet, dasgo.EndpointGetWebhookMessage, (*Server).getWebhookMessage)
+	addRoute(http.MethodPatch, dasgo.EndpointEditWebhookMessage, (*Server).editWebhookMessage)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteWebhookMessage, (*Server).deleteWebhookMessage)
+
+	// application commands
+	addRoute(http.MethodGet, dasgo.EndpointGetGlobalApplicationCommands, (*Server).getCommands)
+	addRoute(http.MethodPost, dasgo.EndpointCreateGlobalApplicationCommand, (*Server).createCommand)
+	addRoute(http.MethodPut, dasgo.EndpointBulkOverwriteGlobalApplicationCommands, (*Server).bulkOverwriteCommands)
+	addRoute(http.MethodGet, dasgo.EndpointGetGlobalApplicationCommand, (*Server).getCommand)
+	addRoute(http.MethodPatch, dasgo.EndpointEditGlobalApplicationCommand, (*Server).editCommand)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteGlobalApplicationCommand, (*Server).deleteCommand)
+	addRoute(http.MethodGet, dasgo.EndpointGetGuildApplicationCommands, (*Server).getCommands)
+	addRoute(http.MethodPost, dasgo.EndpointCreateGuildApplicationCommand, (*Server).createCommand)
+	addRoute(http.MethodPut, dasgo.EndpointBulkOverwriteGuildApplicationCommands, (*Server).bulkOverwriteCommands)
+	addRoute(http.MethodGet, dasgo.EndpointGetGuildApplicationCommand, (*Server).getCommand)
+	addRoute(http.MethodPatch, dasgo.EndpointEditGuildApplicationCommand, (*Server).editCommand)
+	addRoute(http.MethodDelete, dasgo.EndpointDeleteGuildApplicationCommand, (*Server).deleteCommand)
+
+	// oauth2
+	addRoute(http.MethodPost, dasgo.EndpointTokenURL, (*Server).token)
+	addRoute(http.MethodPost, dasgo.EndpointTokenRevocationURL, (*Server).revokeToken)
+}
+
+// Handlers are called while the World is locked, and must not modify the entities of the World
+// in place: Entities are replaced with modified copies, so responses are encoded without a lock.
+
+// validationError returns ValidationErrors for a single violation.
+func validationError(path, format string, a ...interface{}) dasgo.ValidationErrors {
+	return dasgo.ValidationErrors{{Path: path, Message: fmt.Sprintf(format, a...)}}
+}
+
+// snowflakes returns a set of snowflakes.
+func snowflakes(ids []*dasgo.Snowflake) map[dasgo.Snowflake]bool {
+	set := make(map[dasgo.Snowflake]bool, len(ids))
+	for _, id := range ids {
+		if id != nil {
+			set[*id] = true
+		}
+	}
+
+	return set
+}
+
+// reverse reverses a slice in place.
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// getCurrentUser handles Get Current User.
+func (s *Server) getCurrentUser(r *request) (int, interface{}) {
+	return http.StatusOK, s.World.user
+}
+
+// getUser handles Get User.
+func (s *Server) getUser(r *request) (int, interface{}) {
+	user, ok := s.World.users[r.snowflake("user.id")]
+	if !ok {
+		return apiError(http.StatusNotFound, 10013)
+	}
+
+	return http.StatusOK, user
+}
+
+// modifyCurrentUser handles Modify Current User.
+func (s *Server) modifyCurrentUser(r *request) (int, interface{}) {
+	user, err := patch(r, s.World.user)
+	if err != nil {
+		return formError(err)
+	}
+
+	user.ID = s.World.user.ID
+	s.World.user = user
+	s.World.users[user.ID] = user
+
+	return http.StatusOK, user
+}
+
+// partialGuild represents the partial guild returned from Get Current User Guilds.
+type partialGuild struct {
+	ID          dasgo.Snowflake `json:"id"`
+	Name        string          `json:"name"`
+	Icon        string          `json:"icon"`
+	Owner       bool            `json:"owner"`
+	Permissions string          `json:"permissions"`
+	Features    []*string       `json:"features"`
+}
+
+// permissions returns the permissions of a guild member.
+func (w *World) permissions(guildID, userID dasgo.Snowflake) string {
+	var permissions uint64
+	if w.guilds[guildID].OwnerID == userID {
+		permissions |= dasgo.FlagBitwisePermissionADMINISTRATOR
+	}
+
+	roles := []dasgo.Snowflake{guildID}
+	if member, ok := w.members[guildID][userID]; ok {
+		for _, id := range member.Roles {
+			roles = append(roles, *id)
+		}
+	}
+
+	for _, id := range roles {
+		if role, ok := w.roles[guildID][id]; ok {
+			bits, _ := strconv.ParseUint(role.Permissions, 10, 64)
+			permissions |= bits
+		}
+	}
+
+	return strconv.FormatUint(permissions, 10)
+}
+
+// getCurrentUserGuilds handles Get Current User Guilds.
+func (s *Server) getCurrentUserGuilds(r *request) (int, interface{}) {
+	before, after := r.querySnowflake("before"), r.querySnowflake("after")
+	limit := r.queryInt("limit", 200, 1, 200)
+
+	guilds := []*partialGuild{}
+	for _, guild := range values(s.World.guilds) {
+		if _, ok := s.World.members[guild.ID][s.World.user.ID]; !ok ||
+			before != nil && guild.ID >= *before ||
+			after != nil && guild.ID <= *after {
+			continue
+		}
+
+		guilds = append(guilds, &partialGuild{
+			ID:          guild.ID,
+			Name:        guild.Name,
+			Icon:        guild.Icon,
+			Owner:       guild.OwnerID == s.World.user.ID,
+			Permissions: s.World.permissions(guild.ID, s.World.user.ID),
+			Features:    guild.Features,
+		})
+	}
+
+	if len(guilds) > limit {
+		if before != nil {
+			guilds = guilds[len(guilds)-limit:]
+		} else {
+			guilds = guilds[:limit]
+		}
+	}
+
+	return http.StatusOK, guilds
+}
+
+// getCurrentUserGuildMember handles Get Current User Guild Member.
+func (s *Server) getCurrentUserGuildMember(r *request) (int, interface{}) {
+	return s.member(r.snowflake("guild.id"), s.World.user.ID)
+}
+
+// leaveGuild handles Leave Guild.
+func (s *Server) leaveGuild(r *request) (int, interface{}) {
+	guild, ok := s.World.guilds[r.snowflake("guild.id")]
+	if !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	if guild.OwnerID == s.World.user.ID {
+		return apiError(http.StatusBadRequest, 50055)
+	}
+
+	delete(s.World.members[guild.ID], s.World.user.ID)
+
+	return http.StatusNoContent, nil
+}
+
+// createDM handles Create DM.
+func (s *Server) createDM(r *request) (int, interface{}) {
+	var body dasgo.CreateDM
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	recipient, ok := s.World.users[body.RecipientID]
+	if !ok {
+		return apiError(http.StatusNotFound, 10013)
+	}
+
+	for _, channel := range values(s.World.channels) {
+		if *channel.Type == dasgo.FlagChannelTypeDM && len(channel.Recipients) == 1 && channel.Recipients[0].ID == recipient.ID {
+			return http.StatusOK, channel
+		}
+	}
+
+	channelType := dasgo.Flag(dasgo.FlagChannelTypeDM)
+	channel := &dasgo.Channel{Type: &channelType, Recipients: []*dasgo.User{recipient}}
+	s.World.createChannel(channel)
+
+	return http.StatusOK, channel
+}
+
+// getGateway handles Get Gateway.
+func (s *Server) getGateway(r *request) (int, interface{}) {
+	return http.StatusOK, &dasgo.GetGatewayResponse{URL: s.GatewayURL}
+}
+
+// getGatewayBot handles Get Gateway Bot.
+func (s *Server) getGatewayBot(r *request) (int, interface{}) {
+	shards := 1
+
+	return http.StatusOK, &dasgo.GetGatewayBotResponse{
+		URL:    s.GatewayURL,
+		Shards: &shards,
+		SessionStartLimit: dasgo.SessionStartLimit{
+			Total:          1000,
+			Remaining:      1000,
+			MaxConcurrency: 1,
+		},
+	}
+}
+
+// createGuild handles Create Guild.
+//
+// The first role of the request is used as the @everyone role.
+func (s *Server) createGuild(r *request) (int, interface{}) {
+	var body dasgo.CreateGuild
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	if n := utf8.RuneCountInString(body.Name); n < 2 || n > 100 {
+		return formError(validationError("name", "must be between 2 and 100 in length"))
+	}
+
+	guild := &dasgo.Guild{
+		ID:                          s.World.snowflake(),
+		Name:                        body.Name,
+		Region:                      body.Region,
+		VerificationLevel:           body.VerificationLevel,
+		DefaultMessageNotifications: body.DefaultMessageNotifications,
+		ExplicitContentFilter:       body.ExplicitContentFilter,
+		AfkTimeout:                  body.AfkTimeout,
+		SystemChannelFlags:          body.SystemChannelFlags,
+	}
+
+	if body.Icon != nil {
+		guild.Icon = *body.Icon
+	}
+
+	for i, role := range body.Roles {
+		role = clone(role)
+		role.ID = 0
+		if i == 0 {
+			role.ID, role.Name = guild.ID, "@everyone"
+		}
+
+		guild.Roles = append(guild.Roles, role)
+	}
+
+	s.World.createGuild(guild)
+
+	for _, channel := range body.Channels {
+		channel = clone(channel)
+		channel.ID, channel.GuildID, channel.ParentID = 0, guild.ID, nil
+		s.World.createChannel(channel)
+	}
+
+	return http.StatusCreated, s.World.guild(guild.ID)
+}
+
+// getGuild handles Get Guild.
+func (s *Server) getGuild(r *request) (int, interface{}) {
+	guild := s.World.guild(r.snowflake("guild.id"))
+	if guild == nil {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	if r.URL.Query().Get("with_counts") == "true" {
+		members, presences := len(s.World.members[guild.ID]), 0
+		guild.ApproximateMemberCount = &members
+		guild.ApproximatePresenceCount = &presences
+	}
+
+	return http.StatusOK, guild
+}
+
+// modifyGuild handles Modify Guild.
+func (s *Server) modifyGuild(r *request) (int, interface{}) {
+	guild, ok := s.World.guilds[r.snowflake("guild.id")]
+	if !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	modified, err := patch(r, guild)
+	if err != nil {
+		return formError(err)
+	}
+
+	modified.ID, modified.Roles = guild.ID, nil
+	s.World.guilds[guild.ID] = modified
+
+	return http.StatusOK, s.World.guild(guild.ID)
+}
+
+// deleteGuild handles Delete Guild, which deletes the entities of the guild.
+func (s *Server) deleteGuild(r *request) (int, interface{}) {
+	guild, ok := s.World.guilds[r.snowflake("guild.id")]
+	if !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	if guild.OwnerID != s.World.user.ID {
+		return apiError(http.StatusForbidden, 50013)
+	}
+
+	for id, channel := range s.World.channels {
+		if channel.GuildID == guild.ID {
+			delete(s.World.channels, id)
+			delete(s.World.messages, id)
+		}
+	}
+
+	for id, webhook := range s.World.webhooks {
+		if webhook.GuildID != nil && *webhook.GuildID == guild.ID {
+			delete(s.World.webhooks, id)
+		}
+	}
+
+	delete(s.World.guilds, guild.ID)
+	delete(s.World.roles, guild.ID)
+	delete(s.World.members, guild.ID)
+	delete(s.World.commands, guild.ID)
+
+	return http.StatusNoContent, nil
+}
+
+// getGuildChannels handles Get Guild Channels.
+func (s *Server) getGuildChannels(r *request) (int, interface{}) {
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	channels := []*dasgo.Channel{}
+	for _, channel := range values(s.World.channels) {
+		if channel.GuildID == guildID {
+			channels = append(channels, channel)
+		}
+	}
+
+	return http.StatusOK, channels
+}
+
+// createGuildChannel handles Create Guild Channel.
+func (s *Server) createGuildChannel(r *request) (int, interface{}) {
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	var body dasgo.CreateGuildChannel
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	if n := utf8.RuneCountInString(body.Name); n < 1 || n > 100 {
+		return formError(validationError("name", "must be between 1 and 100 in length"))
+	}
+
+	if body.ParentID != nil {
+		if parent, ok := s.World.channels[*body.ParentID]; !ok || parent.GuildID != guildID {
+			return formError(validationError("parent_id", "Invalid channel"))
+		}
+	}
+
+	rateLimitPerUser := dasgo.CodeFlag(body.RateLimitPerUser)
+	channel := &dasgo.Channel{
+		GuildID:                    guildID,
+		Type:                       body.Type,
+		Name:                       body.Name,
+		Topic:                      body.Topic,
+		Position:                   &body.Position,
+		NSFW:                       &body.NSFW,
+		RateLimitPerUser:           &rateLimitPerUser,
+		ParentID:                   body.ParentID,
+		DefaultAutoArchiveDuration: body.DefaultAutoArchiveDuration,
+	}
+
+	for _, overwrite := range body.PermissionOverwrites {
+		channel.PermissionOverwrites = append(channel.PermissionOverwrites, *overwrite)
+	}
+
+	s.World.createChannel(channel)
+
+	return http.StatusCreated, channel
+}
+
+// member returns the response for a guild member.
+func (s *Server) member(guildID, userID dasgo.Snowflake) (int, interface{}) {
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	member, ok := s.World.members[guildID][userID]
+	if !ok {
+		return apiError(http.StatusNotFound, 10007)
+	}
+
+	return http.StatusOK, member
+}
+
+// listGuildMembers handles List Guild Members.
+func (s *Server) listGuildMembers(r *request) (int, interface{}) {
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	after := r.querySnowflake("after")
+	limit := r.queryInt("limit", 1, 1, 1000)
+
+	members := []*dasgo.GuildMember{}
+	for _, member := range values(s.World.members[guildID]) {
+		if len(members) == limit {
+			break
+		}
+
+		if after == nil || member.User.ID > *after {
+			members = append(members, member)
+		}
+	}
+
+	return http.StatusOK, members
+}
+
+// searchGuildMembers handles Search Guild Members, which matches the prefix of usernames and nicknames.
+func (s *Server) searchGuildMembers(r *request) (int, interface{}) {
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	if query == "" {
+		return formError(validationError("query", "This field is required"))
+	}
+
+	limit := r.queryInt("limit", 1, 1, 1000)
+
+	members := []*dasgo.GuildMember{}
+	for _, member := range values(s.World.members[guildID]) {
+		if len(members) == limit {
+			break
+		}
+
+		if strings.HasPrefix(strings.ToLower(member.User.Username), query) ||
+			member.Nick != nil && strings.HasPrefix(strings.ToLower(*member.Nick), query) {
+			members = append(members, member)
+		}
+	}
+
+	return http.StatusOK, members
+}
+
+// getGuildMember handles Get Guild Member.
+func (s *Server) getGuildMember(r *request) (int, interface{}) {
+	return s.member(r.snowflake("guild.id"), r.snowflake("user.id"))
+}
+
+// modifyMember modifies a guild member.
+func (s *Server) modifyMember(r *request, guildID, userID dasgo.Snowflake) (int, interface{}) {
+	status, body := s.member(guildID, userID)
+	if status != http.StatusOK {
+		return status, body
+	}
+
+	member := body.(*dasgo.GuildMember)
+	modified, err := patch(r, member)
+	if err != nil {
+		return formError(err)
+	}
+
+	for _, id := range modified.Roles {
+		if _, ok := s.World.roles[guildID][*id]; !ok {
+			return apiError(http.StatusNotFound, 10011)
+		}
+	}
+
+	modified.User = member.User
+	s.World.members[guildID][userID] = modified
+
+	return http.StatusOK, modified
+}
+
+// modifyGuildMember handles Modify Guild Member.
+func (s *Server) modifyGuildMember(r *request) (int, interface{}) {
+	return s.modifyMember(r, r.snowflake("guild.id"), r.snowflake("user.id"))
+}
+
+// modifyCurrentMember handles Modify Current Member.
+func (s *Server) modifyCurrentMember(r *request) (int, interface{}) {
+	return s.modifyMember(r, r.snowflake("guild.id"), s.World.user.ID)
+}
+
+// removeGuildMember handles Remove Guild Member.
+func (s *Server) removeGuildMember(r *request) (int, interface{}) {
+	guildID, userID := r.snowflake("guild.id"), r.snowflake("user.id")
+	if status, body := s.member(guildID, userID); status != http.StatusOK {
+		return status, body
+	}
+
+	delete(s.World.members[guildID], userID)
+
+	return http.StatusNoContent, nil
+}
+
+// setMemberRole adds or removes a role from a guild member.
+func (s *Server) setMemberRole(r *request, add bool) (int, interface{}) {
+	guildID, userID, roleID := r.snowflake("guild.id"), r.snowflake("user.id"), r.snowflake("role.id")
+	status, body := s.member(guildID, userID)
+	if status != http.StatusOK {
+		return status, body
+	}
+
+	if _, ok := s.World.roles[guildID][roleID]; !ok {
+		return apiError(http.StatusNotFound, 10011)
+	}
+
+	member := clone(body.(*dasgo.GuildMember))
+	roles := make([]*dasgo.Snowflake, 0, len(member.Roles)+1)
+	for _, id := range member.Roles {
+		if *id != roleID {
+			roles = append(roles, id)
+		}
+	}
+
+	if add {
+		roles = append(roles, &roleID)
+	}
+
+	member.Roles = roles
+	s.World.members[guildID][userID] = member
+
+	return http.StatusNoContent, nil
+}
+
+// addGuildMemberRole handles Add Guild Member Role.
+func (s *Server) addGuildMemberRole(r *request) (int, interface{}) {
+	return s.setMemberRole(r, true)
+}
+
+// removeGuildMemberRole handles Remove Guild Member Role.
+func (s *Server) removeGuildMemberRole(r *request) (int, interface{}) {
+	return s.setMemberRole(r, false)
+}
+
+// getGuildRoles handles Get Guild Roles.
+func (s *Server) getGuildRoles(r *request) (int, interface{}) {
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	return http.StatusOK, values(s.World.roles[guildID])
+}
+
+// createGuildRole handles Create Guild Role.
+func (s *Server) createGuildRole(r *request) (int, interface{}) {
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	if len(s.World.roles[guildID]) >= maxRoles {
+		return apiError(http.StatusBadRequest, 30005)
+	}
+
+	var body dasgo.CreateGuildRole
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	role := &dasgo.Role{
+		Name:         body.Name,
+		Hoist:        body.Hoist,
+		Icon:         body.Icon,
+		UnicodeEmoji: body.UnicodeEmoji,
+		Position:     len(s.World.roles[guildID]),
+		Permissions:  body.Permissions,
+		Mentionable:  body.Mentionable,
+	}
+
+	if role.Name == "" {
+		role.Name = "new role"
+	}
+
+	if body.Color != nil {
+		role.Color = *body.Color
+	}
+
+	s.World.createRole(guildID, role)
+
+	return http.StatusOK, role
+}
+
+// modifyGuildRole handles Modify Guild Role.
+func (s *Server) modifyGuildRole(r *request) (int, interface{}) {
+	guildID, roleID := r.snowflake("guild.id"), r.snowflake("role.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	role, ok := s.World.roles[guildID][roleID]
+	if !ok {
+		return apiError(http.StatusNotFound, 10011)
+	}
+
+	modified, err := patch(r, role)
+	if err != nil {
+		return formError(err)
+	}
+
+	modified.ID = role.ID
+	s.World.roles[guildID][roleID] = modified
+
+	return http.StatusOK, modified
+}
+
+// deleteGuildRole handles Delete Guild Role, which removes the role from guild members.
+func (s *Server) deleteGuildRole(r *request) (int, interface{}) {
+	guildID, roleID := r.snowflake("guild.id"), r.snowflake("role.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	if _, ok := s.World.roles[guildID][roleID]; !ok {
+		return apiError(http.StatusNotFound, 10011)
+	}
+
+	// the @everyone role can't be deleted.
+	if roleID == guildID {
+		return apiError(http.StatusBadRequest, 50028)
+	}
+
+	delete(s.World.roles[guildID], roleID)
+
+	for userID, member := range s.World.members[guildID] {
+		if snowflakes(member.Roles)[roleID] {
+			member = clone(member)
+			roles := make([]*dasgo.Snowflake, 0, len(member.Roles)-1)
+			for _, id := range member.Roles {
+				if *id != roleID {
+					roles = append(roles, id)
+				}
+			}
+
+			member.Roles = roles
+			s.World.members[guildID][userID] = member
+		}
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// getGuildWebhooks handles Get Guild Webhooks.
+func (s *Server) getGuildWebhooks(r *request) (int, interface{}) {
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; !ok {
+		return apiError(http.StatusNotFound, 10004)
+	}
+
+	webhooks := []*dasgo.Webhook{}
+	for _, webhook := range values(s.World.webhooks) {
+		if webhook.GuildID != nil && *webhook.GuildID == guildID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return http.StatusOK, webhooks
+}
+
+// channel returns the channel of a request.
+func (s *Server) channel(r *request) (*dasgo.Channel, bool) {
+	channel, ok := s.World.channels[r.snowflake("channel.id")]
+
+	return channel, ok
+}
+
+// getChannel handles Get Channel.
+func (s *Server) getChannel(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	return http.StatusOK, channel
+}
+
+// modifyChannel handles Modify Channel.
+func (s *Server) modifyChannel(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	modified, err := patch(r, channel)
+	if err != nil {
+		return formError(err)
+	}
+
+	modified.ID, modified.GuildID = channel.ID, channel.GuildID
+	s.World.channels[channel.ID] = modified
+
+	return http.StatusOK, modified
+}
+
+// deleteChannel handles Delete/Close Channel, which deletes the messages and webhooks of the channel.
+func (s *Server) deleteChannel(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	for id, webhook := range s.World.webhooks {
+		if webhook.ChannelID != nil && *webhook.ChannelID == channel.ID {
+			delete(s.World.webhooks, id)
+		}
+	}
+
+	delete(s.World.channels, channel.ID)
+	delete(s.World.messages, channel.ID)
+
+	return http.StatusOK, channel
+}
+
+// getChannelMessages handles Get Channel Messages, which returns messages from newest to oldest.
+func (s *Server) getChannelMessages(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	limit := r.queryInt("limit", 50, 1, 100)
+	messages := values(s.World.messages[channel.ID])
+
+	// index returns the index of the first message after (or at when inclusive) a message ID.
+	index := func(id dasgo.Snowflake, inclusive bool) int {
+		return sort.Search(len(messages), func(i int) bool {
+			return messages[i].ID > id || inclusive && messages[i].ID == id
+		})
+	}
+
+	start, end := len(messages)-limit, len(messages)
+	switch {
+	case r.querySnowflake("around") != nil:
+		start = index(*r.querySnowflake("around"), true) - limit/2
+		end = start + limit
+	case r.querySnowflake("before") != nil:
+		end = index(*r.querySnowflake("before"), true)
+		start = end - limit
+	case r.querySnowflake("after") != nil:
+		start = index(*r.querySnowflake("after"), false)
+		end = start + limit
+	}
+
+	if start < 0 {
+		start = 0
+	}
+
+	if end > len(messages) {
+		end = len(messages)
+	}
+
+	if start > end {
+		start = end
+	}
+
+	messages = messages[start:end]
+	reverse(messages)
+
+	return http.StatusOK, messages
+}
+
+// message returns the message of a request.
+func (s *Server) message(r *request, channelID dasgo.Snowflake) (*dasgo.Message, bool) {
+	message, ok := s.World.messages[channelID][r.snowflake("message.id")]
+
+	return message, ok
+}
+
+// getChannelMessage handles Get Channel Message.
+func (s *Server) getChannelMessage(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	message, ok := s.message(r, channel.ID)
+	if !ok {
+		return apiError(http.StatusNotFound, 10008)
+	}
+
+	return http.StatusOK, message
+}
+
+// messageBody represents the body of a Create Message, Edit Message, or Execute Webhook request.
+//
+// Components are accepted, but not stored.
+type messageBody struct {
+	Content          *string                 `json:"content"`
+	TTS              bool                    `json:"tts"`
+	Embeds           []*dasgo.Embed          `json:"embeds"`
+	Embed            *dasgo.Embed            `json:"embed"`
+	AllowedMentions  *dasgo.AllowedMentions  `json:"allowed_mentions"`
+	MessageReference *dasgo.MessageReference `json:"message_reference"`
+	StickerIDs       []*dasgo.Snowflake      `json:"sticker_ids"`
+	Components       json.RawMessage         `json:"components"`
+	Attachments      []*dasgo.Attachment     `json:"attachments"`
+	Flags            *dasgo.CodeFlag         `json:"flags"`
+	Nonce            interface{}             `json:"nonce"`
+
+	// Execute Webhook
+	Username  string `json:"username"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// validateMessage reports every violation of the content and embeds of a message.
+func validateMessage(message *dasgo.Message) error {
+	var errs dasgo.ValidationErrors
+	if n := utf8.RuneCountInString(message.Content); n > maxContentLength {
+		errs = append(errs, validationError("content", "must be %d or fewer in length (found %d)", maxContentLength, n)...)
+	}
+
+	if err := dasgo.ValidateEmbeds(message.Embeds); err != nil {
+		var embedErrors dasgo.ValidationErrors
+		if errors.As(err, &embedErrors) {
+			errs = append(errs, embedErrors...)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// attachments returns the attachments of the files uploaded in a multipart/form-data request.
+func (s *Server) attachments(r *request, channelID dasgo.Snowflake) []*dasgo.Attachment {
+	if r.MultipartForm == nil {
+		return nil
+	}
+
+	fields := make([]string, 0, len(r.MultipartForm.File))
+	for field := range r.MultipartForm.File {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	var attachments []*dasgo.Attachment
+	for _, field := range fields {
+		for _, file := range r.MultipartForm.File[field] {
+			id := s.World.snowflake()
+			attachment := &dasgo.Attachment{
+				ID:       id,
+				Filename: file.Filename,
+				Size:     int(file.Size),
+				URL:      fmt.Sprintf("https://cdn.discordapp.com/attachments/%d/%d/%s", channelID, id, file.Filename),
+			}
+
+			if contentType := file.Header.Get("Content-Type"); contentType != "" {
+				attachment.ContentType = &contentType
+			}
+
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	return attachments
+}
+
+// mention sets the mentions of a message from its content and the allowed mentions of a request.
+func (s *Server) mention(message *dasgo.Message, allowed *dasgo.AllowedMentions) {
+	parse := map[string]bool{"users": true, "roles": true, "everyone": true}
+	var users, roles map[dasgo.Snowflake]bool
+	if allowed != nil {
+		parse = make(map[string]bool)
+		for _, p := range allowed.Parse {
+			if p != nil {
+				parse[*p] = true
+			}
+		}
+
+		users, roles = snowflakes(allowed.Users), snowflakes(allowed.Roles)
+	}
+
+	var guildID dasgo.Snowflake
+	if message.GuildID != nil {
+		guildID = *message.GuildID
+	}
+
+	message.Mentions = []*dasgo.User{}
+	message.MentionRoles = []*dasgo.Snowflake{}
+	message.MentionEveryone = false
+
+	mentioned := make(map[dasgo.Snowflake]bool)
+	mentionUser := func(user *dasgo.User) {
+		if !mentioned[user.ID] {
+			mentioned[user.ID] = true
+			message.Mentions = append(message.Mentions, user)
+		}
+	}
+
+	for _, token := range dasgo.ParseContent(message.Content) {
+		switch token.Type {
+		case dasgo.FlagContentTokenTypeUSER:
+			if user, ok := s.World.users[token.ID]; ok && (parse["users"] || users[token.ID]) {
+				mentionUser(user)
+			}
+
+		case dasgo.FlagContentTokenTypeROLE:
+			if _, ok := s.World.roles[guildID][token.ID]; ok && (parse["roles"] || roles[token.ID]) && !mentioned[token.ID] {
+				mentioned[token.ID] = true
+				id := token.ID
+				message.MentionRoles = append(message.MentionRoles, &id)
+			}
+
+		case dasgo.FlagContentTokenTypeMENTIONEVERYONE, dasgo.FlagContentTokenTypeMENTIONHERE:
+			message.MentionEveryone = message.MentionEveryone || parse["everyone"]
+		}
+	}
+
+	if message.ReferencedMessage != nil && message.ReferencedMessage.Author != nil &&
+		(allowed == nil || allowed.RepliedUser) {
+		mentionUser(message.ReferencedMessage.Author)
+	}
+}
+
+// createMessage creates a message in a channel, which is executed by a webhook when it's non-nil.
+func (s *Server) createMessage(r *request, channel *dasgo.Channel, webhook *dasgo.Webhook) (int, interface{}) {
+	var body messageBody
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	messageType := dasgo.Flag(dasgo.FlagMessageTypeDEFAULT)
+	message := &dasgo.Message{
+		ID:          s.World.snowflake(),
+		ChannelID:   &channel.ID,
+		Author:      s.World.user,
+		TTS:         body.TTS,
+		Embeds:      body.Embeds,
+		Attachments: s.attachments(r, channel.ID),
+		Nonce:       body.Nonce,
+		Type:        &messageType,
+		Flags:       body.Flags,
+	}
+
+	message.Timestamp = message.ID.Time().UTC()
+
+	if body.Content != nil {
+		message.Content = *body.Content
+	}
+
+	if body.Embed != nil {
+		message.Embeds = append(message.Embeds, body.Embed)
+	}
+
+	if message.Embeds == nil {
+		message.Embeds = []*dasgo.Embed{}
+	}
+
+	if message.Attachments == nil {
+		message.Attachments = []*dasgo.Attachment{}
+	}
+
+	if message.Content == "" && len(message.Embeds) == 0 && len(message.Attachments) == 0 && len(body.StickerIDs) == 0 {
+		return apiError(http.StatusBadRequest, 50006)
+	}
+
+	if err := validateMessage(message); err != nil {
+		return formError(err)
+	}
+
+	if webhook != nil {
+		bot := true
+		message.WebhookID = &webhook.ID
+		message.Author = &dasgo.User{ID: webhook.ID, Username: body.Username, Discriminator: "0000", Avatar: webhook.Avatar, Bot: &bot}
+		if message.Author.Username == "" && webhook.Name != nil {
+			message.Author.Username = *webhook.Name
+		}
+
+		if webhook.ApplicationID != nil {
+			message.ApplicationID = *webhook.ApplicationID
+		}
+	}
+
+	if channel.GuildID != 0 {
+		guildID := channel.GuildID
+		message.GuildID = &guildID
+
+		if member, ok := s.World.members[guildID][message.Author.ID]; ok && webhook == nil {
+			message.Member = clone(member)
+			message.Member.User = nil
+		}
+	}
+
+	if reference := body.MessageReference; reference != nil {
+		channelID := channel.ID
+		if reference.ChannelID != nil {
+			channelID = *reference.ChannelID
+		}
+
+		referenced, ok := s.World.messages[channelID][reference.MessageID]
+		if !ok {
+			if reference.FailIfNotExists == nil || *reference.FailIfNotExists {
+				return formError(validationError("message_reference", "Unknown message"))
+			}
+		} else {
+			replyType := dasgo.Flag(dasgo.FlagMessageTypeREPLY)
+			message.Type = &replyType
+			message.MessageReference = &dasgo.MessageReference{MessageID: referenced.ID, ChannelID: referenced.ChannelID, GuildID: referenced.GuildID}
+			message.ReferencedMessage = clone(referenced)
+			message.ReferencedMessage.ReferencedMessage = nil
+		}
+	}
+
+	s.mention(message, body.AllowedMentions)
+	put(s.World.messages, channel.ID, message.ID, message)
+
+	channel = clone(channel)
+	channel.LastMessageID = &message.ID
+	s.World.channels[channel.ID] = channel
+
+	return http.StatusOK, message
+}
+
+// editMessage edits a message.
+func (s *Server) editMessage(r *request, message *dasgo.Message) (int, interface{}) {
+	var fields map[string]json.RawMessage
+	if err := r.decode(&fields); err != nil {
+		return formError(err)
+	}
+
+	var body messageBody
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	edited := clone(message)
+	if _, ok := fields["content"]; ok {
+		edited.Content = ""
+		if body.Content != nil {
+			edited.Content = *body.Content
+		}
+	}
+
+	if _, ok := fields["embeds"]; ok {
+		edited.Embeds = body.Embeds
+		if edited.Embeds == nil {
+			edited.Embeds = []*dasgo.Embed{}
+		}
+	}
+
+	if _, ok := fields["flags"]; ok {
+		edited.Flags = body.Flags
+	}
+
+	if _, ok := fields["attachments"]; ok {
+		retained := snowflakes(nil)
+		for _, attachment := range body.Attachments {
+			retained[attachment.ID] = true
+		}
+
+		edited.Attachments = []*dasgo.Attachment{}
+		for _, attachment := range message.Attachments {
+			if retained[attachment.ID] {
+				edited.Attachments = append(edited.Attachments, attachment)
+			}
+		}
+	}
+
+	edited.Attachments = append(edited.Attachments, s.attachments(r, *message.ChannelID)...)
+
+	if edited.Content == "" && len(edited.Embeds) == 0 && len(edited.Attachments) == 0 && len(edited.StickerItems) == 0 {
+		return apiError(http.StatusBadRequest, 50006)
+	}
+
+	if err := validateMessage(edited); err != nil {
+		return formError(err)
+	}
+
+	if _, ok := fields["content"]; ok {
+		s.mention(edited, body.AllowedMentions)
+	}
+
+	edited.EditedTimestamp = dasgo.NewTimestamp(time.Now().UTC())
+	s.World.messages[*message.ChannelID][message.ID] = edited
+
+	return http.StatusOK, edited
+}
+
+// createChannelMessage handles Create Message.
+func (s *Server) createChannelMessage(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	return s.createMessage(r, channel, nil)
+}
+
+// editChannelMessage handles Edit Message.
+func (s *Server) editChannelMessage(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	message, ok := s.message(r, channel.ID)
+	if !ok {
+		return apiError(http.StatusNotFound, 10008)
+	}
+
+	if message.Author == nil || message.Author.ID != s.World.user.ID {
+		return apiError(http.StatusForbidden, 50005)
+	}
+
+	return s.editMessage(r, message)
+}
+
+// deleteChannelMessage handles Delete Message.
+func (s *Server) deleteChannelMessage(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	message, ok := s.message(r, channel.ID)
+	if !ok {
+		return apiError(http.StatusNotFound, 10008)
+	}
+
+	delete(s.World.messages[channel.ID], message.ID)
+
+	return http.StatusNoContent, nil
+}
+
+// bulkDeleteMessages handles Bulk Delete Messages.
+func (s *Server) bulkDeleteMessages(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	var body dasgo.BulkDeleteMessages
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	if n := len(body.Messages); n < 2 || n > 100 {
+		return formError(validationError("messages", "must be between 2 and 100 in length"))
+	}
+
+	oldest := time.Now().Add(-bulkDeleteAge)
+	for _, id := range body.Messages {
+		if id == nil {
+			return formError(validationError("messages", "must not contain null"))
+		}
+
+		if id.Time().Before(oldest) {
+			return apiError(http.StatusBadRequest, 50034)
+		}
+	}
+
+	for _, id := range body.Messages {
+		delete(s.World.messages[channel.ID], *id)
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// getPinnedMessages handles Get Pinned Messages, which returns messages from newest to oldest.
+func (s *Server) getPinnedMessages(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	pinned := []*dasgo.Message{}
+	for _, message := range values(s.World.messages[channel.ID]) {
+		if message.Pinned {
+			pinned = append(pinned, message)
+		}
+	}
+
+	reverse(pinned)
+
+	return http.StatusOK, pinned
+}
+
+// setPinned pins or unpins a message.
+func (s *Server) setPinned(r *request, pinned bool) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	message, ok := s.message(r, channel.ID)
+	if !ok {
+		return apiError(http.StatusNotFound, 10008)
+	}
+
+	if message.Pinned == pinned {
+		return http.StatusNoContent, nil
+	}
+
+	if pinned {
+		count := 0
+		for _, message := range s.World.messages[channel.ID] {
+			if message.Pinned {
+				count++
+			}
+		}
+
+		if count >= maxPins {
+			return apiError(http.StatusBadRequest, 30003)
+		}
+
+		channel = clone(channel)
+		channel.LastPinTimestamp = dasgo.NewTimestamp(time.Now().UTC())
+		s.World.channels[channel.ID] = channel
+	}
+
+	message = clone(message)
+	message.Pinned = pinned
+	s.World.messages[channel.ID][message.ID] = message
+
+	return http.StatusNoContent, nil
+}
+
+// pinMessage handles Pin Message.
+func (s *Server) pinMessage(r *request) (int, interface{}) {
+	return s.setPinned(r, true)
+}
+
+// unpinMessage handles Unpin Message.
+func (s *Server) unpinMessage(r *request) (int, interface{}) {
+	return s.setPinned(r, false)
+}
+
+// triggerTypingIndicator handles Trigger Typing Indicator.
+func (s *Server) triggerTypingIndicator(r *request) (int, interface{}) {
+	if _, ok := s.channel(r); !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// createWebhook handles Create Webhook.
+func (s *Server) createWebhook(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	var body dasgo.CreateWebhook
+	if err := r.decode(&body); err != nil {
+		return formError(err)
+	}
+
+	if n := utf8.RuneCountInString(body.Name); n < 1 || n > 80 {
+		return formError(validationError("name", "must be between 1 and 80 in length"))
+	}
+
+	if strings.Contains(strings.ToLower(body.Name), "clyde") {
+		return formError(validationError("name", "Username cannot contain \"clyde\""))
+	}
+
+	webhook := &dasgo.Webhook{
+		ChannelID: &channel.ID,
+		User:      s.World.user,
+		Name:      &body.Name,
+	}
+
+	if body.Avatar != "" {
+		webhook.Avatar = &body.Avatar
+	}
+
+	if channel.GuildID != 0 {
+		guildID := channel.GuildID
+		webhook.GuildID = &guildID
+	}
+
+	s.World.createWebhook(webhook)
+
+	return http.StatusOK, webhook
+}
+
+// getChannelWebhooks handles Get Channel Webhooks.
+func (s *Server) getChannelWebhooks(r *request) (int, interface{}) {
+	channel, ok := s.channel(r)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	webhooks := []*dasgo.Webhook{}
+	for _, webhook := range values(s.World.webhooks) {
+		if webhook.ChannelID != nil && *webhook.ChannelID == channel.ID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return http.StatusOK, webhooks
+}
+
+// webhook returns the webhook of a request, which is authenticated by its token (when present).
+func (s *Server) webhook(r *request) (*dasgo.Webhook, int, interface{}) {
+	webhook, ok := s.World.webhooks[r.snowflake("webhook.id")]
+	if !ok {
+		status, body := apiError(http.StatusNotFound, 10015)
+		return nil, status, body
+	}
+
+	if token, ok := r.params["webhook.token"]; ok {
+		if webhook.Token == "" || token != webhook.Token {
+			status, body := apiError(http.StatusUnauthorized, 50027)
+			return nil, status, body
+		}
+	}
+
+	return webhook, http.StatusOK, nil
+}
+
+// webhookResponse returns the webhook object of a response, which omits the
+// user of the webhook when the request is authenticated by its token.
+func webhookResponse(r *request, webhook *dasgo.Webhook) *dasgo.Webhook {
+	if _, ok := r.params["webhook.token"]; ok {
+		webhook = clone(webhook)
+		webhook.User = nil
+	}
+
+	return webhook
+}
+
+// getWebhook handles Get Webhook and Get Webhook with Token.
+func (s *Server) getWebhook(r *request) (int, interface{}) {
+	webhook, status, body := s.webhook(r)
+	if webhook == nil {
+		return status, body
+	}
+
+	return http.StatusOK, webhookResponse(r, webhook)
+}
+
+// modifyWebhook handles Modify Webhook and Modify Webhook with Token.
+//
+// The channel of a webhook can't be modified with a token.
+func (s *Server) modifyWebhook(r *request) (int, interface{}) {
+	webhook, status, body := s.webhook(r)
+	if webhook == nil {
+		return status, body
+	}
+
+	modified, err := patch(r, webhook)
+	if err != nil {
+		return formError(err)
+	}
+
+	modified.ID, modified.Type, modified.Token = webhook.ID, webhook.Type, webhook.Token
+	if _, ok := r.params["webhook.token"]; ok {
+		modified.ChannelID = webhook.ChannelID
+	} else if modified.ChannelID != nil {
+		if _, ok := s.World.channels[*modified.ChannelID]; !ok {
+			return apiError(http.StatusNotFound, 10003)
+		}
+	}
+
+	s.World.webhooks[webhook.ID] = modified
+
+	return http.StatusOK, webhookResponse(r, modified)
+}
+
+// deleteWebhook handles Delete Webhook and Delete Webhook with Token.
+func (s *Server) deleteWebhook(r *request) (int, interface{}) {
+	webhook, status, body := s.webhook(r)
+	if webhook == nil {
+		return status, body
+	}
+
+	delete(s.World.webhooks, webhook.ID)
+
+	return http.StatusNoContent, nil
+}
+
+// webhookChannel returns the channel of a webhook request, which is a thread when thread_id is present.
+func (s *Server) webhookChannel(r *request, webhook *dasgo.Webhook) (*dasgo.Channel, bool) {
+	if threadID := r.querySnowflake("thread_id"); threadID != nil {
+		thread, ok := s.World.channels[*threadID]
+		if !ok || thread.ParentID == nil || webhook.ChannelID == nil || *thread.ParentID != *webhook.ChannelID {
+			return nil, false
+		}
+
+		return thread, true
+	}
+
+	if webhook.ChannelID == nil {
+		return nil, false
+	}
+
+	channel, ok := s.World.channels[*webhook.ChannelID]
+
+	return channel, ok
+}
+
+// executeWebhook handles Execute Webhook, which only responds with the message when wait is true.
+func (s *Server) executeWebhook(r *request) (int, interface{}) {
+	webhook, status, body := s.webhook(r)
+	if webhook == nil {
+		return status, body
+	}
+
+	channel, ok := s.webhookChannel(r, webhook)
+	if !ok {
+		return apiError(http.StatusNotFound, 10003)
+	}
+
+	status, body = s.createMessage(r, channel, webhook)
+	if status == http.StatusOK && r.URL.Query().Get("wait") != "true" {
+		return http.StatusNoContent, nil
+	}
+
+	return status, body
+}
+
+// webhookMessage returns the message of a webhook request, which must be executed by the webhook.
+func (s *Server) webhookMessage(r *request) (*dasgo.Message, int, interface{}) {
+	webhook, status, body := s.webhook(r)
+	if webhook == nil {
+		return nil, status, body
+	}
+
+	channel, ok := s.webhookChannel(r, webhook)
+	if !ok {
+		status, body := apiError(http.StatusNotFound, 10003)
+		return nil, status, body
+	}
+
+	message, ok := s.message(r, channel.ID)
+	if !ok || message.WebhookID == nil || *message.WebhookID != webhook.ID {
+		status, body := apiError(http.StatusNotFound, 10008)
+		return nil, status, body
+	}
+
+	return message, http.StatusOK, nil
+}
+
+// getWebhookMessage handles Get Webhook Message.
+func (s *Server) getWebhookMessage(r *request) (int, interface{}) {
+	message, status, body := s.webhookMessage(r)
+	if message == nil {
+		return status, body
+	}
+
+	return http.StatusOK, message
+}
+
+// editWebhookMessage handles Edit Webhook Message.
+func (s *Server) editWebhookMessage(r *request) (int, interface{}) {
+	message, status, body := s.webhookMessage(r)
+	if message == nil {
+		return status, body
+	}
+
+	return s.editMessage(r, message)
+}
+
+// deleteWebhookMessage handles Delete Webhook Message.
+func (s *Server) deleteWebhookMessage(r *request) (int, interface{}) {
+	message, status, body := s.webhookMessage(r)
+	if message == nil {
+		return status, body
+	}
+
+	delete(s.World.messages[*message.ChannelID], message.ID)
+
+	return http.StatusNoContent, nil
+}
+
+// commands returns the guild ID of an application command request (or 0 for global commands).
+func (s *Server) commands(r *request) (dasgo.Snowflake, int, interface{}) {
+	if r.snowflake("application.id") != s.World.user.ID {
+		status, body := apiError(http.StatusNotFound, 10002)
+		return 0, status, body
+	}
+
+	guildID := r.snowflake("guild.id")
+	if _, ok := s.World.guilds[guildID]; guildID != 0 && !ok {
+		status, body := apiError(http.StatusNotFound, 10004)
+		return 0, status, body
+	}
+
+	return guildID, http.StatusOK, nil
+}
+
+// command returns the application command of a request.
+func (s *Server) command(r *request) (*dasgo.ApplicationCommand, int, interface{}) {
+	guildID, status, body := s.commands(r)
+	if status != http.StatusOK {
+		return nil, status, body
+	}
+
+	command, ok := s.World.commands[guildID][r.snowflake("command.id")]
+	if !ok {
+		status, body := apiError(http.StatusNotFound, 10063)
+		return nil, status, body
+	}
+
+	return command, http.StatusOK, nil
+}
+
+// findCommand returns the application command of a guild with a name and type.
+func (s *Server) findCommand(guildID dasgo.Snowflake, name string, commandType dasgo.Flag) *dasgo.ApplicationCommand {
+	if commandType == 0 {
+		commandType = dasgo.FlagApplicationCommandTypeCHAT_INPUT
+	}
+
+	for _, command := range s.World.commands[guildID] {
+		if command.Name == name && command.Type == commandType {
+			return command
+		}
+	}
+
+	return nil
+}
+
+// getCommands handles Get Global Application Commands and Get Guild Application Commands.
+func (s *Server) getCommands(r *request) (int, interface{}) {
+	guildID, status, body := s.commands(r)
+	if status != http.StatusOK {
+		return status, body
+	}
+
+	return http.StatusOK, values(s.World.commands[guildID])
+}
+
+// createCommand handles Create Global Application Command and Create Guild Application Command.
+//
+// An existing command with the same name and type is replaced.
+func (s *Server) createCommand(r *request) (int, interface{}) {
+	guildID, status, body := s.commands(r)
+	if status != http.StatusOK {
+		return status, body
+	}
+
+	command := new(dasgo.ApplicationCommand)
+	if err := r.decode(command); err != nil {
+		return formError(err)
+	}
+
+	if err := command.Validate(); err != nil {
+		return formError(err)
+	}
+
+	command.ID = 0
+	status = http.StatusCreated
+	if existing := s.findCommand(guildID, command.Name, command.Type); existing != nil {
+		command.ID = existing.ID
+		status = http.StatusOK
+	}
+
+	s.World.createCommand(guildID, command)
+
+	return status, command
+}
+
+// getCommand handles Get Global Application Command and Get Guild Application Command.
+func (s *Server) getCommand(r *request) (int, interface{}) {
+	command, status, body := s.command(r)
+	if command == nil {
+		return status, body
+	}
+
+	return http.StatusOK, command
+}
+
+// editCommand handles Edit Global Application Command and Edit Guild Application Command.
+func (s *Server) editCommand(r *request) (int, interface{}) {
+	command, status, body := s.command(r)
+	if command == nil {
+		return status, body
+	}
+
+	edited, err := patch(r, command)
+	if err != nil {
+		return formError(err)
+	}
+
+	edited.ID, edited.Type = command.ID, command.Type
+	if err := edited.Validate(); err != nil {
+		return formError(err)
+	}
+
+	if existing := s.findCommand(command.GuildID, edited.Name, edited.Type); existing != nil && existing.ID != command.ID {
+		return formError(validationError("name", "Application command names must be unique"))
+	}
+
+	s.World.createCommand(command.GuildID, edited)
+
+	return http.StatusOK, edited
+}
+
+// deleteCommand handles Delete Global Application Command and Delete Guild Application Command.
+func (s *Server) deleteCommand(r *request) (int, interface{}) {
+	command, status, body := s.command(r)
+	if command == nil {
+		return status, body
+	}
+
+	delete(s.World.commands[command.GuildID], command.ID)
+
+	return http.StatusNoContent, nil
+}
+
+// bulkOverwriteCommands handles Bulk Overwrite Global Application Commands and
+// Bulk Overwrite Guild Application Commands.
+//
+// Commands with the name and type of an existing command retain its ID.
+func (s *Server) bulkOverwriteCommands(r *request) (int, interface{}) {
+	guildID, status, body := s.commands(r)
+	if status != http.StatusOK {
+		return status, body
+	}
+
+	var commands []*dasgo.ApplicationCommand
+	if err := r.decode(&commands); err != nil {
+		return formError(err)
+	}
+
+	var errs dasgo.ValidationErrors
+	for i, command := range commands {
+		if command == nil {
+			errs = append(errs, validationError(strconv.Itoa(i), "must not be null")...)
+			continue
+		}
+
+		var commandErrors dasgo.ValidationErrors
+		if err := command.Validate(); errors.As(err, &commandErrors) {
+			for _, commandError := range commandErrors {
+				errs = append(errs, &dasgo.ValidationError{Path: fmt.Sprintf("%d.%s", i, commandError.Path), Message: commandError.Message})
+			}
+		}
+	}
+
+	if len(errs) != 0 {
+		return formError(errs)
+	}
+
+	for _, command := range commands {
+		command.ID = 0
+		if existing := s.findCommand(guildID, command.Name, command.Type); existing != nil {
+			command.ID = existing.ID
+		}
+	}
+
+	delete(s.World.commands, guildID)
+	for _, command := range commands {
+		s.World.createCommand(guildID, command)
+	}
+
+	return http.StatusOK, values(s.World.commands[guildID])
+}
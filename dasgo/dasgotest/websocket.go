@@ -0,0 +1,225 @@
+// Package dasgotest provides fake Discord servers for testing.
+package dasgotest
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WebSocket Opcodes
+// https://www.rfc-editor.org/rfc/rfc6455#section-5.2
+const (
+	wsOpcodeContinuation = 0x0
+	wsOpcodeText         = 0x1
+	wsOpcodeBinary       = 0x2
+	wsOpcodeClose        = 0x8
+	wsOpcodePing         = 0x9
+	wsOpcodePong         = 0xA
+)
+
+// wsAcceptGUID represents the GUID used to compute the Sec-WebSocket-Accept header.
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// wsMaxMessageSize represents the maximum size of a message received from a client.
+const wsMaxMessageSize = 1 << 20
+
+// errWebsocketClosed represents an error returned when the connection is closed by the client.
+var errWebsocketClosed = errors.New("websocket closed")
+
+// wsConn represents the server side of a minimal WebSocket connection (RFC 6455)
+// without extensions or subprotocols.
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// upgrade upgrades an HTTP request to a WebSocket connection.
+func upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet ||
+		!strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		!strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") ||
+		key == "" {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, errors.New("error upgrading connection: not a websocket handshake")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket upgrade unsupported", http.StatusInternalServerError)
+		return nil, errors.New("error upgrading connection: response does not implement http.Hijacker")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("error upgrading connection: %w", err)
+	}
+
+	hash := sha1.Sum([]byte(key + wsAcceptGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(hash[:]) + "\r\n\r\n"
+
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error upgrading connection: %w", err)
+	}
+
+	return &wsConn{conn: conn, reader: rw.Reader}, nil
+}
+
+// readFrame reads a frame from the client.
+func (c *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return false, 0, nil, fmt.Errorf("error reading frame: %w", err)
+	}
+
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, fmt.Errorf("error reading frame: %w", err)
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return false, 0, nil, fmt.Errorf("error reading frame: %w", err)
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	// clients must mask frames.
+	if !masked {
+		return false, 0, nil, errors.New("error reading frame: frame is not masked")
+	}
+
+	if length > wsMaxMessageSize {
+		return false, 0, nil, fmt.Errorf("error reading frame: frame exceeds %d bytes", wsMaxMessageSize)
+	}
+
+	mask := make([]byte, 4)
+	if _, err := io.ReadFull(c.reader, mask); err != nil {
+		return false, 0, nil, fmt.Errorf("error reading frame: %w", err)
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, fmt.Errorf("error reading frame: %w", err)
+	}
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// readMessage reads the next text or binary message from the client, while
+// responding to control frames.
+//
+// errWebsocketClosed is returned when the client closes the connection.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case wsOpcodePing:
+			if err := c.writeFrame(wsOpcodePong, payload); err != nil {
+				return nil, err
+			}
+
+			continue
+		case wsOpcodePong:
+			continue
+		case wsOpcodeClose:
+			c.writeFrame(wsOpcodeClose, payload)
+			c.conn.Close()
+
+			return nil, errWebsocketClosed
+		}
+
+		message = append(message, payload...)
+		if len(message) > wsMaxMessageSize {
+			return nil, fmt.Errorf("error reading message: message exceeds %d bytes", wsMaxMessageSize)
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// writeFrame writes an unfragmented frame to the client.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return errWebsocketClosed
+	}
+
+	if opcode == wsOpcodeClose {
+		c.closed = true
+	}
+
+	frame := []byte{0x80 | opcode}
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	default:
+		frame = append(frame, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+
+	if _, err := c.conn.Write(append(frame, payload...)); err != nil {
+		return fmt.Errorf("error writing frame: %w", err)
+	}
+
+	return nil
+}
+
+// writeText writes a text message to the client.
+func (c *wsConn) writeText(message []byte) error {
+	return c.writeFrame(wsOpcodeText, message)
+}
+
+// close closes the connection with a close code and reason.
+func (c *wsConn) close(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+
+	err := c.writeFrame(wsOpcodeClose, payload)
+	c.conn.Close()
+
+	return err
+}
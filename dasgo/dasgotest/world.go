@@ -0,0 +1,419 @@
+// Package dasgotest provides fake Discord servers for testing.
+package dasgotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+)
+
+// World represents the in-memory entities of a fake Discord.
+//
+// Entities passed to and returned from a World are copied, so they can be used
+// by a test while the World is modified by a server.
+type World struct {
+	mu sync.Mutex
+
+	// last represents the last snowflake that was generated.
+	last dasgo.Snowflake
+
+	// user represents the current (bot) user.
+	user *dasgo.User
+
+	users    map[dasgo.Snowflake]*dasgo.User
+	guilds   map[dasgo.Snowflake]*dasgo.Guild
+	channels map[dasgo.Snowflake]*dasgo.Channel
+	messages map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.Message
+	roles    map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.Role
+	members  map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.GuildMember
+	webhooks map[dasgo.Snowflake]*dasgo.Webhook
+
+	// commands maps guild IDs (0 for global commands) to application commands.
+	commands map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.ApplicationCommand
+}
+
+// NewWorld returns a new World with a current user of the given username.
+func NewWorld(username string) *World {
+	w := &World{
+		users:    make(map[dasgo.Snowflake]*dasgo.User),
+		guilds:   make(map[dasgo.Snowflake]*dasgo.Guild),
+		channels: make(map[dasgo.Snowflake]*dasgo.Channel),
+		messages: make(map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.Message),
+		roles:    make(map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.Role),
+		members:  make(map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.GuildMember),
+		webhooks: make(map[dasgo.Snowflake]*dasgo.Webhook),
+		commands: make(map[dasgo.Snowflake]map[dasgo.Snowflake]*dasgo.ApplicationCommand),
+	}
+
+	bot := true
+	w.user = w.AddUser(&dasgo.User{Username: username, Discriminator: "0000", Bot: &bot})
+
+	return w
+}
+
+// clone returns a shallow copy of an entity.
+func clone[T any](entity *T) *T {
+	if entity == nil {
+		return nil
+	}
+
+	copied := *entity
+
+	return &copied
+}
+
+// values returns copies of the entities of a map sorted by ID.
+func values[T any](entities map[dasgo.Snowflake]*T) []*T {
+	ids := make([]dasgo.Snowflake, 0, len(entities))
+	for id := range entities {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	sorted := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, clone(entities[id]))
+	}
+
+	return sorted
+}
+
+// put puts an entity into a map of maps.
+func put[T any](entities map[dasgo.Snowflake]map[dasgo.Snowflake]*T, key, id dasgo.Snowflake, entity *T) {
+	if entities[key] == nil {
+		entities[key] = make(map[dasgo.Snowflake]*T)
+	}
+
+	entities[key][id] = entity
+}
+
+// snowflake returns a new snowflake for the current time.
+//
+// Snowflakes are unique and increasing: when a snowflake was already generated for the current
+// millisecond, the next snowflake increments the last one, which carries into the timestamp bits.
+func (w *World) snowflake() dasgo.Snowflake {
+	id := dasgo.SnowflakeFromTime(time.Now())
+	if id <= w.last {
+		id = w.last + 1
+	}
+
+	w.last = id
+
+	return id
+}
+
+// token returns a new random token.
+func token() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// User returns the current (bot) user.
+func (w *World) User() *dasgo.User {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return clone(w.user)
+}
+
+// AddUser adds a user, which is assigned an ID when its ID is 0.
+func (w *World) AddUser(user *dasgo.User) *dasgo.User {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	user = clone(user)
+	if user.ID == 0 {
+		user.ID = w.snowflake()
+	}
+
+	w.users[user.ID] = user
+
+	return clone(user)
+}
+
+// AddGuild adds a guild owned by the current user, which is assigned an ID when its ID is 0.
+//
+// The @everyone role of the guild is created along with a member for the current user.
+func (w *World) AddGuild(guild *dasgo.Guild) *dasgo.Guild {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	guild = clone(guild)
+	w.createGuild(guild)
+
+	return clone(guild)
+}
+
+// createGuild creates a guild.
+func (w *World) createGuild(guild *dasgo.Guild) {
+	if guild.ID == 0 {
+		guild.ID = w.snowflake()
+	}
+
+	if guild.OwnerID == 0 {
+		guild.OwnerID = w.user.ID
+	}
+
+	for _, role := range guild.Roles {
+		w.createRole(guild.ID, clone(role))
+	}
+
+	if _, ok := w.roles[guild.ID][guild.ID]; !ok {
+		w.createRole(guild.ID, &dasgo.Role{ID: guild.ID, Name: "@everyone", Permissions: "1071698660929"})
+	}
+
+	guild.Roles = nil
+	w.guilds[guild.ID] = guild
+	w.createMember(guild.ID, &dasgo.GuildMember{User: clone(w.user)})
+}
+
+// Guild returns a guild (with its roles), or nil when the guild does not exist.
+func (w *World) Guild(id dasgo.Snowflake) *dasgo.Guild {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.guild(id)
+}
+
+// guild returns a copy of a guild with its roles.
+func (w *World) guild(id dasgo.Snowflake) *dasgo.Guild {
+	guild := clone(w.guilds[id])
+	if guild != nil {
+		guild.Roles = values(w.roles[id])
+	}
+
+	return guild
+}
+
+// AddChannel adds a channel, which is assigned an ID when its ID is 0.
+func (w *World) AddChannel(channel *dasgo.Channel) *dasgo.Channel {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	channel = clone(channel)
+	w.createChannel(channel)
+
+	return clone(channel)
+}
+
+// createChannel creates a channel.
+func (w *World) createChannel(channel *dasgo.Channel) {
+	if channel.ID == 0 {
+		channel.ID = w.snowflake()
+	}
+
+	if channel.Type == nil {
+		channelType := dasgo.Flag(dasgo.FlagChannelTypeGUILD_TEXT)
+		channel.Type = &channelType
+	}
+
+	w.channels[channel.ID] = channel
+}
+
+// Channel returns a channel, or nil when the channel does not exist.
+func (w *World) Channel(id dasgo.Snowflake) *dasgo.Channel {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return clone(w.channels[id])
+}
+
+// AddRole adds a role to a guild, which is assigned an ID when its ID is 0.
+func (w *World) AddRole(guildID dasgo.Snowflake, role *dasgo.Role) *dasgo.Role {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	role = clone(role)
+	w.createRole(guildID, role)
+
+	return clone(role)
+}
+
+// createRole creates a role.
+func (w *World) createRole(guildID dasgo.Snowflake, role *dasgo.Role) {
+	if role.ID == 0 {
+		role.ID = w.snowflake()
+	}
+
+	if role.Permissions == "" {
+		role.Permissions = "0"
+	}
+
+	put(w.roles, guildID, role.ID, role)
+}
+
+// Role returns a role, or nil when the role does not exist.
+func (w *World) Role(guildID, id dasgo.Snowflake) *dasgo.Role {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return clone(w.roles[guildID][id])
+}
+
+// AddMember adds a member to a guild, whose user is added when it does not exist.
+func (w *World) AddMember(guildID dasgo.Snowflake, member *dasgo.GuildMember) *dasgo.GuildMember {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	member = clone(member)
+	w.createMember(guildID, member)
+
+	return clone(member)
+}
+
+// createMember creates a member.
+func (w *World) createMember(guildID dasgo.Snowflake, member *dasgo.GuildMember) {
+	member.User = clone(member.User)
+	if member.User == nil {
+		member.User = new(dasgo.User)
+	}
+
+	if member.User.ID == 0 {
+		member.User.ID = w.snowflake()
+	}
+
+	if _, ok := w.users[member.User.ID]; !ok {
+		w.users[member.User.ID] = clone(member.User)
+	}
+
+	if member.JoinedAt.IsZero() {
+		member.JoinedAt = time.Now().UTC()
+	}
+
+	if member.Roles == nil {
+		member.Roles = []*dasgo.Snowflake{}
+	}
+
+	put(w.members, guildID, member.User.ID, member)
+}
+
+// Member returns a guild member, or nil when the member does not exist.
+func (w *World) Member(guildID, userID dasgo.Snowflake) *dasgo.GuildMember {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return clone(w.members[guildID][userID])
+}
+
+// AddMessage adds a message, which is assigned an ID when its ID is 0.
+//
+// The message is authored by the current user when its author is nil.
+func (w *World) AddMessage(message *dasgo.Message) *dasgo.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	message = clone(message)
+	if message.ID == 0 {
+		message.ID = w.snowflake()
+	}
+
+	if message.Author == nil {
+		message.Author = clone(w.user)
+	}
+
+	if message.Timestamp.IsZero() {
+		message.Timestamp = message.ID.Time().UTC()
+	}
+
+	if message.ChannelID != nil {
+		put(w.messages, *message.ChannelID, message.ID, message)
+	}
+
+	return clone(message)
+}
+
+// Message returns a message, or nil when the message does not exist.
+func (w *World) Message(channelID, id dasgo.Snowflake) *dasgo.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return clone(w.messages[channelID][id])
+}
+
+// Messages returns the messages of a channel, sorted from oldest to newest.
+func (w *World) Messages(channelID dasgo.Snowflake) []*dasgo.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return values(w.messages[channelID])
+}
+
+// AddWebhook adds a webhook, which is assigned an ID and token when they're empty.
+func (w *World) AddWebhook(webhook *dasgo.Webhook) *dasgo.Webhook {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	webhook = clone(webhook)
+	w.createWebhook(webhook)
+
+	return clone(webhook)
+}
+
+// createWebhook creates a webhook.
+func (w *World) createWebhook(webhook *dasgo.Webhook) {
+	if webhook.ID == 0 {
+		webhook.ID = w.snowflake()
+	}
+
+	if webhook.Type == 0 {
+		webhook.Type = dasgo.FlagWebhookTypeINCOMING
+	}
+
+	if webhook.Token == "" && webhook.Type == dasgo.FlagWebhookTypeINCOMING {
+		webhook.Token = token()
+	}
+
+	w.webhooks[webhook.ID] = webhook
+}
+
+// Webhook returns a webhook, or nil when the webhook does not exist.
+func (w *World) Webhook(id dasgo.Snowflake) *dasgo.Webhook {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return clone(w.webhooks[id])
+}
+
+// AddCommand adds an application command to a guild (or globally when guildID is 0).
+func (w *World) AddCommand(guildID dasgo.Snowflake, command *dasgo.ApplicationCommand) *dasgo.ApplicationCommand {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	command = clone(command)
+	w.createCommand(guildID, command)
+
+	return clone(command)
+}
+
+// createCommand creates an application command.
+func (w *World) createCommand(guildID dasgo.Snowflake, command *dasgo.ApplicationCommand) {
+	if command.ID == 0 {
+		command.ID = w.snowflake()
+	}
+
+	if command.Type == 0 {
+		command.Type = dasgo.FlagApplicationCommandTypeCHAT_INPUT
+	}
+
+	command.ApplicationID = w.user.ID
+	command.GuildID = guildID
+	command.Version = w.snowflake()
+	put(w.commands, guildID, command.ID, command)
+}
+
+// Commands returns the application commands of a guild (or the global commands when guildID is 0).
+func (w *World) Commands(guildID dasgo.Snowflake) []*dasgo.ApplicationCommand {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return values(w.commands[guildID])
+}
@@ -0,0 +1,441 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Dispatch Modes
+const (
+	FlagDispatchModeSERIAL   = 0
+	FlagDispatchModeGUILD    = 1
+	FlagDispatchModePARALLEL = 2
+)
+
+// EventHandler represents a function that handles any event.
+type EventHandler func(Event)
+
+// Middleware represents a function that wraps the handling of every event.
+//
+// A Middleware calls next to continue handling the event, or returns to drop it.
+type Middleware func(next EventHandler) EventHandler
+
+// Dispatcher represents an event dispatcher that calls the handlers subscribed to the type of an event.
+//
+// Events are dispatched from any source (i.e a gateway connection, a recorded session, or a test)
+// as pointers to the event structs of the Gateway Events (i.e *MessageCreate).
+type Dispatcher struct {
+	// Mode represents the Dispatch Mode used to call handlers.
+	//
+	// SERIAL handles one event at a time in the order of dispatch.
+	// GUILD handles one event at a time per guild, while events of different guilds are handled concurrently.
+	// PARALLEL handles every event concurrently.
+	Mode Flag
+
+	// PanicHandler is called when a handler or middleware panics.
+	//
+	// The panic is logged when PanicHandler is nil.
+	PanicHandler func(event Event, recovered interface{}, stack []byte)
+
+	mu         sync.RWMutex
+	handlers   map[reflect.Type][]*subscription
+	observers  map[reflect.Type][]*subscription
+	middleware []Middleware
+
+	queueMu sync.Mutex
+	queues  map[Snowflake]*dispatchQueue
+	wg      sync.WaitGroup
+}
+
+// subscription represents a handler subscribed to an event type.
+type subscription struct {
+	handle func(Event) bool
+	once   bool
+	done   int32
+}
+
+// dispatchQueue represents the pending events of a serially handled queue.
+type dispatchQueue struct {
+	events []Event
+}
+
+// NewDispatcher returns a new Dispatcher that uses the given Dispatch Mode.
+func NewDispatcher(mode Flag) *Dispatcher {
+	return &Dispatcher{
+		Mode:      mode,
+		handlers:  make(map[reflect.Type][]*subscription),
+		observers: make(map[reflect.Type][]*subscription),
+		queues:    make(map[Snowflake]*dispatchQueue),
+	}
+}
+
+// Use adds middleware to the dispatcher, which is called in the order it's added.
+func (d *Dispatcher) Use(middleware ...Middleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.middleware = append(d.middleware, middleware...)
+}
+
+// Handle subscribes a handler to events of type T and returns a function that unsubscribes it.
+func Handle[T any](d *Dispatcher, handler func(*T)) (remove func()) {
+	return subscribe(d, nil, false, handler)
+}
+
+// HandleOnce subscribes a handler that is called for the next event of type T.
+func HandleOnce[T any](d *Dispatcher, handler func(*T)) (remove func()) {
+	return subscribe(d, nil, true, handler)
+}
+
+// HandleIf subscribes a handler to events of type T that match the predicate.
+func HandleIf[T any](d *Dispatcher, predicate func(*T) bool, handler func(*T)) (remove func()) {
+	return subscribe(d, predicate, false, handler)
+}
+
+// HandleOnceIf subscribes a handler that is called for the next event of type T that matches the predicate.
+func HandleOnceIf[T any](d *Dispatcher, predicate func(*T) bool, handler func(*T)) (remove func()) {
+	return subscribe(d, predicate, true, handler)
+}
+
+// subscribe subscribes a handler to events of type T.
+func subscribe[T any](d *Dispatcher, predicate func(*T) bool, once bool, handler func(*T)) func() {
+	eventType, sub := newSubscription(predicate, once, handler)
+
+	d.mu.Lock()
+	d.handlers[eventType] = append(d.handlers[eventType], sub)
+	d.mu.Unlock()
+
+	return func() { d.unsubscribe(eventType, sub) }
+}
+
+// observe subscribes a handler to events of type T that match the predicate, which is called
+// when an event is dispatched rather than when it's handled (without middleware).
+//
+// An observer receives events while a handler blocks the queue of a SERIAL or GUILD Dispatcher,
+// so it must not block.
+func observe[T any](d *Dispatcher, predicate func(*T) bool, handler func(*T)) func() {
+	eventType, sub := newSubscription(predicate, false, handler)
+
+	d.mu.Lock()
+	d.observers[eventType] = append(d.observers[eventType], sub)
+	d.mu.Unlock()
+
+	return func() { d.unsubscribe(eventType, sub) }
+}
+
+// newSubscription returns a subscription of a handler to events of type T.
+func newSubscription[T any](predicate func(*T) bool, once bool, handler func(*T)) (reflect.Type, *subscription) {
+	eventType := reflect.TypeOf((*T)(nil))
+	sub := &subscription{once: once}
+	sub.handle = func(event Event) bool {
+		e := event.(*T)
+		if predicate != nil && !predicate(e) {
+			return false
+		}
+
+		if sub.once && !atomic.CompareAndSwapInt32(&sub.done, 0, 1) {
+			return false
+		}
+
+		handler(e)
+
+		return true
+	}
+
+	return eventType, sub
+}
+
+// unsubscribe removes a subscription.
+func (d *Dispatcher) unsubscribe(eventType reflect.Type, sub *subscription) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, subscriptions := range []map[reflect.Type][]*subscription{d.handlers, d.observers} {
+		subs := subscriptions[eventType]
+		for i, s := range subs {
+			if s == sub {
+				// copy the subscriptions since dispatched events may reference the current slice.
+				subscriptions[eventType] = append(append([]*subscription{}, subs[:i]...), subs[i+1:]...)
+				break
+			}
+		}
+
+		if len(subscriptions[eventType]) == 0 {
+			delete(subscriptions, eventType)
+		}
+	}
+}
+
+// Dispatch dispatches an event to its handlers according to the Dispatch Mode.
+//
+// Dispatch does not wait for the event to be handled.
+func (d *Dispatcher) Dispatch(event Event) {
+	if event == nil {
+		return
+	}
+
+	d.mu.RLock()
+	observers := d.observers[reflect.TypeOf(event)]
+	d.mu.RUnlock()
+
+	for _, sub := range observers {
+		d.call(event, sub)
+	}
+
+	d.wg.Add(1)
+
+	switch d.Mode {
+	case FlagDispatchModePARALLEL:
+		go func() {
+			defer d.wg.Done()
+			d.handle(event)
+		}()
+
+	case FlagDispatchModeGUILD:
+		d.enqueue(EventGuildID(event), event)
+
+	default:
+		d.enqueue(0, event)
+	}
+}
+
+// enqueue adds an event to a queue, which is drained by a goroutine that exits once the queue is empty.
+func (d *Dispatcher) enqueue(key Snowflake, event Event) {
+	d.queueMu.Lock()
+	defer d.queueMu.Unlock()
+
+	if queue, ok := d.queues[key]; ok {
+		queue.events = append(queue.events, event)
+		return
+	}
+
+	d.queues[key] = &dispatchQueue{events: []Event{event}}
+
+	go d.drain(key)
+}
+
+// drain handles the events of a queue in order.
+func (d *Dispatcher) drain(key Snowflake) {
+	for {
+		d.queueMu.Lock()
+		queue := d.queues[key]
+		if len(queue.events) == 0 {
+			delete(d.queues, key)
+			d.queueMu.Unlock()
+
+			return
+		}
+
+		event := queue.events[0]
+		queue.events[0] = nil
+		queue.events = queue.events[1:]
+		d.queueMu.Unlock()
+
+		d.handle(event)
+		d.wg.Done()
+	}
+}
+
+// Wait waits for every dispatched event to be handled.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// handle calls the middleware and handlers of an event.
+func (d *Dispatcher) handle(event Event) {
+	defer d.recover(event)
+
+	d.mu.RLock()
+	subs := d.handlers[reflect.TypeOf(event)]
+	middleware := d.middleware
+	d.mu.RUnlock()
+
+	var handler EventHandler = func(event Event) {
+		for _, sub := range subs {
+			d.call(event, sub)
+		}
+	}
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	handler(event)
+}
+
+// call calls a handler, which is unsubscribed when it's a one-shot handler that handled the event.
+func (d *Dispatcher) call(event Event, sub *subscription) {
+	defer d.recover(event)
+
+	if sub.handle(event) && sub.once {
+		d.unsubscribe(reflect.TypeOf(event), sub)
+	}
+}
+
+// recover recovers a panic that occurs while handling an event.
+func (d *Dispatcher) recover(event Event) {
+	if recovered := recover(); recovered != nil {
+		stack := debug.Stack()
+		if d.PanicHandler != nil {
+			d.PanicHandler(event, recovered, stack)
+			return
+		}
+
+		log.Printf("dasgo: panic while handling %T: %v\n%s", event, recovered, stack)
+	}
+}
+
+// EventGuildID returns the ID of the guild an event occurred in, or 0 when the event does not belong to a guild.
+func EventGuildID(event Event) Snowflake {
+	switch e := event.(type) {
+	case *GuildCreate:
+		if e.Guild != nil {
+			return e.ID
+		}
+
+	case *GuildUpdate:
+		if e.Guild != nil {
+			return e.ID
+		}
+
+	case *GuildDelete:
+		if e.Guild != nil {
+			return e.ID
+		}
+
+	default:
+		return structGuildID(event)
+	}
+
+	return 0
+}
+
+// structGuildID returns the value of the GuildID field of an event struct.
+func structGuildID(event Event) Snowflake {
+	value := reflect.ValueOf(event)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return 0
+	}
+
+	field, ok := value.Elem().Type().FieldByName("GuildID")
+	if !ok {
+		return 0
+	}
+
+	// FieldByIndexErr returns an error when the field is promoted through a nil embedded pointer.
+	guildID, err := value.Elem().FieldByIndexErr(field.Index)
+	if err != nil {
+		return 0
+	}
+
+	if guildID.Kind() == reflect.Ptr {
+		if guildID.IsNil() {
+			return 0
+		}
+
+		guildID = guildID.Elem()
+	}
+
+	switch guildID.Kind() {
+	case reflect.Uint64:
+		return Snowflake(guildID.Uint())
+	case reflect.String:
+		id, _ := strconv.ParseUint(guildID.String(), 10, 64)
+		return Snowflake(id)
+	}
+
+	return 0
+}
+
+// gatewayEvents maps Gateway Event Names to a function that returns a new event of the respective type.
+var gatewayEvents = map[string]func() Event{
+	FlagGatewayEventNameHello:                               func() Event { return new(Hello) },
+	FlagGatewayEventNameReady:                               func() Event { return new(Ready) },
+	FlagGatewayEventNameResumed:                             func() Event { return new(Resumed) },
+	FlagGatewayEventNameReconnect:                           func() Event { return new(Reconnect) },
+	FlagGatewayEventNameInvalidSession:                      func() Event { return new(InvalidSession) },
+	FlagGatewayEventNameApplicationCommandPermissionsUpdate: func() Event { return new(ApplicationCommandPermissionsUpdate) },
+	FlagGatewayEventNameChannelCreate:                       func() Event { return new(ChannelCreate) },
+	FlagGatewayEventNameChannelUpdate:                       func() Event { return new(ChannelUpdate) },
+	FlagGatewayEventNameChannelDelete:                       func() Event { return new(ChannelDelete) },
+	FlagGatewayEventNameChannelPinsUpdate:                   func() Event { return new(ChannelPinsUpdate) },
+	FlagGatewayEventNameThreadCreate:                        func() Event { return new(ThreadCreate) },
+	FlagGatewayEventNameThreadUpdate:                        func() Event { return new(ThreadUpdate) },
+	FlagGatewayEventNameThreadDelete:                        func() Event { return new(ThreadDelete) },
+	FlagGatewayEventNameThreadListSync:                      func() Event { return new(ThreadListSync) },
+	FlagGatewayEventNameThreadMemberUpdate:                  func() Event { return new(ThreadMemberUpdate) },
+	FlagGatewayEventNameThreadMembersUpdate:                 func() Event { return new(ThreadMembersUpdate) },
+	FlagGatewayEventNameGuildCreate:                         func() Event { return new(GuildCreate) },
+	FlagGatewayEventNameGuildUpdate:                         func() Event { return new(GuildUpdate) },
+	FlagGatewayEventNameGuildDelete:                         func() Event { return new(GuildDelete) },
+	FlagGatewayEventNameGuildBanAdd:                         func() Event { return new(GuildBanAdd) },
+	FlagGatewayEventNameGuildBanRemove:                      func() Event { return new(GuildBanRemove) },
+	FlagGatewayEventNameGuildEmojisUpdate:                   func() Event { return new(GuildEmojisUpdate) },
+	FlagGatewayEventNameGuildStickersUpdate:                 func() Event { return new(GuildStickersUpdate) },
+	FlagGatewayEventNameGuildIntegrationsUpdate:             func() Event { return new(GuildIntegrationsUpdate) },
+	FlagGatewayEventNameGuildMemberAdd:                      func() Event { return new(GuildMemberAdd) },
+	FlagGatewayEventNameGuildMemberRemove:                   func() Event { return new(GuildMemberRemove) },
+	FlagGatewayEventNameGuildMemberUpdate:                   func() Event { return new(GuildMemberUpdate) },
+	FlagGatewayEventNameGuildMembersChunk:                   func() Event { return new(GuildMembersChunk) },
+	FlagGatewayEventNameGuildRoleCreate:                     func() Event { return new(GuildRoleCreate) },
+	FlagGatewayEventNameGuildRoleUpdate:                     func() Event { return new(GuildRoleUpdate) },
+	FlagGatewayEventNameGuildRoleDelete:                     func() Event { return new(GuildRoleDelete) },
+	FlagGatewayEventNameGuildScheduledEventCreate:           func() Event { return new(GuildScheduledEventCreate) },
+	FlagGatewayEventNameGuildScheduledEventUpdate:           func() Event { return new(GuildScheduledEventUpdate) },
+	FlagGatewayEventNameGuildScheduledEventDelete:           func() Event { return new(GuildScheduledEventDelete) },
+	FlagGatewayEventNameGuildScheduledEventUserAdd:          func() Event { return new(GuildScheduledEventUserAdd) },
+	FlagGatewayEventNameGuildScheduledEventUserRemove:       func() Event { return new(GuildScheduledEventUserRemove) },
+	FlagGatewayEventNameIntegrationCreate:                   func() Event { return new(IntegrationCreate) },
+	FlagGatewayEventNameIntegrationUpdate:                   func() Event { return new(IntegrationUpdate) },
+	FlagGatewayEventNameIntegrationDelete:                   func() Event { return new(IntegrationDelete) },
+	FlagGatewayEventNameInteractionCreate:                   func() Event { return new(InteractionCreate) },
+	FlagGatewayEventNameInviteCreate:                        func() Event { return new(InviteCreate) },
+	FlagGatewayEventNameInviteDelete:                        func() Event { return new(InviteDelete) },
+	FlagGatewayEventNameMessageCreate:                       func() Event { return new(MessageCreate) },
+	FlagGatewayEventNameMessageUpdate:                       func() Event { return new(MessageUpdate) },
+	FlagGatewayEventNameMessageDelete:                       func() Event { return new(MessageDelete) },
+	FlagGatewayEventNameMessageDeleteBulk:                   func() Event { return new(MessageDeleteBulk) },
+	FlagGatewayEventNameMessageReactionAdd:                  func() Event { return new(MessageReactionAdd) },
+	FlagGatewayEventNameMessageReactionRemove:               func() Event { return new(MessageReactionRemove) },
+	FlagGatewayEventNameMessageReactionRemoveAll:            func() Event { return new(MessageReactionRemoveAll) },
+	FlagGatewayEventNameMessageReactionRemoveEmoji:          func() Event { return new(MessageReactionRemoveEmoji) },
+	FlagGatewayEventNamePresenceUpdate:                      func() Event { return new(PresenceUpdate) },
+	FlagGatewayEventNameStageInstanceCreate:                 func() Event { return new(StageInstanceCreate) },
+	FlagGatewayEventNameStageInstanceDelete:                 func() Event { return new(StageInstanceDelete) },
+	FlagGatewayEventNameStageInstanceUpdate:                 func() Event { return new(StageInstanceUpdate) },
+	FlagGatewayEventNameTypingStart:                         func() Event { return new(TypingStart) },
+	FlagGatewayEventNameUserUpdate:                          func() Event { return new(UserUpdate) },
+	FlagGatewayEventNameVoiceStateUpdate:                    func() Event { return new(VoiceStateUpdate) },
+	FlagGatewayEventNameVoiceServerUpdate:                   func() Event { return new(VoiceServerUpdate) },
+	FlagGatewayEventNameWebhooksUpdate:                      func() Event { return new(WebhooksUpdate) },
+}
+
+// NewEvent returns a new event for a Gateway Event Name, or nil when the name is unknown.
+func NewEvent(name string) Event {
+	if newEvent, ok := gatewayEvents[name]; ok {
+		return newEvent()
+	}
+
+	return nil
+}
+
+// DecodeEvent decodes the data of a Gateway Event into the event struct of its name.
+func DecodeEvent(name string, data json.RawMessage) (Event, error) {
+	event := NewEvent(name)
+	if event == nil {
+		return nil, fmt.Errorf("error decoding event: unknown event name %q", name)
+	}
+
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, fmt.Errorf("error decoding %s event: %w", name, err)
+	}
+
+	return event, nil
+}
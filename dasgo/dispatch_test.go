@@ -0,0 +1,418 @@
+package dasgo
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testEvent returns an event of a guild with a sequence number.
+func testEvent(guildID Snowflake, sequence int) *GuildMemberAdd {
+	return &GuildMemberAdd{GuildID: guildID, GuildMember: &GuildMember{User: &User{ID: Snowflake(sequence)}}}
+}
+
+// waitTimeout fails the test when wait doesn't return in time.
+func waitTimeout(t *testing.T, wait func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events to be handled")
+	}
+}
+
+func TestDispatchSerial(t *testing.T) {
+	d := NewDispatcher(FlagDispatchModeSERIAL)
+
+	var running int32
+	var order []int
+	Handle(d, func(e *GuildMemberAdd) {
+		if atomic.AddInt32(&running, 1) != 1 {
+			t.Error("expected events to be handled one at a time")
+		}
+
+		order = append(order, int(e.User.ID))
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	})
+
+	for i := 0; i < 20; i++ {
+		d.Dispatch(testEvent(Snowflake(i%3), i))
+	}
+
+	waitTimeout(t, d.Wait)
+
+	for i, sequence := range order {
+		if i != sequence {
+			t.Fatalf("expected events in the order of dispatch, got %v", order)
+		}
+	}
+
+	if len(order) != 20 {
+		t.Fatalf("expected 20 events, got %d", len(order))
+	}
+}
+
+func TestDispatchGuild(t *testing.T) {
+	d := NewDispatcher(FlagDispatchModeGUILD)
+
+	var mu sync.Mutex
+	order := make(map[Snowflake][]int)
+
+	// the first event of guild 1 blocks until an event of guild 2 is handled.
+	guild2 := make(chan struct{})
+	var once sync.Once
+	Handle(d, func(e *GuildMemberAdd) {
+		if e.GuildID == 1 && e.User.ID == 0 {
+			select {
+			case <-guild2:
+			case <-time.After(5 * time.Second):
+				t.Error("expected the events of guild 2 to be handled concurrently")
+			}
+		}
+
+		if e.GuildID == 2 {
+			once.Do(func() { close(guild2) })
+		}
+
+		mu.Lock()
+		order[e.GuildID] = append(order[e.GuildID], int(e.User.ID))
+		mu.Unlock()
+	})
+
+	for i := 0; i < 10; i++ {
+		d.Dispatch(testEvent(1, i))
+		d.Dispatch(testEvent(2, i))
+	}
+
+	waitTimeout(t, d.Wait)
+
+	want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	for _, guildID := range []Snowflake{1, 2} {
+		if !reflect.DeepEqual(order[guildID], want) {
+			t.Errorf("expected the events of guild %d in order, got %v", guildID, order[guildID])
+		}
+	}
+}
+
+func TestDispatchParallel(t *testing.T) {
+	d := NewDispatcher(FlagDispatchModePARALLEL)
+
+	// each handler blocks until every event is being handled.
+	const events = 5
+	var started sync.WaitGroup
+	started.Add(events)
+
+	var handled int32
+	all := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(all)
+	}()
+
+	Handle(d, func(e *GuildMemberAdd) {
+		started.Done()
+		select {
+		case <-all:
+			atomic.AddInt32(&handled, 1)
+		case <-time.After(5 * time.Second):
+			t.Error("expected the events to be handled concurrently")
+		}
+	})
+
+	for i := 0; i < events; i++ {
+		d.Dispatch(testEvent(0, i))
+	}
+
+	waitTimeout(t, d.Wait)
+
+	if handled != events {
+		t.Fatalf("expected %d events, got %d", events, handled)
+	}
+}
+
+func TestHandleOnce(t *testing.T) {
+	for _, mode := range []Flag{FlagDispatchModeSERIAL, FlagDispatchModeGUILD, FlagDispatchModePARALLEL} {
+		d := NewDispatcher(mode)
+
+		var calls, filtered int32
+		HandleOnce(d, func(*GuildMemberAdd) { atomic.AddInt32(&calls, 1) })
+		HandleOnceIf(d, func(e *GuildMemberAdd) bool { return e.User.ID == 50 }, func(*GuildMemberAdd) {
+			atomic.AddInt32(&filtered, 1)
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				d.Dispatch(testEvent(Snowflake(i%4), i))
+			}(i)
+		}
+
+		wg.Wait()
+		waitTimeout(t, d.Wait)
+
+		if calls != 1 || filtered != 1 {
+			t.Errorf("expected each one-shot handler to be called once in mode %d, got %d and %d", mode, calls, filtered)
+		}
+
+		d.mu.RLock()
+		subscriptions := len(d.handlers)
+		d.mu.RUnlock()
+
+		if subscriptions != 0 {
+			t.Errorf("expected the one-shot handlers to be unsubscribed in mode %d", mode)
+		}
+	}
+}
+
+func TestHandleRemove(t *testing.T) {
+	d := NewDispatcher(FlagDispatchModeSERIAL)
+
+	var calls int32
+	remove := Handle(d, func(*GuildMemberAdd) { atomic.AddInt32(&calls, 1) })
+	Handle(d, func(*GuildMemberRemove) { t.Error("expected a handler of another type to be ignored") })
+
+	d.Dispatch(testEvent(0, 0))
+	waitTimeout(t, d.Wait)
+
+	remove()
+	d.Dispatch(testEvent(0, 1))
+	waitTimeout(t, d.Wait)
+
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	d := NewDispatcher(FlagDispatchModeSERIAL)
+
+	var order []string
+	middleware := func(name string) Middleware {
+		return func(next EventHandler) EventHandler {
+			return func(event Event) {
+				order = append(order, name+" before")
+				next(event)
+				order = append(order, name+" after")
+			}
+		}
+	}
+
+	d.Use(middleware("a"), middleware("b"))
+	d.Use(func(next EventHandler) EventHandler {
+		return func(event Event) {
+			// drop the events of guild 1.
+			if EventGuildID(event) != 1 {
+				next(event)
+			}
+		}
+	})
+
+	Handle(d, func(*GuildMemberAdd) { order = append(order, "handler") })
+
+	d.Dispatch(testEvent(0, 0))
+	d.Dispatch(testEvent(1, 1))
+	waitTimeout(t, d.Wait)
+
+	want := []string{
+		"a before", "b before", "handler", "b after", "a after",
+		"a before", "b before", "b after", "a after",
+	}
+
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("expected %q, got %q", want, order)
+	}
+}
+
+func TestPanicHandler(t *testing.T) {
+	d := NewDispatcher(FlagDispatchModeSERIAL)
+
+	var recovered []interface{}
+	d.PanicHandler = func(event Event, r interface{}, stack []byte) {
+		if _, ok := event.(*GuildMemberAdd); !ok || len(stack) == 0 {
+			t.Errorf("expected the event and stack of the panic, got %T", event)
+		}
+
+		recovered = append(recovered, r)
+	}
+
+	var calls int32
+	Handle(d, func(*GuildMemberAdd) { panic("handler") })
+	Handle(d, func(*GuildMemberAdd) { atomic.AddInt32(&calls, 1) })
+
+	d.Dispatch(testEvent(0, 0))
+	waitTimeout(t, d.Wait)
+
+	if !reflect.DeepEqual(recovered, []interface{}{"handler"}) {
+		t.Fatalf("expected the panic to be recovered, got %v", recovered)
+	}
+
+	if calls != 1 {
+		t.Fatal("expected the next handler to be called after a panic")
+	}
+
+	// a panic in middleware drops the event.
+	d.Use(func(next EventHandler) EventHandler {
+		return func(Event) { panic("middleware") }
+	})
+
+	d.Dispatch(testEvent(0, 1))
+	waitTimeout(t, d.Wait)
+
+	if len(recovered) != 2 || recovered[1] != "middleware" || calls != 1 {
+		t.Fatalf("expected the middleware panic to be recovered, got %v", recovered)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	for _, mode := range []Flag{FlagDispatchModeSERIAL, FlagDispatchModeGUILD, FlagDispatchModePARALLEL} {
+		d := NewDispatcher(mode)
+
+		var mu sync.Mutex
+		var recovered []interface{}
+		d.PanicHandler = func(event Event, r interface{}, stack []byte) {
+			mu.Lock()
+			recovered = append(recovered, r)
+			mu.Unlock()
+		}
+
+		// handlers block until they're released, and middleware drops the events of guild 1.
+		release := make(chan struct{})
+		var handled int32
+		Handle(d, func(*GuildMemberAdd) {
+			<-release
+			atomic.AddInt32(&handled, 1)
+		})
+
+		d.Use(func(next EventHandler) EventHandler {
+			return func(event Event) {
+				if EventGuildID(event) != 1 {
+					next(event)
+				}
+			}
+		})
+
+		var observed []int
+		observe(d, func(e *GuildMemberAdd) bool { return e.GuildID != 2 }, func(e *GuildMemberAdd) {
+			observed = append(observed, int(e.User.ID))
+		})
+
+		observe(d, nil, func(*GuildMemberAdd) { panic("observer") })
+
+		var removed int
+		remove := observe(d, nil, func(*GuildMemberAdd) { removed++ })
+
+		for i := 0; i < 6; i++ {
+			d.Dispatch(testEvent(Snowflake(i%3), i))
+			if i == 0 {
+				remove()
+			}
+		}
+
+		// observers are called before Dispatch returns, while the handlers are blocked.
+		if want := []int{0, 1, 3, 4}; !reflect.DeepEqual(observed, want) {
+			t.Fatalf("expected observed events %v in mode %d, got %v", want, mode, observed)
+		}
+
+		if removed != 1 {
+			t.Fatalf("expected a removed observer to observe 1 event in mode %d, got %d", mode, removed)
+		}
+
+		mu.Lock()
+		panics := len(recovered)
+		mu.Unlock()
+
+		if panics != 6 {
+			t.Fatalf("expected 6 recovered observer panics in mode %d, got %d", mode, panics)
+		}
+
+		if atomic.LoadInt32(&handled) != 0 {
+			t.Fatalf("expected no handled events before the handlers are released in mode %d", mode)
+		}
+
+		close(release)
+		waitTimeout(t, d.Wait)
+
+		if handled != 4 {
+			t.Fatalf("expected 4 handled events in mode %d, got %d", mode, handled)
+		}
+	}
+}
+
+func TestPanicLog(t *testing.T) {
+	writer := log.Writer()
+	defer log.SetOutput(writer)
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+
+	d := NewDispatcher(FlagDispatchModeSERIAL)
+	Handle(d, func(*GuildMemberAdd) { panic("logged") })
+
+	d.Dispatch(testEvent(0, 0))
+	waitTimeout(t, d.Wait)
+
+	if s := output.String(); !strings.Contains(s, "panic while handling *dasgo.GuildMemberAdd: logged") {
+		t.Fatalf("expected the panic to be logged, got %q", s)
+	}
+}
+
+func TestWait(t *testing.T) {
+	for _, mode := range []Flag{FlagDispatchModeSERIAL, FlagDispatchModeGUILD, FlagDispatchModePARALLEL} {
+		d := NewDispatcher(mode)
+
+		var handled int32
+		Handle(d, func(*GuildMemberAdd) {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&handled, 1)
+		})
+
+		for i := 0; i < 10; i++ {
+			d.Dispatch(testEvent(Snowflake(i%2), i))
+		}
+
+		waitTimeout(t, d.Wait)
+
+		if handled != 10 {
+			t.Errorf("expected Wait to return after 10 events in mode %d, got %d", mode, handled)
+		}
+	}
+
+	// Wait returns immediately when no events are dispatched.
+	waitTimeout(t, NewDispatcher(FlagDispatchModeSERIAL).Wait)
+}
+
+func TestEventGuildID(t *testing.T) {
+	guildID := Snowflake(5)
+	tests := []struct {
+		event Event
+		want  Snowflake
+	}{
+		{event: &GuildCreate{Guild: &Guild{ID: 1}}, want: 1},
+		{event: &GuildCreate{}, want: 0},
+		{event: &GuildMemberAdd{GuildID: 2}, want: 2},
+		{event: &MessageCreate{Message: &Message{GuildID: &guildID}}, want: 5},
+		{event: &MessageCreate{}, want: 0},
+		{event: &Ready{}, want: 0},
+	}
+
+	for _, test := range tests {
+		if guildID := EventGuildID(test.event); guildID != test.want {
+			t.Errorf("expected guild %d for %T, got %d", test.want, test.event, guildID)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// EmbedBuilder represents a fluent builder for an Embed.
+type EmbedBuilder struct {
+	embed   *Embed
+	dropped []*EmbedField
+}
+
+// NewEmbed returns a new EmbedBuilder.
+func NewEmbed() *EmbedBuilder {
+	return &EmbedBuilder{embed: new(Embed)}
+}
+
+// Title sets the title of the embed.
+func (b *EmbedBuilder) Title(title string) *EmbedBuilder {
+	b.embed.Title = &title
+	return b
+}
+
+// Description sets the description of the embed.
+func (b *EmbedBuilder) Description(description string) *EmbedBuilder {
+	b.embed.Description = &description
+	return b
+}
+
+// URL sets the URL of the embed.
+func (b *EmbedBuilder) URL(url string) *EmbedBuilder {
+	b.embed.URL = &url
+	return b
+}
+
+// Timestamp sets the timestamp of the embed.
+func (b *EmbedBuilder) Timestamp(timestamp time.Time) *EmbedBuilder {
+	b.embed.Timestamp = &timestamp
+	return b
+}
+
+// Color sets the color of the embed (i.e 0xFF0000).
+func (b *EmbedBuilder) Color(color int) *EmbedBuilder {
+	b.embed.Color = &color
+	return b
+}
+
+// ColorRGB sets the color of the embed using red, green, and blue components.
+func (b *EmbedBuilder) ColorRGB(red, green, blue uint8) *EmbedBuilder {
+	return b.Color(int(red)<<16 | int(green)<<8 | int(blue))
+}
+
+// Footer sets the footer of the embed. An empty iconURL is omitted.
+func (b *EmbedBuilder) Footer(text, iconURL string) *EmbedBuilder {
+	b.embed.Footer = &EmbedFooter{Text: text, IconURL: optionalString(iconURL)}
+	return b
+}
+
+// Image sets the image of the embed.
+func (b *EmbedBuilder) Image(url string) *EmbedBuilder {
+	b.embed.Image = &EmbedImage{URL: url}
+	return b
+}
+
+// Thumbnail sets the thumbnail of the embed.
+func (b *EmbedBuilder) Thumbnail(url string) *EmbedBuilder {
+	b.embed.Thumbnail = &EmbedThumbnail{URL: url}
+	return b
+}
+
+// Author sets the author of the embed. An empty url or iconURL is omitted.
+func (b *EmbedBuilder) Author(name, url, iconURL string) *EmbedBuilder {
+	b.embed.Author = &EmbedAuthor{Name: name, URL: optionalString(url), IconURL: optionalString(iconURL)}
+	return b
+}
+
+// Field adds a field to the embed.
+func (b *EmbedBuilder) Field(name, value string) *EmbedBuilder {
+	b.embed.Fields = append(b.embed.Fields, &EmbedField{Name: name, Value: value})
+	return b
+}
+
+// InlineField adds an inline field to the embed.
+func (b *EmbedBuilder) InlineField(name, value string) *EmbedBuilder {
+	inline := true
+	b.embed.Fields = append(b.embed.Fields, &EmbedField{Name: name, Value: value, Inline: &inline})
+	return b
+}
+
+// Truncate truncates the values of the embed that exceed the Embed Limits.
+//
+// The fields that are removed from the embed are reported by Dropped.
+func (b *EmbedBuilder) Truncate() *EmbedBuilder {
+	b.dropped = append(b.dropped, b.embed.Truncate()...)
+	return b
+}
+
+// Dropped returns the fields that were removed from the embed by Truncate.
+func (b *EmbedBuilder) Dropped() []*EmbedField {
+	return b.dropped
+}
+
+// Build returns the embed.
+func (b *EmbedBuilder) Build() *Embed {
+	return b.embed
+}
+
+// Validate reports every violation of the Embed Limits.
+//
+// Characters are counted as Unicode code points. The returned error is nil or ValidationErrors.
+func (e *Embed) Validate() error {
+	v := new(validator)
+	v.validateEmbed("", e)
+
+	if n := e.Length(); n > FlagEmbedLimitTotal {
+		v.add("", "must contain at most %d characters (found %d)", FlagEmbedLimitTotal, n)
+	}
+
+	return v.err()
+}
+
+// ValidateEmbeds reports every violation of the Embed Limits for the embeds of a single message.
+//
+// The total character limit applies to the combined embeds of a message.
+// The returned error is nil or ValidationErrors.
+func ValidateEmbeds(embeds []*Embed) error {
+	v := new(validator)
+	if len(embeds) > FlagEmbedLimitMessage {
+		v.add("embeds", "must contain at most %d embeds (found %d)", FlagEmbedLimitMessage, len(embeds))
+	}
+
+	total := 0
+	for i, embed := range embeds {
+		path := fmt.Sprintf("embeds[%d]", i)
+		if embed == nil {
+			v.add(path, "must not be null")
+			continue
+		}
+
+		v.validateEmbed(path, embed)
+		total += embed.Length()
+	}
+
+	if total > FlagEmbedLimitTotal {
+		v.add("embeds", "must contain at most %d characters combined (found %d)", FlagEmbedLimitTotal, total)
+	}
+
+	return v.err()
+}
+
+// Length returns the number of characters in the embed that count towards the total character limit.
+func (e *Embed) Length() int {
+	n := optionalLength(e.Title) + optionalLength(e.Description)
+	for _, field := range e.Fields {
+		if field != nil {
+			n += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		}
+	}
+
+	if e.Footer != nil {
+		n += utf8.RuneCountInString(e.Footer.Text)
+	}
+
+	if e.Author != nil {
+		n += utf8.RuneCountInString(e.Author.Name)
+	}
+
+	return n
+}
+
+// Truncate truncates the values of the embed that exceed the Embed Limits using an ellipsis,
+// and returns the fields that are removed from the embed.
+//
+// Fields beyond the field limit are removed. When the embed still exceeds the
+// total character limit, the description is shortened, then trailing fields are removed.
+func (e *Embed) Truncate() []*EmbedField {
+	var dropped []*EmbedField
+	if e.Title != nil {
+		*e.Title = truncate(*e.Title, FlagEmbedLimitTitle)
+	}
+
+	if e.Description != nil {
+		*e.Description = truncate(*e.Description, FlagEmbedLimitDescription)
+	}
+
+	if len(e.Fields) > FlagEmbedLimitFields {
+		dropped = append(dropped, e.Fields[FlagEmbedLimitFields:]...)
+		e.Fields = e.Fields[:FlagEmbedLimitFields]
+	}
+
+	for _, field := range e.Fields {
+		if field != nil {
+			field.Name = truncate(field.Name, FlagEmbedLimitFieldName)
+			field.Value = truncate(field.Value, FlagEmbedLimitFieldValue)
+		}
+	}
+
+	if e.Footer != nil {
+		e.Footer.Text = truncate(e.Footer.Text, FlagEmbedLimitFooterText)
+	}
+
+	if e.Author != nil {
+		e.Author.Name = truncate(e.Author.Name, FlagEmbedLimitAuthorName)
+	}
+
+	excess := e.Length() - FlagEmbedLimitTotal
+	if excess <= 0 {
+		return dropped
+	}
+
+	if e.Description != nil {
+		length := utf8.RuneCountInString(*e.Description)
+		if limit := length - excess; limit > 0 {
+			*e.Description = truncate(*e.Description, limit)
+			return dropped
+		}
+
+		e.Description = nil
+	}
+
+	// the trailing fields precede the fields beyond the field limit.
+	fields := e.Fields
+	for len(e.Fields) != 0 && e.Length() > FlagEmbedLimitTotal {
+		e.Fields = e.Fields[:len(e.Fields)-1]
+	}
+
+	return append(fields[len(e.Fields):len(fields):len(fields)], dropped...)
+}
+
+// validateEmbed validates the per-field Embed Limits of an embed.
+func (v *validator) validateEmbed(path string, e *Embed) {
+	if e.Title != nil {
+		v.validateLength(joinPath(path, "title"), *e.Title, 0, FlagEmbedLimitTitle)
+	}
+
+	if e.Description != nil {
+		v.validateLength(joinPath(path, "description"), *e.Description, 0, FlagEmbedLimitDescription)
+	}
+
+	if e.Color != nil && (*e.Color < 0 || *e.Color > 0xFFFFFF) {
+		v.add(joinPath(path, "color"), "must be between 0x000000 and 0xFFFFFF")
+	}
+
+	if len(e.Fields) > FlagEmbedLimitFields {
+		v.add(joinPath(path, "fields"), "must contain at most %d fields (found %d)", FlagEmbedLimitFields, len(e.Fields))
+	}
+
+	for i, field := range e.Fields {
+		fieldPath := joinPath(path, fmt.Sprintf("fields[%d]", i))
+		if field == nil {
+			v.add(fieldPath, "must not be null")
+			continue
+		}
+
+		v.validateLength(joinPath(fieldPath, "name"), field.Name, 1, FlagEmbedLimitFieldName)
+		v.validateLength(joinPath(fieldPath, "value"), field.Value, 1, FlagEmbedLimitFieldValue)
+	}
+
+	if e.Footer != nil {
+		v.validateLength(joinPath(path, "footer.text"), e.Footer.Text, 1, FlagEmbedLimitFooterText)
+	}
+
+	if e.Author != nil {
+		v.validateLength(joinPath(path, "author.name"), e.Author.Name, 1, FlagEmbedLimitAuthorName)
+	}
+}
+
+// validateLength validates the number of characters in a string.
+func (v *validator) validateLength(path, s string, min, max int) {
+	if n := utf8.RuneCountInString(s); n < min || n > max {
+		if min == 0 {
+			v.add(path, "must be at most %d characters (found %d)", max, n)
+		} else {
+			v.add(path, "must be %d-%d characters (found %d)", min, max, n)
+		}
+	}
+}
+
+// truncate shortens a string to limit characters (including a trailing ellipsis).
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	if limit <= 0 {
+		return ""
+	}
+
+	runes := []rune(s)
+
+	return string(runes[:limit-1]) + "…"
+}
+
+// optionalLength returns the number of characters in an optional string.
+func optionalLength(s *string) int {
+	if s == nil {
+		return 0
+	}
+
+	return utf8.RuneCountInString(*s)
+}
+
+// optionalString returns a pointer to a non-empty string or nil.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,199 @@
+package dasgo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// testFields returns n fields with values of length characters.
+func testFields(n, length int) []*EmbedField {
+	fields := make([]*EmbedField, n)
+	for i := range fields {
+		fields[i] = &EmbedField{Name: fmt.Sprint(i), Value: strings.Repeat("v", length)}
+	}
+
+	return fields
+}
+
+func TestEmbedValidate(t *testing.T) {
+	long := func(n int) *string {
+		s := strings.Repeat("é", n)
+		return &s
+	}
+
+	color := 0x1000000
+
+	tests := []struct {
+		name  string
+		embed *Embed
+		paths []string
+	}{
+		{
+			name:  "valid",
+			embed: NewEmbed().Title("title").Description("description").Field("name", "value").Build(),
+		},
+		{
+			name:  "title counts code points",
+			embed: &Embed{Title: long(FlagEmbedLimitTitle)},
+		},
+		{
+			name:  "title",
+			embed: &Embed{Title: long(FlagEmbedLimitTitle + 1)},
+			paths: []string{"title"},
+		},
+		{
+			name:  "description",
+			embed: &Embed{Description: long(FlagEmbedLimitDescription + 1)},
+			paths: []string{"description"},
+		},
+		{
+			name:  "color",
+			embed: &Embed{Color: &color},
+			paths: []string{"color"},
+		},
+		{
+			name:  "field limit",
+			embed: &Embed{Fields: testFields(FlagEmbedLimitFields+1, 1)},
+			paths: []string{"fields"},
+		},
+		{
+			name: "field name and value",
+			embed: &Embed{Fields: []*EmbedField{
+				{Name: "", Value: strings.Repeat("v", FlagEmbedLimitFieldValue+1)},
+				nil,
+			}},
+			paths: []string{"fields[0].name", "fields[0].value", "fields[1]"},
+		},
+		{
+			name: "footer and author",
+			embed: &Embed{
+				Footer: &EmbedFooter{Text: *long(FlagEmbedLimitFooterText + 1)},
+				Author: &EmbedAuthor{Name: *long(FlagEmbedLimitAuthorName + 1)},
+			},
+			paths: []string{"footer.text", "author.name"},
+		},
+		{
+			name:  "total",
+			embed: &Embed{Description: long(FlagEmbedLimitDescription), Fields: testFields(2, FlagEmbedLimitFieldValue)},
+			paths: []string{""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if paths := validationPaths(t, test.embed.Validate()); !reflect.DeepEqual(paths, test.paths) {
+				t.Fatalf("expected violations at %q, got %q", test.paths, paths)
+			}
+		})
+	}
+}
+
+func TestValidateEmbeds(t *testing.T) {
+	embeds := make([]*Embed, FlagEmbedLimitMessage+1)
+	for i := range embeds {
+		embeds[i] = NewEmbed().Title("title").Build()
+	}
+
+	want := []string{"embeds"}
+	if paths := validationPaths(t, ValidateEmbeds(embeds)); !reflect.DeepEqual(paths, want) {
+		t.Errorf("expected violations at %q, got %q", want, paths)
+	}
+
+	// the total character limit applies to the combined embeds.
+	description := strings.Repeat("d", FlagEmbedLimitTotal/2+1)
+	embeds = []*Embed{{Description: &description}, nil, {Description: &description}}
+
+	want = []string{"embeds[1]", "embeds"}
+	if paths := validationPaths(t, ValidateEmbeds(embeds)); !reflect.DeepEqual(paths, want) {
+		t.Errorf("expected violations at %q, got %q", want, paths)
+	}
+}
+
+func TestEmbedLength(t *testing.T) {
+	embed := NewEmbed().
+		Title("ab").
+		Description("cd").
+		Field("é", "f").
+		Footer("g", "https://example.com/icon.png").
+		Author("h", "https://example.com", "").
+		URL("https://example.com/ignored").
+		Build()
+
+	if n := embed.Length(); n != 8 {
+		t.Fatalf("expected 8 characters, got %d", n)
+	}
+}
+
+func TestEmbedTruncate(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		embed := NewEmbed().
+			Title(strings.Repeat("t", FlagEmbedLimitTitle+1)).
+			Field(strings.Repeat("n", FlagEmbedLimitFieldName+1), strings.Repeat("v", FlagEmbedLimitFieldValue+1)).
+			Build()
+
+		if dropped := embed.Truncate(); len(dropped) != 0 {
+			t.Errorf("expected no dropped fields, got %d", len(dropped))
+		}
+
+		if n := utf8.RuneCountInString(*embed.Title); n != FlagEmbedLimitTitle || !strings.HasSuffix(*embed.Title, "…") {
+			t.Errorf("expected a title of %d characters ending with an ellipsis, got %d", FlagEmbedLimitTitle, n)
+		}
+
+		if err := embed.Validate(); err != nil {
+			t.Errorf("expected a valid embed, got %v", err)
+		}
+	})
+
+	t.Run("field limit", func(t *testing.T) {
+		b := NewEmbed()
+		for _, field := range testFields(FlagEmbedLimitFields+2, 1) {
+			b.Field(field.Name, field.Value)
+		}
+
+		embed := b.Truncate().Build()
+		if len(embed.Fields) != FlagEmbedLimitFields {
+			t.Fatalf("expected %d fields, got %d", FlagEmbedLimitFields, len(embed.Fields))
+		}
+
+		dropped := b.Dropped()
+		if len(dropped) != 2 || dropped[0].Name != "25" || dropped[1].Name != "26" {
+			t.Fatalf("expected fields 25 and 26 to be dropped, got %v", dropped)
+		}
+	})
+
+	t.Run("total shortens the description", func(t *testing.T) {
+		description := strings.Repeat("d", FlagEmbedLimitDescription)
+		embed := &Embed{Description: &description, Fields: testFields(2, FlagEmbedLimitFieldValue)}
+
+		if dropped := embed.Truncate(); len(dropped) != 0 {
+			t.Errorf("expected no dropped fields, got %d", len(dropped))
+		}
+
+		if n := embed.Length(); n != FlagEmbedLimitTotal {
+			t.Errorf("expected %d characters, got %d", FlagEmbedLimitTotal, n)
+		}
+	})
+
+	t.Run("total drops trailing fields", func(t *testing.T) {
+		embed := &Embed{Fields: testFields(FlagEmbedLimitFields+1, FlagEmbedLimitFieldValue)}
+
+		dropped := embed.Truncate()
+		if n := embed.Length(); n > FlagEmbedLimitTotal {
+			t.Fatalf("expected at most %d characters, got %d", FlagEmbedLimitTotal, n)
+		}
+
+		if len(embed.Fields)+len(dropped) != FlagEmbedLimitFields+1 {
+			t.Fatalf("expected %d kept and dropped fields, got %d and %d",
+				FlagEmbedLimitFields+1, len(embed.Fields), len(dropped))
+		}
+
+		for i, field := range dropped {
+			if want := fmt.Sprint(len(embed.Fields) + i); field.Name != want {
+				t.Fatalf("expected dropped field %d to be field %s, got %s", i, want, field.Name)
+			}
+		}
+	})
+}
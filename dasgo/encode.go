@@ -0,0 +1,203 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// File represents a file that is uploaded with a multipart/form-data request.
+type File struct {
+	Name string
+
+	// ContentType represents the MIME type of the file (application/octet-stream when empty).
+	ContentType string
+
+	Data []byte
+}
+
+// taggedFields calls fn with the name and value of each field of a struct with a tag key
+// (i.e `url`), including the fields of embedded structs, which are shadowed by shallower fields.
+//
+// A field is skipped when its tag is "-", or when its tag has the omitempty option and its value
+// is empty. A field without a tag name is named after the field.
+func taggedFields(v interface{}, key string, fn func(name string, field reflect.Value)) {
+	seen := make(map[string]bool)
+
+	var walk func(rv reflect.Value)
+	walk = func(rv reflect.Value) {
+		rv = reflect.Indirect(rv)
+		if !rv.IsValid() || rv.Kind() != reflect.Struct {
+			return
+		}
+
+		var embedded []reflect.Value
+		for i := 0; i < rv.NumField(); i++ {
+			structField := rv.Type().Field(i)
+			tag, ok := structField.Tag.Lookup(key)
+			if !ok {
+				if structField.Anonymous && structField.IsExported() {
+					embedded = append(embedded, rv.Field(i))
+				}
+
+				continue
+			}
+
+			name, options, _ := strings.Cut(tag, ",")
+			if name == "-" || !structField.IsExported() {
+				continue
+			}
+
+			if name == "" {
+				name = structField.Name
+			}
+
+			field := rv.Field(i)
+			if seen[name] || (contains(strings.Split(options, ","), "omitempty") && isEmptyValue(field)) {
+				continue
+			}
+
+			seen[name] = true
+			fn(name, field)
+		}
+
+		for _, field := range embedded {
+			walk(field)
+		}
+	}
+
+	walk(reflect.ValueOf(v))
+}
+
+// isEmptyValue returns whether a value is omitted by the omitempty option,
+// which is a zero value, or an empty array, slice, map, or string.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+// formatValue returns the text of a field value, or false when the value is nil.
+func formatValue(field reflect.Value) (string, bool) {
+	if field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return "", false
+		}
+
+		field = field.Elem()
+	}
+
+	if marshaler, ok := field.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text), true
+		}
+	}
+
+	return fmt.Sprint(field.Interface()), true
+}
+
+// queryValues returns the URL Query String Parameters of a struct with `url` tags.
+func queryValues(v interface{}) url.Values {
+	values := make(url.Values)
+	taggedFields(v, "url", func(name string, field reflect.Value) {
+		if text, ok := formatValue(field); ok {
+			values.Set(name, text)
+		}
+	})
+
+	return values
+}
+
+// headerValues returns the headers of a struct with `http` tags.
+func headerValues(v interface{}) http.Header {
+	header := make(http.Header)
+	taggedFields(v, "http", func(name string, field reflect.Value) {
+		if text, ok := formatValue(field); ok {
+			header.Set(name, text)
+		}
+	})
+
+	return header
+}
+
+// jsonPayload returns the JSON payload of a struct with `json` tags, which is encoded
+// by the struct when it implements json.Marshaler.
+func jsonPayload(v interface{}) ([]byte, error) {
+	if marshaler, ok := v.(json.Marshaler); ok {
+		return marshaler.MarshalJSON()
+	}
+
+	var err error
+	fields := make(map[string]json.RawMessage)
+	taggedFields(v, "json", func(name string, field reflect.Value) {
+		if err != nil {
+			return
+		}
+
+		fields[name], err = json.Marshal(field.Interface())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// requestBody returns the body and content type of a request, which is a multipart/form-data
+// body (with a payload_json field) when files are uploaded, and a JSON body otherwise.
+//
+// The JSON payload only contains the fields of the request with a `json` tag.
+func requestBody(request interface{}, files []*File) ([]byte, string, error) {
+	payload, err := jsonPayload(request)
+	if err != nil {
+		return nil, "", fmt.Errorf("error encoding request: %w", err)
+	}
+
+	if len(files) == 0 {
+		return payload, "application/json", nil
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("payload_json", string(payload)); err != nil {
+		return nil, "", fmt.Errorf("error encoding request: %w", err)
+	}
+
+	for i, file := range files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[%d]"; filename=%q`, i, file.Name))
+		header.Set("Content-Type", contentType)
+
+		part, err := form.CreatePart(header)
+		if err != nil {
+			return nil, "", fmt.Errorf("error encoding file %q: %w", file.Name, err)
+		}
+
+		if _, err := part.Write(file.Data); err != nil {
+			return nil, "", fmt.Errorf("error encoding file %q: %w", file.Name, err)
+		}
+	}
+
+	if err := form.Close(); err != nil {
+		return nil, "", fmt.Errorf("error encoding request: %w", err)
+	}
+
+	return body.Bytes(), form.FormDataContentType(), nil
+}
@@ -174,3 +174,25 @@ const (
 	EndpointGetCurrentBotApplicationInformation    = "oauth2/applications/@me"
 	EndpointGetCurrentAuthorizationInformation     = "oauth2/@me"
 )
+
+// CDN Endpoints
+// https://discord.com/developers/docs/reference#image-formatting-cdn-endpoints
+const (
+	CDNEndpointBaseURL                  = "https://cdn.discordapp.com/"
+	CDNEndpointCustomEmoji              = "emojis/{emoji.id}"
+	CDNEndpointGuildIcon                = "icons/{guild.id}/{guild.icon}"
+	CDNEndpointGuildSplash              = "splashes/{guild.id}/{guild.splash}"
+	CDNEndpointGuildDiscoverySplash     = "discovery-splashes/{guild.id}/{guild.discovery_splash}"
+	CDNEndpointGuildBanner              = "banners/{guild.id}/{guild.banner}"
+	CDNEndpointUserBanner               = "banners/{user.id}/{user.banner}"
+	CDNEndpointDefaultUserAvatar        = "embed/avatars/{index}"
+	CDNEndpointUserAvatar               = "avatars/{user.id}/{user.avatar}"
+	CDNEndpointGuildMemberAvatar        = "guilds/{guild.id}/users/{user.id}/avatars/{member.avatar}"
+	CDNEndpointApplicationIcon          = "app-icons/{application.id}/{icon}"
+	CDNEndpointApplicationCover         = "app-icons/{application.id}/{cover_image}"
+	CDNEndpointApplicationAsset         = "app-assets/{application.id}/{asset.id}"
+	CDNEndpointTeamIcon                 = "team-icons/{team.id}/{team.icon}"
+	CDNEndpointSticker                  = "stickers/{sticker.id}"
+	CDNEndpointRoleIcon                 = "role-icons/{role.id}/{role.icon}"
+	CDNEndpointGuildScheduledEventCover = "guild-events/{scheduled_event.id}/{scheduled_event.cover_image}"
+)
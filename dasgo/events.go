@@ -74,18 +74,19 @@ const (
 // Hello Structure
 // https://discord.com/developers/docs/topics/gateway#hello-hello-structure
 type Hello struct {
-	HeartbeatInterval time.Duration `json:"heartbeat_interval"`
+	HeartbeatInterval Milliseconds `json:"heartbeat_interval"`
 }
 
 // Ready Event Fields
 // https://discord.com/developers/docs/topics/gateway#ready-ready-event-fields
 type Ready struct {
-	Version     int          `json:"v"`
-	User        *User        `json:"user"`
-	Guilds      []*Guild     `json:"guilds"`
-	SessionID   string       `json:"session_id"`
-	Shard       *[2]int      `json:"shard,omitempty"`
-	Application *Application `json:"application"`
+	Version          int          `json:"v"`
+	User             *User        `json:"user"`
+	Guilds           []*Guild     `json:"guilds"`
+	SessionID        string       `json:"session_id"`
+	ResumeGatewayURL string       `json:"resume_gateway_url"`
+	Shard            *[2]int      `json:"shard,omitempty"`
+	Application      *Application `json:"application"`
 }
 
 // Resumed
@@ -181,14 +182,23 @@ type ThreadMembersUpdate struct {
 type ChannelPinsUpdate struct {
 	GuildID          Snowflake `json:"guild_id,omitempty"`
 	ChannelID        Snowflake `json:"channel_id"`
-	LastPinTimestamp time.Time `json:"last_pin_timestamp,omitempty"`
+	LastPinTimestamp Timestamp `json:"last_pin_timestamp"`
 }
 
 // Guild Create
 // https://discord.com/developers/docs/topics/gateway#guild-create
 type GuildCreate struct {
 	*Guild
-	
+	JoinedAt             time.Time              `json:"joined_at,omitempty"`
+	Large                bool                   `json:"large,omitempty"`
+	MemberCount          int                    `json:"member_count,omitempty"`
+	VoiceStates          []*VoiceState          `json:"voice_states,omitempty"`
+	Members              []*GuildMember         `json:"members,omitempty"`
+	Channels             []*Channel             `json:"channels,omitempty"`
+	Presences            []*PresenceUpdate      `json:"presences,omitempty"`
+	StageInstances       []*StageInstance       `json:"stage_instances,omitempty"`
+	GuildScheduledEvents []*GuildScheduledEvent `json:"guild_scheduled_events,omitempty"`
+
 	// https://discord.com/developers/docs/topics/threads#gateway-events
 	Threads []*Channel `json:"threads,omitempty"`
 }
@@ -208,8 +218,8 @@ type GuildDelete struct {
 // Guild Ban Add
 // https://discord.com/developers/docs/topics/gateway#guild-ban-add
 type GuildBanAdd struct {
-	GuildID string `json:"guild_id"`
-	User    *User  `json:"user"`
+	GuildID Snowflake `json:"guild_id"`
+	User    *User     `json:"user"`
 }
 
 // Guild Ban Remove
@@ -364,7 +374,7 @@ type InviteCreate struct {
 	Inviter           *User        `json:"inviter,omitempty"`
 	MaxAge            int          `json:"max_age"`
 	MaxUses           int          `json:"max_uses"`
-	TargetType        *int          `json:"target_user_type,omitempty"`
+	TargetType        *int          `json:"target_type,omitempty"`
 	TargetUser        *User        `json:"target_user,omitempty"`
 	TargetApplication *Application `json:"target_application,omitempty"`
 	Temporary         bool         `json:"temporary"`
@@ -376,7 +386,7 @@ type InviteCreate struct {
 type InviteDelete struct {
 	ChannelID Snowflake `json:"channel_id"`
 	GuildID   Snowflake `json:"guild_id,omitempty"`
-	Code      Snowflake `json:"code"`
+	Code      string    `json:"code"`
 }
 
 // Message Create
@@ -388,7 +398,7 @@ type MessageCreate struct {
 // Message Update
 // https://discord.com/developers/docs/topics/gateway#message-update
 type MessageUpdate struct {
-	Message *Message
+	*Message
 }
 
 // Message Delete
@@ -0,0 +1,235 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message Formatting
+// https://discord.com/developers/docs/reference#message-formatting-formats
+const (
+	FlagMessageFormatUser                 = "<@%d>"
+	FlagMessageFormatUserNickname         = "<@!%d>"
+	FlagMessageFormatChannel              = "<#%d>"
+	FlagMessageFormatRole                 = "<@&%d>"
+	FlagMessageFormatSlashCommand         = "</%s:%d>"
+	FlagMessageFormatStandardEmoji        = "%s"
+	FlagMessageFormatCustomEmoji          = "<:%s:%d>"
+	FlagMessageFormatCustomEmojiAnimated  = "<a:%s:%d>"
+	FlagMessageFormatUnixTimestamp        = "<t:%d>"
+	FlagMessageFormatUnixTimestampStyled  = "<t:%d:%s>"
+	FlagMessageFormatMentionEveryone      = "@everyone"
+	FlagMessageFormatMentionHere          = "@here"
+	FlagMessageFormatMaskedLink           = "[%s](%s)"
+	FlagMessageFormatMaskedLinkSuppressed = "[%s](<%s>)"
+)
+
+// Timestamp Styles
+// https://discord.com/developers/docs/reference#message-formatting-timestamp-styles
+const (
+	FlagTimestampStyleShortTime     = "t"
+	FlagTimestampStyleLongTime      = "T"
+	FlagTimestampStyleShortDate     = "d"
+	FlagTimestampStyleLongDate      = "D"
+	FlagTimestampStyleShortDateTime = "f"
+	FlagTimestampStyleLongDateTime  = "F"
+	FlagTimestampStyleRelativeTime  = "R"
+)
+
+// MentionUser returns the mention of a user.
+func MentionUser(id Snowflake) string {
+	return fmt.Sprintf(FlagMessageFormatUser, id)
+}
+
+// MentionRole returns the mention of a role.
+func MentionRole(id Snowflake) string {
+	return fmt.Sprintf(FlagMessageFormatRole, id)
+}
+
+// MentionChannel returns the mention of a channel.
+func MentionChannel(id Snowflake) string {
+	return fmt.Sprintf(FlagMessageFormatChannel, id)
+}
+
+// MentionSlashCommand returns the mention of a slash command.
+//
+// The name of a subcommand is qualified with its parent names (i.e "tag get").
+func MentionSlashCommand(name string, id Snowflake) string {
+	return fmt.Sprintf(FlagMessageFormatSlashCommand, name, id)
+}
+
+// Mention returns the mention of the user.
+func (u *User) Mention() string {
+	return MentionUser(u.ID)
+}
+
+// Mention returns the mention of the role.
+func (r *Role) Mention() string {
+	return MentionRole(r.ID)
+}
+
+// Mention returns the mention of the channel.
+func (c *Channel) Mention() string {
+	return MentionChannel(c.ID)
+}
+
+// Mention returns the mention of the command, or one of its subcommands
+// when the names of a subcommand group and/or subcommand are provided.
+func (c *ApplicationCommand) Mention(subcommands ...string) string {
+	return MentionSlashCommand(strings.Join(append([]string{c.Name}, subcommands...), " "), c.ID)
+}
+
+// emojiPlaceholderName represents the name used to display a custom emoji without a name
+// (i.e the emoji of a reaction from a deleted emoji), since custom emoji are displayed by ID.
+const emojiPlaceholderName = "emoji"
+
+// Mention returns the markup used to display the emoji in message content.
+//
+// A standard (unicode) emoji is returned as its name, and a custom emoji without
+// a name is displayed using a placeholder name.
+func (e *Emoji) Mention() string {
+	name := ""
+	if e.Name != nil {
+		name = *e.Name
+	}
+
+	if e.ID == 0 {
+		return fmt.Sprintf(FlagMessageFormatStandardEmoji, name)
+	}
+
+	if name == "" {
+		name = emojiPlaceholderName
+	}
+
+	if e.Animated != nil && *e.Animated {
+		return fmt.Sprintf(FlagMessageFormatCustomEmojiAnimated, name, e.ID)
+	}
+
+	return fmt.Sprintf(FlagMessageFormatCustomEmoji, name, e.ID)
+}
+
+// FormatTimestamp returns the markup used to display a timestamp in a Timestamp Style.
+//
+// An empty style uses the default style (FlagTimestampStyleShortDateTime).
+func FormatTimestamp(t time.Time, style string) string {
+	if style == "" {
+		return fmt.Sprintf(FlagMessageFormatUnixTimestamp, t.Unix())
+	}
+
+	return fmt.Sprintf(FlagMessageFormatUnixTimestampStyled, t.Unix(), style)
+}
+
+// Bold returns bold markdown.
+func Bold(s string) string {
+	return "**" + s + "**"
+}
+
+// Italic returns italic markdown.
+func Italic(s string) string {
+	return "*" + s + "*"
+}
+
+// Underline returns underline markdown.
+func Underline(s string) string {
+	return "__" + s + "__"
+}
+
+// Strikethrough returns strikethrough markdown.
+func Strikethrough(s string) string {
+	return "~~" + s + "~~"
+}
+
+// Spoiler returns spoiler markdown.
+func Spoiler(s string) string {
+	return "||" + s + "||"
+}
+
+// InlineCode returns inline code markdown.
+//
+// Double backticks are used when the code contains a backtick.
+func InlineCode(code string) string {
+	if strings.Contains(code, "`") {
+		return "`` " + code + " ``"
+	}
+
+	return "`" + code + "`"
+}
+
+// CodeBlock returns code block markdown with an optional language used for syntax highlighting.
+//
+// Consecutive backticks in the code are separated by a zero-width space,
+// so that the code can't close the block early.
+func CodeBlock(language, code string) string {
+	// a run of backticks is broken up in two passes, since the first pass
+	// replaces non-overlapping pairs (i.e "````" becomes "`\u200b``\u200b`").
+	for i := 0; i < 2; i++ {
+		code = strings.ReplaceAll(code, "``", "`\u200b`")
+	}
+
+	if !strings.HasSuffix(code, "\n") {
+		code += "\n"
+	}
+
+	return "```" + language + "\n" + code + "```"
+}
+
+// Quote returns quote markdown for each line of s.
+func Quote(s string) string {
+	return "> " + strings.ReplaceAll(s, "\n", "\n> ")
+}
+
+// BlockQuote returns block quote markdown, which quotes the remainder of the message.
+func BlockQuote(s string) string {
+	return ">>> " + s
+}
+
+// MaskedLink returns masked link markdown.
+func MaskedLink(text, url string) string {
+	return fmt.Sprintf(FlagMessageFormatMaskedLink, text, url)
+}
+
+// MaskedLinkSuppressed returns masked link markdown that does not generate an embed.
+func MaskedLinkSuppressed(text, url string) string {
+	return fmt.Sprintf(FlagMessageFormatMaskedLinkSuppressed, text, url)
+}
+
+// markdownEscaper escapes markdown syntax.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"~", `\~`,
+	"`", "\\`",
+	"|", `\|`,
+	">", `\>`,
+	"#", `\#`,
+	"[", `\[`,
+	"]", `\]`,
+	"-", `\-`,
+)
+
+// mentionEscaper neutralizes mentions using a zero-width space.
+var mentionEscaper = strings.NewReplacer(
+	"@everyone", "@\u200beveryone",
+	"@here", "@\u200bhere",
+	"<@", "<@\u200b",
+	"<#", "<#\u200b",
+	"</", "</\u200b",
+)
+
+// EscapeMarkdown escapes markdown syntax so that s is displayed as plain text.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// EscapeMentions neutralizes @everyone, @here, user, role, channel, and command mentions in s.
+func EscapeMentions(s string) string {
+	return mentionEscaper.Replace(s)
+}
+
+// Escape escapes markdown syntax and neutralizes mentions in user-supplied content.
+func Escape(s string) string {
+	return EscapeMentions(EscapeMarkdown(s))
+}
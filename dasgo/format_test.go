@@ -0,0 +1,100 @@
+package dasgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMention(t *testing.T) {
+	name, animated := "blob", true
+	tests := []struct {
+		name    string
+		mention string
+		want    string
+	}{
+		{name: "user", mention: (&User{ID: 1}).Mention(), want: "<@1>"},
+		{name: "role", mention: (&Role{ID: 2}).Mention(), want: "<@&2>"},
+		{name: "channel", mention: (&Channel{ID: 3}).Mention(), want: "<#3>"},
+		{name: "command", mention: (&ApplicationCommand{ID: 4, Name: "tag"}).Mention(), want: "</tag:4>"},
+		{name: "subcommand", mention: (&ApplicationCommand{ID: 4, Name: "tag"}).Mention("admin", "get"), want: "</tag admin get:4>"},
+		{name: "standard emoji", mention: (&Emoji{Name: func() *string { s := "👍"; return &s }()}).Mention(), want: "👍"},
+		{name: "custom emoji", mention: (&Emoji{ID: 5, Name: &name}).Mention(), want: "<:blob:5>"},
+		{name: "animated emoji", mention: (&Emoji{ID: 5, Name: &name, Animated: &animated}).Mention(), want: "<a:blob:5>"},
+		{name: "custom emoji without a name", mention: (&Emoji{ID: 5}).Mention(), want: "<:emoji:5>"},
+		{name: "timestamp", mention: FormatTimestamp(time.Unix(1618953630, 0), ""), want: "<t:1618953630>"},
+		{name: "styled timestamp", mention: FormatTimestamp(time.Unix(1618953630, 0), FlagTimestampStyleRelativeTime), want: "<t:1618953630:R>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.mention != test.want {
+				t.Fatalf("expected %q, got %q", test.want, test.mention)
+			}
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{name: "bold", markdown: Bold("a"), want: "**a**"},
+		{name: "italic", markdown: Italic("a"), want: "*a*"},
+		{name: "underline", markdown: Underline("a"), want: "__a__"},
+		{name: "strikethrough", markdown: Strikethrough("a"), want: "~~a~~"},
+		{name: "spoiler", markdown: Spoiler("a"), want: "||a||"},
+		{name: "inline code", markdown: InlineCode("a"), want: "`a`"},
+		{name: "inline code with a backtick", markdown: InlineCode("a`b"), want: "`` a`b ``"},
+		{name: "code block", markdown: CodeBlock("go", "a := 1"), want: "```go\na := 1\n```"},
+		{name: "code block with a trailing newline", markdown: CodeBlock("", "a\n"), want: "```\na\n```"},
+		{name: "code block with a fence", markdown: CodeBlock("md", "```go\na\n```"), want: "```md\n`\u200b`\u200b`go\na\n`\u200b`\u200b`\n```"},
+		{name: "code block with a run of backticks", markdown: CodeBlock("", "````"), want: "```\n`\u200b`\u200b`\u200b`\n```"},
+		{name: "quote", markdown: Quote("a\nb"), want: "> a\n> b"},
+		{name: "block quote", markdown: BlockQuote("a\nb"), want: ">>> a\nb"},
+		{name: "masked link", markdown: MaskedLink("a", "https://example.com"), want: "[a](https://example.com)"},
+		{name: "suppressed masked link", markdown: MaskedLinkSuppressed("a", "https://example.com"), want: "[a](<https://example.com>)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.markdown != test.want {
+				t.Fatalf("expected %q, got %q", test.want, test.markdown)
+			}
+		})
+	}
+}
+
+func TestCodeBlockFence(t *testing.T) {
+	for n := 3; n <= 8; n++ {
+		block := CodeBlock("", "a"+strings.Repeat("`", n)+"b")
+		inner := strings.TrimSuffix(strings.TrimPrefix(block, "```\n"), "```")
+		if strings.Contains(inner, "``") {
+			t.Fatalf("expected the code of %d backticks to contain no consecutive backticks, got %q", n, inner)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name    string
+		escaped string
+		want    string
+	}{
+		{name: "markdown", escaped: EscapeMarkdown(`**a** _b_ ~~c~~ ||d|| > e # f [g](h) - \`), want: `\*\*a\*\* \_b\_ \~\~c\~\~ \|\|d\|\| \> e \# f \[g\](h) \- \\`},
+		{name: "code", escaped: EscapeMarkdown("`a`"), want: "\\`a\\`"},
+		{name: "mentions", escaped: EscapeMentions("@everyone @here <@1> <@!1> <@&2> <#3> </a:4>"),
+			want: "@\u200beveryone @\u200bhere <@\u200b1> <@\u200b!1> <@\u200b&2> <#\u200b3> </\u200ba:4>"},
+		{name: "both", escaped: Escape("*@everyone*"), want: "\\*@\u200beveryone\\*"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.escaped != test.want {
+				t.Fatalf("expected %q, got %q", test.want, test.escaped)
+			}
+		})
+	}
+}
@@ -187,10 +187,10 @@ type GatewayVoiceStateUpdate struct {
 // Gateway Presence Update Structure
 // https://discord.com/developers/docs/topics/gateway#update-presence-gateway-presence-update-structure
 type GatewayPresenceUpdate struct {
-	Since  int         `json:"since"`
-	Game   []*Activity `json:"game"`
-	Status string      `json:"status"`
-	AFK    bool        `json:"afk"`
+	Since      int         `json:"since"`
+	Activities []*Activity `json:"activities"`
+	Status     string      `json:"status"`
+	AFK        bool        `json:"afk"`
 }
 
 // Status Types
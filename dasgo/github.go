@@ -0,0 +1,235 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+// GitHub Webhook Events
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads
+const (
+	FlagGitHubEventCOMMIT_COMMENT              = "commit_comment"
+	FlagGitHubEventCREATE                      = "create"
+	FlagGitHubEventDELETE                      = "delete"
+	FlagGitHubEventFORK                        = "fork"
+	FlagGitHubEventISSUE_COMMENT               = "issue_comment"
+	FlagGitHubEventISSUES                      = "issues"
+	FlagGitHubEventMEMBER                      = "member"
+	FlagGitHubEventPUBLIC                      = "public"
+	FlagGitHubEventPULL_REQUEST                = "pull_request"
+	FlagGitHubEventPULL_REQUEST_REVIEW         = "pull_request_review"
+	FlagGitHubEventPULL_REQUEST_REVIEW_COMMENT = "pull_request_review_comment"
+	FlagGitHubEventPUSH                        = "push"
+	FlagGitHubEventRELEASE                     = "release"
+	FlagGitHubEventWATCH                       = "watch"
+	FlagGitHubEventCHECK_RUN                   = "check_run"
+	FlagGitHubEventCHECK_SUITE                 = "check_suite"
+	FlagGitHubEventDISCUSSION                  = "discussion"
+	FlagGitHubEventDISCUSSION_COMMENT          = "discussion_comment"
+)
+
+// GitHub Webhook Headers
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#delivery-headers
+const (
+	FlagGitHubHeaderEvent = "X-GitHub-Event"
+)
+
+// GitHub Webhook Payload
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads
+//
+// The payload contains the fields of every GitHub Webhook Event that Discord accepts,
+// which are set according to the event. The Created, Deleted, and Forced fields of a
+// push event are always sent, since a false value is meaningful.
+type GitHubWebhookPayload struct {
+	Action     string            `json:"action,omitempty"`
+	Sender     *GitHubUser       `json:"sender,omitempty"`
+	Repository *GitHubRepository `json:"repository,omitempty"`
+
+	// push, create, delete
+	Ref          string           `json:"ref,omitempty"`
+	RefType      string           `json:"ref_type,omitempty"`
+	Before       string           `json:"before,omitempty"`
+	After        string           `json:"after,omitempty"`
+	Created      bool             `json:"created"`
+	Deleted      bool             `json:"deleted"`
+	Forced       bool             `json:"forced"`
+	Compare      string           `json:"compare,omitempty"`
+	Commits      []*GitHubCommit  `json:"commits,omitempty"`
+	HeadCommit   *GitHubCommit    `json:"head_commit,omitempty"`
+	Pusher       *GitHubCommitter `json:"pusher,omitempty"`
+	MasterBranch string           `json:"master_branch,omitempty"`
+
+	// pull_request, pull_request_review, pull_request_review_comment
+	Number      int                `json:"number,omitempty"`
+	PullRequest *GitHubPullRequest `json:"pull_request,omitempty"`
+	Review      *GitHubReview      `json:"review,omitempty"`
+
+	// issues, issue_comment, commit_comment
+	Issue   *GitHubIssue   `json:"issue,omitempty"`
+	Comment *GitHubComment `json:"comment,omitempty"`
+
+	// release
+	Release *GitHubRelease `json:"release,omitempty"`
+
+	// fork
+	Forkee *GitHubRepository `json:"forkee,omitempty"`
+
+	// member
+	Member *GitHubUser `json:"member,omitempty"`
+
+	// check_run, check_suite
+	CheckRun   *GitHubCheckRun   `json:"check_run,omitempty"`
+	CheckSuite *GitHubCheckSuite `json:"check_suite,omitempty"`
+
+	// discussion, discussion_comment
+	Discussion *GitHubDiscussion `json:"discussion,omitempty"`
+}
+
+// GitHub User Object
+// https://docs.github.com/en/rest/users/users#get-a-user
+type GitHubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	HTMLURL   string `json:"html_url,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+// GitHub Repository Object
+// https://docs.github.com/en/rest/repos/repos#get-a-repository
+type GitHubRepository struct {
+	ID            int64       `json:"id"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Owner         *GitHubUser `json:"owner,omitempty"`
+	HTMLURL       string      `json:"html_url"`
+	Description   string      `json:"description,omitempty"`
+	Private       bool        `json:"private"`
+	Fork          bool        `json:"fork,omitempty"`
+	DefaultBranch string      `json:"default_branch,omitempty"`
+}
+
+// GitHub Commit Object
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type GitHubCommit struct {
+	ID        string           `json:"id"`
+	TreeID    string           `json:"tree_id,omitempty"`
+	Distinct  bool             `json:"distinct,omitempty"`
+	Message   string           `json:"message"`
+	Timestamp string           `json:"timestamp,omitempty"`
+	URL       string           `json:"url"`
+	Author    *GitHubCommitter `json:"author,omitempty"`
+	Committer *GitHubCommitter `json:"committer,omitempty"`
+	Added     []string         `json:"added,omitempty"`
+	Removed   []string         `json:"removed,omitempty"`
+	Modified  []string         `json:"modified,omitempty"`
+}
+
+// GitHub Committer Object
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type GitHubCommitter struct {
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// GitHub Pull Request Object
+// https://docs.github.com/en/rest/pulls/pulls#get-a-pull-request
+type GitHubPullRequest struct {
+	ID      int64         `json:"id"`
+	Number  int           `json:"number"`
+	State   string        `json:"state"`
+	Title   string        `json:"title"`
+	Body    string        `json:"body,omitempty"`
+	HTMLURL string        `json:"html_url"`
+	User    *GitHubUser   `json:"user,omitempty"`
+	Draft   bool          `json:"draft,omitempty"`
+	Merged  bool          `json:"merged,omitempty"`
+	Head    *GitHubBranch `json:"head,omitempty"`
+	Base    *GitHubBranch `json:"base,omitempty"`
+}
+
+// GitHub Branch Object
+// https://docs.github.com/en/rest/pulls/pulls#get-a-pull-request
+type GitHubBranch struct {
+	Label string            `json:"label,omitempty"`
+	Ref   string            `json:"ref"`
+	SHA   string            `json:"sha"`
+	Repo  *GitHubRepository `json:"repo,omitempty"`
+}
+
+// GitHub Review Object
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request_review
+type GitHubReview struct {
+	ID      int64       `json:"id"`
+	Body    string      `json:"body,omitempty"`
+	State   string      `json:"state"`
+	HTMLURL string      `json:"html_url"`
+	User    *GitHubUser `json:"user,omitempty"`
+}
+
+// GitHub Issue Object
+// https://docs.github.com/en/rest/issues/issues#get-an-issue
+type GitHubIssue struct {
+	ID      int64       `json:"id"`
+	Number  int         `json:"number"`
+	State   string      `json:"state"`
+	Title   string      `json:"title"`
+	Body    string      `json:"body,omitempty"`
+	HTMLURL string      `json:"html_url"`
+	User    *GitHubUser `json:"user,omitempty"`
+}
+
+// GitHub Comment Object
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#issue_comment
+type GitHubComment struct {
+	ID       int64       `json:"id"`
+	Body     string      `json:"body"`
+	HTMLURL  string      `json:"html_url"`
+	User     *GitHubUser `json:"user,omitempty"`
+	CommitID string      `json:"commit_id,omitempty"`
+	Path     string      `json:"path,omitempty"`
+}
+
+// GitHub Release Object
+// https://docs.github.com/en/rest/releases/releases#get-a-release
+type GitHubRelease struct {
+	ID         int64       `json:"id"`
+	TagName    string      `json:"tag_name"`
+	Name       string      `json:"name,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	HTMLURL    string      `json:"html_url"`
+	Draft      bool        `json:"draft"`
+	Prerelease bool        `json:"prerelease"`
+	Author     *GitHubUser `json:"author,omitempty"`
+}
+
+// GitHub Check Run Object
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#check_run
+type GitHubCheckRun struct {
+	ID         int64             `json:"id"`
+	Name       string            `json:"name"`
+	Status     string            `json:"status"`
+	Conclusion string            `json:"conclusion,omitempty"`
+	HTMLURL    string            `json:"html_url"`
+	HeadSHA    string            `json:"head_sha"`
+	CheckSuite *GitHubCheckSuite `json:"check_suite,omitempty"`
+}
+
+// GitHub Check Suite Object
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#check_suite
+type GitHubCheckSuite struct {
+	ID           int64                `json:"id"`
+	Status       string               `json:"status"`
+	Conclusion   string               `json:"conclusion,omitempty"`
+	HeadBranch   string               `json:"head_branch,omitempty"`
+	HeadSHA      string               `json:"head_sha"`
+	PullRequests []*GitHubPullRequest `json:"pull_requests,omitempty"`
+}
+
+// GitHub Discussion Object
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#discussion
+type GitHubDiscussion struct {
+	ID      int64       `json:"id"`
+	Number  int         `json:"number"`
+	Title   string      `json:"title"`
+	Body    string      `json:"body,omitempty"`
+	HTMLURL string      `json:"html_url"`
+	User    *GitHubUser `json:"user,omitempty"`
+}
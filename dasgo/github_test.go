@@ -0,0 +1,84 @@
+package dasgo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGitHubWebhookPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *ExecuteGitHubCompatibleWebhook
+		contains []string
+		omits    []string
+	}{
+		{
+			name: "push",
+			request: &ExecuteGitHubCompatibleWebhook{
+				Event: FlagGitHubEventPUSH,
+				GitHubWebhookPayload: GitHubWebhookPayload{
+					Ref:    "refs/heads/main",
+					Before: "a",
+					After:  "b",
+				},
+			},
+			contains: []string{`"ref":"refs/heads/main"`, `"created":false`, `"deleted":false`, `"forced":false`},
+		},
+		{
+			name: "forced push",
+			request: &ExecuteGitHubCompatibleWebhook{
+				Event:                FlagGitHubEventPUSH,
+				GitHubWebhookPayload: GitHubWebhookPayload{Ref: "refs/heads/main", Forced: true},
+			},
+			contains: []string{`"forced":true`},
+		},
+		{
+			name: "issues",
+			request: &ExecuteGitHubCompatibleWebhook{
+				Event: FlagGitHubEventISSUES,
+				GitHubWebhookPayload: GitHubWebhookPayload{
+					Action: "opened",
+					Issue:  &GitHubIssue{Number: 1, State: "open", Title: "issue"},
+				},
+			},
+			contains: []string{`"action":"opened"`, `"issue":{`},
+			omits:    []string{`"ref"`, `"commits"`, `"pull_request"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := jsonPayload(test.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, s := range test.contains {
+				if !strings.Contains(string(payload), s) {
+					t.Fatalf("expected %s to contain %s", payload, s)
+				}
+			}
+
+			for _, s := range test.omits {
+				if strings.Contains(string(payload), s) {
+					t.Fatalf("expected %s to omit %s", payload, s)
+				}
+			}
+
+			// the event is sent in a header, rather than the payload.
+			if strings.Contains(string(payload), `"`+test.request.Event+`"`) {
+				t.Fatalf("expected %s to omit the event", payload)
+			}
+
+			var decoded GitHubWebhookPayload
+			if err := json.Unmarshal(payload, &decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			if decoded.Forced != test.request.Forced || decoded.Ref != test.request.Ref {
+				t.Fatalf("expected %+v, got %+v", test.request.GitHubWebhookPayload, decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/gif"
+	"image/png"
+	"strings"
+)
+
+// Image Data
+// https://discord.com/developers/docs/reference#image-data
+const (
+	FlagImageDataPrefix          = "data:"
+	FlagImageDataEncoding        = ";base64,"
+	FlagImageDataContentTypeJPEG = "image/jpeg"
+	FlagImageDataContentTypePNG  = "image/png"
+	FlagImageDataContentTypeGIF  = "image/gif"
+)
+
+// Image Data Limits
+// https://discord.com/developers/docs/resources/emoji#create-guild-emoji
+// https://discord.com/developers/docs/resources/guild#create-guild-role
+const (
+	FlagImageDataLimitEMOJI    = 256 * 1024
+	FlagImageDataLimitROLEICON = 256 * 1024
+)
+
+// Sticker Limits
+// https://discord.com/developers/docs/resources/sticker#create-guild-sticker
+const (
+	FlagStickerLimitNameMIN        = 2
+	FlagStickerLimitNameMAX        = 30
+	FlagStickerLimitDescriptionMIN = 2
+	FlagStickerLimitDescriptionMAX = 100
+	FlagStickerLimitTagsMAX        = 200
+	FlagStickerLimitFileSizeMAX    = 512 * 1024
+
+	// FlagStickerLimitDimension represents the width and height (in pixels) that a sticker must have.
+	FlagStickerLimitDimension = 320
+)
+
+// imageSignatures maps the signature (magic number) of an image to its content type.
+var imageSignatures = []struct {
+	signature   []byte
+	contentType string
+}{
+	{[]byte("\x89PNG\r\n\x1a\n"), FlagImageDataContentTypePNG},
+	{[]byte("\xff\xd8\xff"), FlagImageDataContentTypeJPEG},
+	{[]byte("GIF87a"), FlagImageDataContentTypeGIF},
+	{[]byte("GIF89a"), FlagImageDataContentTypeGIF},
+}
+
+// DetectImageContentType returns the content type of an image (JPEG, PNG, or GIF)
+// determined by its signature, or an empty string when the content type is unsupported.
+func DetectImageContentType(data []byte) string {
+	for _, sig := range imageSignatures {
+		if bytes.HasPrefix(data, sig.signature) {
+			return sig.contentType
+		}
+	}
+
+	return ""
+}
+
+// EncodeImageData encodes an image (JPEG, PNG, or GIF) into an Image Data URI
+// (i.e data:image/png;base64,BASE64_ENCODED_PNG_IMAGE_DATA).
+//
+// The image is rejected when its size exceeds limit (when non-zero) in bytes.
+func EncodeImageData(data []byte, limit int) (string, error) {
+	if len(data) == 0 {
+		return "", errors.New("error encoding image data: image is empty")
+	}
+
+	contentType := DetectImageContentType(data)
+	if contentType == "" {
+		return "", errors.New("error encoding image data: image format is not JPEG, PNG, or GIF")
+	}
+
+	if limit != 0 && len(data) > limit {
+		return "", fmt.Errorf("error encoding image data: image size (%d bytes) exceeds the limit of %d bytes", len(data), limit)
+	}
+
+	return FlagImageDataPrefix + contentType + FlagImageDataEncoding + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// DecodeImageData decodes an Image Data URI into its content type and image.
+func DecodeImageData(uri string) (string, []byte, error) {
+	if !strings.HasPrefix(uri, FlagImageDataPrefix) {
+		return "", nil, fmt.Errorf("error decoding image data: URI does not start with %q", FlagImageDataPrefix)
+	}
+
+	separator := strings.Index(uri, FlagImageDataEncoding)
+	if separator == -1 {
+		return "", nil, fmt.Errorf("error decoding image data: URI does not contain %q", FlagImageDataEncoding)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(uri[separator+len(FlagImageDataEncoding):])
+	if err != nil {
+		return "", nil, fmt.Errorf("error decoding image data: %w", err)
+	}
+
+	return uri[len(FlagImageDataPrefix):separator], data, nil
+}
+
+// DetectStickerFormatType returns the Sticker Format Type of a sticker file.
+func DetectStickerFormatType(data []byte) (Flag, error) {
+	switch DetectImageContentType(data) {
+	case FlagImageDataContentTypePNG:
+		if isAPNG(data) {
+			return FlagStickerFormatTypeAPNG, nil
+		}
+
+		return FlagStickerFormatTypePNG, nil
+	case FlagImageDataContentTypeGIF:
+		return FlagStickerFormatTypeGIF, nil
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) != 0 && trimmed[0] == '{' && json.Valid(trimmed) {
+		return FlagStickerFormatTypeLOTTIE, nil
+	}
+
+	return 0, errors.New("file is not a PNG, APNG, GIF, or Lottie file")
+}
+
+// ValidateStickerFile validates a sticker file against Discord's sticker constraints
+// (file size, format, and dimensions), and returns its Sticker Format Type.
+func ValidateStickerFile(data []byte) (Flag, error) {
+	formatType, err := validateStickerFile(data)
+	if err != nil {
+		return 0, fmt.Errorf("error validating sticker file: %w", err)
+	}
+
+	return formatType, nil
+}
+
+// validateStickerFile returns the Sticker Format Type of a sticker file, or the
+// sticker constraint that the file violates.
+func validateStickerFile(data []byte) (Flag, error) {
+	if len(data) > FlagStickerLimitFileSizeMAX {
+		return 0, fmt.Errorf("file size (%d bytes) exceeds the limit of %d bytes", len(data), FlagStickerLimitFileSizeMAX)
+	}
+
+	formatType, err := DetectStickerFormatType(data)
+	if err != nil {
+		return 0, err
+	}
+
+	var width, height int
+	switch formatType {
+	case FlagStickerFormatTypePNG, FlagStickerFormatTypeAPNG, FlagStickerFormatTypeGIF:
+		decode := png.DecodeConfig
+		if formatType == FlagStickerFormatTypeGIF {
+			decode = gif.DecodeConfig
+		}
+
+		config, err := decode(bytes.NewReader(data))
+		if err != nil {
+			return 0, fmt.Errorf("error decoding image: %w", err)
+		}
+
+		width, height = config.Width, config.Height
+	case FlagStickerFormatTypeLOTTIE:
+		var animation struct {
+			Width  *int            `json:"w"`
+			Height *int            `json:"h"`
+			Layers json.RawMessage `json:"layers"`
+		}
+
+		if err := json.Unmarshal(data, &animation); err != nil {
+			return 0, fmt.Errorf("error decoding Lottie file: %w", err)
+		}
+
+		if animation.Width == nil || animation.Height == nil || len(animation.Layers) == 0 {
+			return 0, errors.New("Lottie file does not contain a width (w), height (h), and layers")
+		}
+
+		width, height = *animation.Width, *animation.Height
+	}
+
+	if width != FlagStickerLimitDimension || height != FlagStickerLimitDimension {
+		return 0, fmt.Errorf("dimensions (%dx%d) must be %dx%d",
+			width, height, FlagStickerLimitDimension, FlagStickerLimitDimension)
+	}
+
+	return formatType, nil
+}
+
+// isAPNG returns whether a PNG is an animated PNG, which contains an animation
+// control (acTL) chunk before its image data (IDAT).
+func isAPNG(data []byte) bool {
+	for offset := len(imageSignatures[0].signature); offset+8 <= len(data); {
+		length := binary.BigEndian.Uint32(data[offset:])
+		switch string(data[offset+4 : offset+8]) {
+		case "acTL":
+			return true
+		case "IDAT":
+			return false
+		}
+
+		if uint64(length) > uint64(len(data)) {
+			return false
+		}
+
+		// chunk length, type, data, and CRC.
+		offset += 4 + 4 + int(length) + 4
+	}
+
+	return false
+}
@@ -0,0 +1,178 @@
+package dasgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// testPNG returns a PNG with dimensions, which contains a chunk of each type after its header.
+func testPNG(t *testing.T, width, height int, chunks ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+
+	// the signature (8 bytes) and header chunk (25 bytes) precede the inserted chunks.
+	data := buf.Bytes()
+	result := append([]byte(nil), data[:33]...)
+	for _, chunkType := range chunks {
+		chunk := make([]byte, 12)
+		copy(chunk[4:], chunkType)
+		binary.BigEndian.PutUint32(chunk[8:], crc32.ChecksumIEEE([]byte(chunkType)))
+		result = append(result, chunk...)
+	}
+
+	return append(result, data[33:]...)
+}
+
+// testGIF returns a GIF with dimensions.
+func testGIF(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.Black, color.White}), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDetectImageContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "png", data: testPNG(t, 1, 1), want: FlagImageDataContentTypePNG},
+		{name: "jpeg", data: []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), want: FlagImageDataContentTypeJPEG},
+		{name: "gif87a", data: []byte("GIF87a..."), want: FlagImageDataContentTypeGIF},
+		{name: "gif89a", data: testGIF(t, 1, 1), want: FlagImageDataContentTypeGIF},
+		{name: "webp", data: []byte("RIFF\x00\x00\x00\x00WEBP"), want: ""},
+		{name: "truncated png", data: []byte("\x89PNG"), want: ""},
+		{name: "empty", data: nil, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if contentType := DetectImageContentType(test.data); contentType != test.want {
+				t.Fatalf("expected %q, got %q", test.want, contentType)
+			}
+		})
+	}
+}
+
+func TestImageData(t *testing.T) {
+	data := testPNG(t, 1, 1)
+
+	uri, err := EncodeImageData(data, FlagImageDataLimitEMOJI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Fatalf("expected a PNG data URI, got %q", uri)
+	}
+
+	contentType, decoded, err := DecodeImageData(uri)
+	if err != nil || contentType != FlagImageDataContentTypePNG || !bytes.Equal(decoded, data) {
+		t.Fatalf("expected the PNG to be decoded, got %q (%v)", contentType, err)
+	}
+
+	if _, err := EncodeImageData(data, len(data)-1); err == nil {
+		t.Fatal("expected an error for an image that exceeds the limit")
+	}
+
+	if _, err := EncodeImageData([]byte("text"), 0); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+
+	if _, _, err := DecodeImageData("image/png;base64,"); err == nil {
+		t.Fatal("expected an error for a URI without a data prefix")
+	}
+}
+
+func TestIsAPNG(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{name: "png", data: testPNG(t, 1, 1), want: false},
+		{name: "apng", data: testPNG(t, 1, 1, "acTL"), want: true},
+		{name: "apng after another chunk", data: testPNG(t, 1, 1, "tEXt", "acTL"), want: true},
+		{name: "signature", data: testPNG(t, 1, 1)[:8], want: false},
+		{name: "invalid chunk length", data: append(testPNG(t, 1, 1)[:8], 0xff, 0xff, 0xff, 0xff, 't', 'E', 'X', 't'), want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if apng := isAPNG(test.data); apng != test.want {
+				t.Fatalf("expected %v, got %v", test.want, apng)
+			}
+		})
+	}
+
+	// an animation control chunk after the image data isn't an APNG.
+	data := testPNG(t, 1, 1)
+	if isAPNG(append(data[:len(data)-12], append([]byte{0, 0, 0, 0, 'a', 'c', 'T', 'L', 0, 0, 0, 0}, data[len(data)-12:]...)...)) {
+		t.Fatal("expected an acTL chunk after IDAT to be ignored")
+	}
+}
+
+func TestValidateStickerFile(t *testing.T) {
+	lottie := `{"v":"5.5.2","w":320,"h":320,"layers":[{}]}`
+
+	tests := []struct {
+		name string
+		data []byte
+		want Flag
+		err  string
+	}{
+		{name: "png", data: testPNG(t, 320, 320), want: FlagStickerFormatTypePNG},
+		{name: "apng", data: testPNG(t, 320, 320, "acTL"), want: FlagStickerFormatTypeAPNG},
+		{name: "gif", data: testGIF(t, 320, 320), want: FlagStickerFormatTypeGIF},
+		{name: "lottie", data: []byte(lottie), want: FlagStickerFormatTypeLOTTIE},
+		{name: "small png", data: testPNG(t, 319, 320), err: "dimensions (319x320) must be 320x320"},
+		{name: "large gif", data: testGIF(t, 320, 321), err: "dimensions (320x321) must be 320x320"},
+		{name: "lottie without layers", data: []byte(`{"w":320,"h":320}`), err: "Lottie file does not contain"},
+		{name: "jpeg", data: []byte("\xff\xd8\xff\xe0"), err: "file is not a PNG, APNG, GIF, or Lottie file"},
+		{
+			name: "file size",
+			data: append(testPNG(t, 320, 320), make([]byte, FlagStickerLimitFileSizeMAX)...),
+			err:  "exceeds the limit of 524288 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			formatType, err := ValidateStickerFile(test.data)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error containing %q, got %v", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil || formatType != test.want {
+				t.Fatalf("expected format type %d, got %d (%v)", test.want, formatType, err)
+			}
+		})
+	}
+
+	// a file of exactly 512 KiB is valid.
+	data := testPNG(t, 320, 320)
+	data = append(data, make([]byte, FlagStickerLimitFileSizeMAX-len(data))...)
+	if _, err := ValidateStickerFile(data); err != nil {
+		t.Fatalf("expected a 512 KiB sticker to be valid, got %v", err)
+	}
+}
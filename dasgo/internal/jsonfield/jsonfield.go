@@ -0,0 +1,191 @@
+// Package jsonfield provides the JSON keys of Go structs, which are used to walk
+// a Go value alongside the JSON value that it's decoded from.
+package jsonfield
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Fields represents the JSON keys of a struct type.
+type Fields struct {
+	// Name represents the name of the type that the first embedded struct of
+	// the struct type refers to (i.e Channel for ChannelCreate).
+	Name string
+
+	// Keys maps each known key to its field, which is nil for keys that are
+	// only encoded by the type (i.e MarshalJSON).
+	Keys map[string]*Field
+}
+
+// Field represents a field of a struct that is decoded from JSON.
+type Field struct {
+	index []int
+	typ   reflect.Type
+}
+
+// cache caches the Fields of each struct type.
+var cache sync.Map
+
+// Of returns the JSON keys of a struct type.
+func Of(t reflect.Type) *Fields {
+	if cached, ok := cache.Load(t); ok {
+		return cached.(*Fields)
+	}
+
+	fields := &Fields{Name: embeddedName(t), Keys: make(map[string]*Field)}
+
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	for current := []level{{typ: t}}; len(current) != 0; {
+		var next []level
+		found := make(map[string]*Field)
+		for _, l := range current {
+			for i := 0; i < l.typ.NumField(); i++ {
+				field := l.typ.Field(i)
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				index := append(append([]int(nil), l.index...), i)
+				name := strings.Split(tag, ",")[0]
+
+				embedded := field.Type
+				if embedded.Kind() == reflect.Ptr {
+					embedded = embedded.Elem()
+				}
+
+				if field.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+					next = append(next, level{typ: embedded, index: index})
+					continue
+				}
+
+				if !field.IsExported() {
+					continue
+				}
+
+				if name == "" {
+					name = field.Name
+				}
+
+				if _, ok := fields.Keys[name]; !ok {
+					found[name] = &Field{index: index, typ: field.Type}
+				}
+			}
+		}
+
+		for name, field := range found {
+			fields.Keys[name] = field
+		}
+
+		current = next
+	}
+
+	// keys that are only encoded by the type are known.
+	if t.Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) {
+		if data, err := json.Marshal(reflect.Zero(t).Interface()); err == nil {
+			var encoded map[string]json.RawMessage
+			if json.Unmarshal(data, &encoded) == nil {
+				for key := range encoded {
+					if _, ok := fields.Keys[key]; !ok {
+						fields.Keys[key] = nil
+					}
+				}
+			}
+		}
+	}
+
+	cached, _ := cache.LoadOrStore(t, fields)
+
+	return cached.(*Fields)
+}
+
+// embeddedName returns the name of the type that the first embedded struct of a
+// struct type refers to, or the name of the struct type when it does not embed a struct.
+func embeddedName(t reflect.Type) string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous || strings.Split(field.Tag.Get("json"), ",")[0] != "" {
+			continue
+		}
+
+		embedded := field.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+
+		if embedded.Kind() == reflect.Struct && embedded.Name() != "" {
+			return embeddedName(embedded)
+		}
+	}
+
+	if t.Name() == "" {
+		return t.String()
+	}
+
+	return t.Name()
+}
+
+// Value returns the value of a field of a struct, or its zero value when it is
+// embedded in a nil pointer.
+func (f *Field) Value(v reflect.Value) reflect.Value {
+	value, err := v.FieldByIndexErr(f.index)
+	if err != nil {
+		return reflect.Zero(f.typ)
+	}
+
+	return value
+}
+
+// Values returns the values of the fields of a struct by JSON key, including the fields
+// of embedded structs, where v is a struct or a pointer to one. A field that is embedded in a nil
+// pointer has its zero value, and keys that are only encoded by the type (i.e MarshalJSON) are omitted.
+//
+// Values returns nil when v isn't a struct.
+func Values(v reflect.Value) map[string]reflect.Value {
+	v = Indirect(v)
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	keys := Of(v.Type()).Keys
+	fields := make(map[string]reflect.Value, len(keys))
+	for key, field := range keys {
+		if field != nil {
+			fields[key] = field.Value(v)
+		}
+	}
+
+	return fields
+}
+
+// Indirect returns the value that a pointer or interface refers to, or the zero
+// value of the type that a nil pointer refers to.
+func Indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		switch v.Kind() {
+		case reflect.Interface:
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+
+			v = v.Elem()
+		case reflect.Ptr:
+			if v.IsNil() {
+				v = reflect.Zero(v.Type().Elem())
+			} else {
+				v = v.Elem()
+			}
+		default:
+			return v
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,341 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Gateway Send Rate Limit
+// https://discord.com/developers/docs/topics/gateway#rate-limiting
+const (
+	FlagGatewayRateLimitCommands = 120
+	FlagGatewayRateLimitWindow   = time.Minute
+)
+
+// Request Guild Members Limits
+// https://discord.com/developers/docs/topics/gateway-events#request-guild-members
+const (
+	FlagGuildRequestMembersLimitNonce   = 32
+	FlagGuildRequestMembersLimitUserIDs = 100
+)
+
+const (
+	// memberChunkCommands represents the number of Request Guild Members commands that a MemberChunker
+	// sends to a shard per rate limit window, which leaves room for the shard's heartbeats and other commands.
+	memberChunkCommands = FlagGatewayRateLimitCommands - 10
+
+	// defaultMemberChunkTimeout represents the default maximum duration between Guild Members Chunk events.
+	defaultMemberChunkTimeout = 10 * time.Second
+)
+
+// ErrMemberChunkTimeout represents an error returned when a Guild Members Chunk event isn't received in time.
+var ErrMemberChunkTimeout = errors.New("guild members chunk timed out")
+
+// GatewaySender represents a Gateway connection (shard) that sends Gateway Commands.
+type GatewaySender interface {
+	SendCommand(ctx context.Context, op int, command Command) error
+}
+
+// GuildMembers represents the combined Guild Members Chunk events of a Request Guild Members command.
+type GuildMembers struct {
+	GuildID   Snowflake
+	Members   []*GuildMember
+	Presences []*PresenceUpdate
+	NotFound  []Snowflake
+}
+
+// MemberChunker requests guild members over the Gateway, and correlates the Guild Members Chunk
+// events of each request by nonce.
+//
+// A MemberChunker requires the Dispatcher that receives the Guild Members Chunk events of its shards.
+type MemberChunker struct {
+	Dispatcher *Dispatcher
+
+	// Shards represents the Gateway connections of the bot, where the index of a shard is its ID.
+	Shards []GatewaySender
+
+	// Timeout represents the maximum duration between the Guild Members Chunk events of a request
+	// (10 seconds when zero).
+	Timeout time.Duration
+
+	mu       sync.Mutex
+	limiters map[int]*gatewayLimiter
+}
+
+// NewMemberChunker returns a new MemberChunker for the shards of a bot.
+func NewMemberChunker(dispatcher *Dispatcher, shards ...GatewaySender) *MemberChunker {
+	return &MemberChunker{
+		Dispatcher: dispatcher,
+		Shards:     shards,
+		limiters:   make(map[int]*gatewayLimiter),
+	}
+}
+
+// ShardID returns the ID of the shard that receives the events of a guild.
+//
+// https://discord.com/developers/docs/topics/gateway#sharding-sharding-formula
+func ShardID(guildID Snowflake, shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+
+	return int((uint64(guildID) >> 22) % uint64(shards))
+}
+
+// NewNonce returns a random nonce that is used to correlate Gateway events (i.e Guild Members Chunk).
+func NewNonce() (string, error) {
+	b := make([]byte, FlagGuildRequestMembersLimitNonce/2)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Request sends a Request Guild Members command, and returns the members of every Guild Members Chunk
+// event that answers it.
+//
+// The members that are received before an error occurs are returned with the error.
+func (c *MemberChunker) Request(ctx context.Context, request *GuildRequestMembers) (*GuildMembers, error) {
+	result := &GuildMembers{GuildID: request.GuildID}
+	err := c.Stream(ctx, request, func(chunk *GuildMembersChunk) {
+		result.Members = append(result.Members, chunk.Members...)
+		result.Presences = append(result.Presences, chunk.Presences...)
+		result.NotFound = append(result.NotFound, chunk.NotFound...)
+	})
+
+	return result, err
+}
+
+// Stream sends a Request Guild Members command, and calls handler with each Guild Members Chunk
+// event that answers it until every chunk is received.
+//
+// The request's Nonce is generated when it's nil, and its Query is set to request every member
+// of the guild when it doesn't specify a Query or UserIDs. ErrMemberChunkTimeout is returned
+// when the next chunk isn't received within the chunker's Timeout.
+//
+// Chunks are received when they're dispatched rather than when they're handled, so Stream
+// can be called from an event handler (i.e Ready or GuildCreate) in every Dispatch Mode.
+func (c *MemberChunker) Stream(ctx context.Context, request *GuildRequestMembers, handler func(*GuildMembersChunk)) error {
+	if c.Dispatcher == nil {
+		return errors.New("member chunker has no dispatcher")
+	}
+
+	if len(c.Shards) == 0 {
+		return errors.New("member chunker has no shards")
+	}
+
+	if len(request.UserIDs) > FlagGuildRequestMembersLimitUserIDs {
+		return fmt.Errorf("error requesting members of guild %d: %d user IDs exceed the limit of %d",
+			request.GuildID, len(request.UserIDs), FlagGuildRequestMembersLimitUserIDs)
+	}
+
+	if request.Nonce == nil {
+		nonce, err := NewNonce()
+		if err != nil {
+			return err
+		}
+
+		request.Nonce = &nonce
+	}
+
+	if request.Query == nil && len(request.UserIDs) == 0 {
+		query := ""
+		request.Query = &query
+	}
+
+	nonce := *request.Nonce
+
+	var mu sync.Mutex
+	var pending []*GuildMembersChunk
+	notify := make(chan struct{}, 1)
+
+	// chunks are observed when they're dispatched, so that they're received while the caller
+	// blocks the queue of the dispatcher (i.e from a GuildCreate handler). The observer is
+	// subscribed before the command is sent, so that no chunk is missed.
+	remove := observe(c.Dispatcher, func(e *GuildMembersChunk) bool {
+		return e.GuildID == request.GuildID && e.Nonce != nil && *e.Nonce == nonce
+	}, func(e *GuildMembersChunk) {
+		mu.Lock()
+		pending = append(pending, e)
+		mu.Unlock()
+
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	})
+	defer remove()
+
+	shardID := ShardID(request.GuildID, len(c.Shards))
+	if err := c.limiter(shardID).wait(ctx); err != nil {
+		return err
+	}
+
+	if err := c.Shards[shardID].SendCommand(ctx, FlagGatewayOpcodeRequestGuildMembers, request); err != nil {
+		return fmt.Errorf("error requesting members of guild %d: %w", request.GuildID, err)
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultMemberChunkTimeout
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	received := make(map[int]bool)
+	for {
+		select {
+		case <-notify:
+			mu.Lock()
+			chunks := pending
+			pending = nil
+			mu.Unlock()
+
+			for _, chunk := range chunks {
+				if received[chunk.ChunkIndex] {
+					continue
+				}
+
+				received[chunk.ChunkIndex] = true
+				handler(chunk)
+
+				if len(received) >= chunk.ChunkCount {
+					return nil
+				}
+			}
+
+			if !timer.Stop() {
+				<-timer.C
+			}
+
+			timer.Reset(timeout)
+
+		case <-timer.C:
+			return fmt.Errorf("error requesting members of guild %d (%d chunks received): %w",
+				request.GuildID, len(received), ErrMemberChunkTimeout)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RequestGuilds requests every member of each guild (i.e the guilds of a Ready event at startup),
+// and calls handler with the result of each guild.
+//
+// The guilds of each shard are requested one at a time, while shards are requested concurrently.
+// The error of a guild is passed to handler, and RequestGuilds only returns an error when the
+// context is done.
+func (c *MemberChunker) RequestGuilds(ctx context.Context, guildIDs []Snowflake, presences bool,
+	handler func(guildID Snowflake, members *GuildMembers, err error)) error {
+	if len(c.Shards) == 0 {
+		return errors.New("member chunker has no shards")
+	}
+
+	shards := make(map[int][]Snowflake)
+	for _, id := range guildIDs {
+		shardID := ShardID(id, len(c.Shards))
+		shards[shardID] = append(shards[shardID], id)
+	}
+
+	var handlerMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, ids := range shards {
+		wg.Add(1)
+
+		go func(ids []Snowflake) {
+			defer wg.Done()
+
+			for _, id := range ids {
+				if ctx.Err() != nil {
+					return
+				}
+
+				request := &GuildRequestMembers{GuildID: id}
+				if presences {
+					request.Presences = &presences
+				}
+
+				members, err := c.Request(ctx, request)
+				if ctx.Err() != nil {
+					return
+				}
+
+				handlerMu.Lock()
+				handler(id, members, err)
+				handlerMu.Unlock()
+			}
+		}(ids)
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// limiter returns the rate limiter of a shard.
+func (c *MemberChunker) limiter(shardID int) *gatewayLimiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.limiters == nil {
+		c.limiters = make(map[int]*gatewayLimiter)
+	}
+
+	limiter, ok := c.limiters[shardID]
+	if !ok {
+		limiter = &gatewayLimiter{limit: memberChunkCommands, window: FlagGatewayRateLimitWindow}
+		c.limiters[shardID] = limiter
+	}
+
+	return limiter
+}
+
+// gatewayLimiter limits the number of commands that are sent to a shard within a rate limit window.
+type gatewayLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	sent   []time.Time
+}
+
+// wait waits until a command can be sent, and records it.
+func (l *gatewayLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+
+		// drop the commands that were sent before the current window.
+		expired := 0
+		for expired < len(l.sent) && now.Sub(l.sent[expired]) >= l.window {
+			expired++
+		}
+
+		l.sent = l.sent[expired:]
+		if len(l.sent) < l.limit {
+			l.sent = append(l.sent, now)
+			l.mu.Unlock()
+
+			return nil
+		}
+
+		delay := l.window - now.Sub(l.sent[0])
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
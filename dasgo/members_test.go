@@ -0,0 +1,64 @@
+package dasgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGatewayLimiter(t *testing.T) {
+	limiter := &gatewayLimiter{limit: 2, window: 100 * time.Millisecond}
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := limiter.wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed >= 50*time.Millisecond {
+		t.Fatalf("expected the commands within the limit to be sent immediately, got %v", elapsed)
+	}
+
+	// the next command waits for the first command to leave the window.
+	if err := limiter.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected the command to wait for the window, got %v", elapsed)
+	}
+
+	// a command that waits for the window is canceled with its context.
+	limiter = &gatewayLimiter{limit: 1, window: time.Hour}
+	if err := limiter.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if len(limiter.sent) != 1 {
+		t.Fatalf("expected a canceled command to be unrecorded, got %d commands", len(limiter.sent))
+	}
+}
+
+func TestMemberChunkerLimiter(t *testing.T) {
+	c := new(MemberChunker)
+
+	// each shard has its own limiter.
+	limiter := c.limiter(0)
+	if c.limiter(0) != limiter || c.limiter(1) == limiter {
+		t.Fatal("expected a limiter per shard")
+	}
+
+	if limiter.limit != memberChunkCommands || limiter.limit >= FlagGatewayRateLimitCommands {
+		t.Fatalf("expected a limit below the Gateway rate limit, got %d", limiter.limit)
+	}
+}
@@ -0,0 +1,334 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OAuth2 Grant Types
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant
+const (
+	FlagOAuth2GrantTypeAuthorizationCode = "authorization_code"
+	FlagOAuth2GrantTypeRefreshToken      = "refresh_token"
+	FlagOAuth2GrantTypeClientCredentials = "client_credentials"
+)
+
+// OAuth2 Response Types
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant
+const (
+	FlagOAuth2ResponseTypeCode  = "code"
+	FlagOAuth2ResponseTypeToken = "token"
+)
+
+// OAuth2 Prompts
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant
+const (
+	FlagOAuth2PromptConsent = "consent"
+	FlagOAuth2PromptNone    = "none"
+)
+
+// OAuth2 Token Type Hints
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-token-revocation-example
+const (
+	FlagOAuth2TokenTypeHintAccessToken  = "access_token"
+	FlagOAuth2TokenTypeHintRefreshToken = "refresh_token"
+)
+
+// OAuth2 Error Response
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+type OAuth2Error struct {
+	// StatusCode represents the HTTP status code of the response (when the error is a response).
+	StatusCode int `json:"-"`
+
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// Error returns the error message of the response.
+func (e *OAuth2Error) Error() string {
+	if e.Description == "" {
+		return "oauth2 error: " + e.Code
+	}
+
+	return fmt.Sprintf("oauth2 error: %s: %s", e.Code, e.Description)
+}
+
+// OAuth2Client represents an OAuth2 client of a Discord application.
+type OAuth2Client struct {
+	ClientID     Snowflake
+	ClientSecret string
+
+	// RedirectURI represents the redirect URI used in the Authorization Code Grant.
+	RedirectURI string
+
+	// BaseURL represents the URL that OAuth2 endpoints are relative to
+	// (EndpointBaseURL when empty).
+	BaseURL string
+
+	// HTTPClient represents the client used to send requests (http.DefaultClient when nil).
+	HTTPClient *http.Client
+}
+
+// NewOAuth2State returns a random state used to prevent CSRF attacks in an OAuth2 flow.
+func NewOAuth2State() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating OAuth2 state: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// OAuth2Scopes returns the scope parameter of a list of OAuth2 Scopes.
+func OAuth2Scopes(scopes ...string) string {
+	return strings.Join(scopes, " ")
+}
+
+// AuthorizationURL returns the URL that a user is sent to in order to authorize the application.
+//
+// The request's ClientID and RedirectURI default to the client's ClientID and RedirectURI,
+// while its ResponseType defaults to FlagOAuth2ResponseTypeCode.
+func (c *OAuth2Client) AuthorizationURL(request *AuthorizationURL) string {
+	authorization := *request
+	if authorization.ClientID == 0 {
+		authorization.ClientID = c.ClientID
+	}
+
+	if authorization.RedirectURI == "" {
+		authorization.RedirectURI = c.RedirectURI
+	}
+
+	if authorization.ResponseType == "" {
+		authorization.ResponseType = FlagOAuth2ResponseTypeCode
+	}
+
+	return c.endpoint(EndpointAuthorizationURL) + "?" + queryValues(&authorization).Encode()
+}
+
+// BotAuthorizationURL returns the URL that a user is sent to in order to add the application's
+// bot to a guild (Bot Authorization Flow).
+//
+// The request's ClientID defaults to the client's ClientID, and the bot scope is always requested.
+func (c *OAuth2Client) BotAuthorizationURL(request *BotAuth) string {
+	authorization := *request
+	if authorization.ClientID == 0 {
+		authorization.ClientID = c.ClientID
+	}
+
+	scopes := strings.Fields(authorization.Scope)
+	if !contains(scopes, FlagOAuth2ScopeBot) {
+		authorization.Scope = OAuth2Scopes(append(scopes, FlagOAuth2ScopeBot)...)
+	}
+
+	return c.endpoint(EndpointAuthorizationURL) + "?" + queryValues(&authorization).Encode()
+}
+
+// ParseRedirectURL parses the redirect URL that a user is sent to after authorizing the application,
+// and validates that its state matches the (non-empty) state of the authorization URL.
+//
+// An error from the authorization (i.e access_denied) is returned as an *OAuth2Error.
+func ParseRedirectURL(u *url.URL, state string) (*RedirectURL, error) {
+	if state == "" {
+		return nil, errors.New("error parsing redirect URL: the authorization state is empty")
+	}
+
+	query := u.Query()
+	if code := query.Get("error"); code != "" {
+		return nil, &OAuth2Error{Code: code, Description: query.Get("error_description")}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return nil, errors.New("error parsing redirect URL: state does not match the authorization state")
+	}
+
+	redirect := &RedirectURL{Code: query.Get("code"), State: query.Get("state")}
+	if redirect.Code == "" {
+		return nil, errors.New("error parsing redirect URL: code is missing")
+	}
+
+	if guildID := query.Get("guild_id"); guildID != "" {
+		id, err := strconv.ParseUint(guildID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing redirect URL guild_id: %w", err)
+		}
+
+		redirect.GuildID = Snowflake(id)
+	}
+
+	if permissions := query.Get("permissions"); permissions != "" {
+		bits, err := strconv.ParseUint(permissions, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing redirect URL permissions: %w", err)
+		}
+
+		redirect.Permissions = BitFlag(bits)
+	}
+
+	return redirect, nil
+}
+
+// Exchange exchanges an authorization code for an access token.
+func (c *OAuth2Client) Exchange(ctx context.Context, code string) (*AccessTokenResponse, error) {
+	response := new(AccessTokenResponse)
+	if err := c.exchange(ctx, code, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ExchangeBot exchanges an authorization code with the bot scope (Advanced Bot Authorization)
+// for an access token and the guild that the bot is added to.
+func (c *OAuth2Client) ExchangeBot(ctx context.Context, code string) (*ExtendedBotAuthorizationAccessTokenResponse, error) {
+	response := new(ExtendedBotAuthorizationAccessTokenResponse)
+	if err := c.exchange(ctx, code, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ExchangeWebhook exchanges an authorization code with the webhook.incoming scope
+// for an access token and the webhook that is created.
+func (c *OAuth2Client) ExchangeWebhook(ctx context.Context, code string) (*WebhookTokenResponse, error) {
+	response := new(WebhookTokenResponse)
+	if err := c.exchange(ctx, code, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// exchange exchanges an authorization code for an access token response.
+func (c *OAuth2Client) exchange(ctx context.Context, code string, response interface{}) error {
+	request := &AccessTokenExchange{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		GrantType:    FlagOAuth2GrantTypeAuthorizationCode,
+		Code:         code,
+		RedirectURI:  c.RedirectURI,
+	}
+
+	if err := c.post(ctx, EndpointTokenURL, queryValues(request), false, response); err != nil {
+		return fmt.Errorf("error exchanging authorization code: %w", err)
+	}
+
+	return nil
+}
+
+// Refresh exchanges a refresh token for a new access token.
+func (c *OAuth2Client) Refresh(ctx context.Context, refreshToken string) (*AccessTokenResponse, error) {
+	request := &RefreshTokenExchange{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		GrantType:    FlagOAuth2GrantTypeRefreshToken,
+		RefreshToken: refreshToken,
+	}
+
+	response := new(AccessTokenResponse)
+	if err := c.post(ctx, EndpointTokenURL, queryValues(request), false, response); err != nil {
+		return nil, fmt.Errorf("error refreshing access token: %w", err)
+	}
+
+	return response, nil
+}
+
+// ClientCredentials requests an access token for the application's owner (Client Credentials Grant).
+func (c *OAuth2Client) ClientCredentials(ctx context.Context, scopes ...string) (*ClientCredentialsAccessTokenResponse, error) {
+	request := &ClientCredentialsTokenRequest{
+		GrantType: FlagOAuth2GrantTypeClientCredentials,
+		Scope:     OAuth2Scopes(scopes...),
+	}
+
+	response := new(ClientCredentialsAccessTokenResponse)
+	if err := c.post(ctx, EndpointTokenURL, queryValues(request), true, response); err != nil {
+		return nil, fmt.Errorf("error requesting client credentials access token: %w", err)
+	}
+
+	return response, nil
+}
+
+// Revoke revokes an access or refresh token with a Token Type Hint (when non-empty).
+func (c *OAuth2Client) Revoke(ctx context.Context, token, tokenTypeHint string) error {
+	form := url.Values{"token": {token}}
+	if tokenTypeHint != "" {
+		form.Set("token_type_hint", tokenTypeHint)
+	}
+
+	if err := c.post(ctx, EndpointTokenRevocationURL, form, true, nil); err != nil {
+		return fmt.Errorf("error revoking token: %w", err)
+	}
+
+	return nil
+}
+
+// endpoint returns the URL of an endpoint.
+func (c *OAuth2Client) endpoint(endpoint string) string {
+	if c.BaseURL == "" {
+		return EndpointBaseURL + endpoint
+	}
+
+	return c.BaseURL + endpoint
+}
+
+// post sends a form to an endpoint, and decodes its JSON response into response (when non-nil).
+//
+// The client authenticates with HTTP Basic authentication when basic is true.
+func (c *OAuth2Client) post(ctx context.Context, endpoint string, form url.Values, basic bool, response interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(endpoint), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if basic {
+		request.SetBasicAuth(strconv.FormatUint(uint64(c.ClientID), 10), c.ClientSecret)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		oauth2Error := &OAuth2Error{StatusCode: resp.StatusCode}
+		if json.Unmarshal(body, oauth2Error) != nil || oauth2Error.Code == "" {
+			oauth2Error.Code = http.StatusText(resp.StatusCode)
+		}
+
+		return oauth2Error
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, response); err != nil {
+		return err
+	}
+
+	return nil
+}
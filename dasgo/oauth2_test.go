@@ -0,0 +1,317 @@
+package dasgo_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/switchupcb/dasgo/dasgo"
+	"github.com/switchupcb/dasgo/dasgo/dasgotest"
+)
+
+// oauth2Client returns an OAuth2 client of the World's application in a fake server.
+func oauth2Client(t *testing.T) (*dasgotest.Server, *dasgo.OAuth2Client) {
+	t.Helper()
+
+	s := dasgotest.NewServer(nil, "token")
+	t.Cleanup(s.Close)
+
+	s.ClientSecret = "secret"
+	client := &dasgo.OAuth2Client{
+		ClientID:     s.World.User().ID,
+		ClientSecret: "secret",
+		RedirectURI:  "https://example.com/callback",
+		BaseURL:      s.BaseURL(),
+	}
+
+	return s, client
+}
+
+func TestOAuth2Exchange(t *testing.T) {
+	s, client := oauth2Client(t)
+
+	code := s.Authorize(&dasgotest.Grant{RedirectURI: client.RedirectURI, Scopes: []string{dasgo.FlagOAuth2ScopeIdentify}})
+
+	response, err := client.Exchange(context.Background(), code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.AccessToken == "" || response.RefreshToken == "" || response.TokenType != "Bearer" ||
+		response.Scope != dasgo.FlagOAuth2ScopeIdentify || response.ExpiresIn.Duration() == 0 {
+		t.Fatalf("expected an access token response, got %+v", response)
+	}
+
+	if !s.ValidAccessToken(response.AccessToken) {
+		t.Fatal("expected the access token to be valid")
+	}
+
+	// an authorization code is exchanged once.
+	var oauth2Error *dasgo.OAuth2Error
+	if _, err := client.Exchange(context.Background(), code); !errors.As(err, &oauth2Error) || oauth2Error.Code != "invalid_grant" {
+		t.Fatalf("expected invalid_grant, got %v", err)
+	}
+
+	// an authorization code is exchanged with the redirect URI of its authorization.
+	code = s.Authorize(&dasgotest.Grant{RedirectURI: "https://example.com/other"})
+	if _, err := client.Exchange(context.Background(), code); !errors.As(err, &oauth2Error) || oauth2Error.StatusCode != 400 {
+		t.Fatalf("expected a 400 invalid_grant, got %v", err)
+	}
+
+	client.ClientSecret = "wrong"
+	code = s.Authorize(&dasgotest.Grant{RedirectURI: client.RedirectURI})
+	if _, err := client.Exchange(context.Background(), code); !errors.As(err, &oauth2Error) || oauth2Error.Code != "invalid_client" {
+		t.Fatalf("expected invalid_client, got %v", err)
+	}
+}
+
+func TestOAuth2Refresh(t *testing.T) {
+	s, client := oauth2Client(t)
+
+	code := s.Authorize(&dasgotest.Grant{RedirectURI: client.RedirectURI, Scopes: []string{dasgo.FlagOAuth2ScopeIdentify}})
+	exchanged, err := client.Exchange(context.Background(), code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := client.Refresh(context.Background(), exchanged.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshed.AccessToken == exchanged.AccessToken || refreshed.RefreshToken == exchanged.RefreshToken ||
+		refreshed.Scope != exchanged.Scope {
+		t.Fatalf("expected new tokens with the same scope, got %+v", refreshed)
+	}
+
+	// the previous tokens are invalidated by a refresh.
+	if s.ValidAccessToken(exchanged.AccessToken) || !s.ValidAccessToken(refreshed.AccessToken) {
+		t.Fatal("expected only the refreshed access token to be valid")
+	}
+
+	var oauth2Error *dasgo.OAuth2Error
+	if _, err := client.Refresh(context.Background(), exchanged.RefreshToken); !errors.As(err, &oauth2Error) || oauth2Error.Code != "invalid_grant" {
+		t.Fatalf("expected invalid_grant, got %v", err)
+	}
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	s, client := oauth2Client(t)
+
+	response, err := client.ClientCredentials(context.Background(), dasgo.FlagOAuth2ScopeIdentify, dasgo.FlagOAuth2ScopeConnections)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := dasgo.OAuth2Scopes(dasgo.FlagOAuth2ScopeIdentify, dasgo.FlagOAuth2ScopeConnections); response.Scope != want ||
+		!s.ValidAccessToken(response.AccessToken) {
+		t.Fatalf("expected a valid access token with scope %q, got %+v", want, response)
+	}
+
+	// client credentials are sent with HTTP Basic authentication.
+	client.ClientSecret = "wrong"
+
+	var oauth2Error *dasgo.OAuth2Error
+	if _, err := client.ClientCredentials(context.Background()); !errors.As(err, &oauth2Error) || oauth2Error.StatusCode != 401 {
+		t.Fatalf("expected a 401 invalid_client, got %v", err)
+	}
+}
+
+func TestOAuth2Revoke(t *testing.T) {
+	s, client := oauth2Client(t)
+
+	code := s.Authorize(&dasgotest.Grant{RedirectURI: client.RedirectURI})
+	response, err := client.Exchange(context.Background(), code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// revoking a refresh token revokes its access token.
+	if err := client.Revoke(context.Background(), response.RefreshToken, dasgo.FlagOAuth2TokenTypeHintRefreshToken); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.ValidAccessToken(response.AccessToken) {
+		t.Fatal("expected the access token to be revoked")
+	}
+
+	if _, err := client.Refresh(context.Background(), response.RefreshToken); err == nil {
+		t.Fatal("expected the refresh token to be revoked")
+	}
+
+	// revoking an unknown token succeeds.
+	if err := client.Revoke(context.Background(), "unknown", ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOAuth2ExchangeBot(t *testing.T) {
+	s, client := oauth2Client(t)
+	guild := s.World.AddGuild(&dasgo.Guild{Name: "guild"})
+
+	code := s.Authorize(&dasgotest.Grant{
+		RedirectURI: client.RedirectURI,
+		Scopes:      []string{dasgo.FlagOAuth2ScopeBot, dasgo.FlagOAuth2ScopeApplicationsCommands},
+		GuildID:     guild.ID,
+	})
+
+	response, err := client.ExchangeBot(context.Background(), code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Guild == nil || response.Guild.ID != guild.ID || response.AccessToken == "" {
+		t.Fatalf("expected the guild of the authorization, got %+v", response)
+	}
+}
+
+func TestOAuth2ExchangeWebhook(t *testing.T) {
+	s, client := oauth2Client(t)
+	guild := s.World.AddGuild(&dasgo.Guild{Name: "guild"})
+	channel := s.World.AddChannel(&dasgo.Channel{Name: "general", GuildID: guild.ID})
+
+	code := s.Authorize(&dasgotest.Grant{
+		RedirectURI: client.RedirectURI,
+		Scopes:      []string{dasgo.FlagOAuth2ScopeWebhookIncoming},
+		ChannelID:   channel.ID,
+	})
+
+	response, err := client.ExchangeWebhook(context.Background(), code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhook := response.Webhook
+	if webhook == nil || webhook.ChannelID == nil || *webhook.ChannelID != channel.ID ||
+		webhook.GuildID == nil || *webhook.GuildID != guild.ID {
+		t.Fatalf("expected a webhook in channel %d, got %+v", channel.ID, webhook)
+	}
+
+	if s.World.Webhook(webhook.ID) == nil {
+		t.Fatal("expected the webhook to be created")
+	}
+}
+
+func TestAuthorizationURL(t *testing.T) {
+	client := &dasgo.OAuth2Client{ClientID: 1, RedirectURI: "https://example.com/callback"}
+
+	u, err := url.Parse(client.AuthorizationURL(&dasgo.AuthorizationURL{
+		Scope: dasgo.OAuth2Scopes(dasgo.FlagOAuth2ScopeIdentify, dasgo.FlagOAuth2ScopeGuilds),
+		State: "state",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	if !strings.HasPrefix(u.String(), dasgo.EndpointBaseURL+dasgo.EndpointAuthorizationURL+"?") ||
+		query.Get("client_id") != "1" || query.Get("redirect_uri") != client.RedirectURI ||
+		query.Get("response_type") != dasgo.FlagOAuth2ResponseTypeCode || query.Get("scope") != "identify guilds" ||
+		query.Get("state") != "state" {
+		t.Fatalf("expected an authorization URL with the client's defaults, got %s", u)
+	}
+
+	u, err = url.Parse(client.BotAuthorizationURL(&dasgo.BotAuth{Scope: dasgo.FlagOAuth2ScopeApplicationsCommands}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if scope := u.Query().Get("scope"); scope != "applications.commands bot" {
+		t.Fatalf("expected the bot scope to be requested, got %q", scope)
+	}
+}
+
+func TestParseRedirectURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		state    string
+		redirect *dasgo.RedirectURL
+		err      string
+	}{
+		{
+			name:     "code",
+			url:      "https://example.com/callback?code=abc&state=xyz",
+			state:    "xyz",
+			redirect: &dasgo.RedirectURL{Code: "abc", State: "xyz"},
+		},
+		{
+			name:     "bot",
+			url:      "https://example.com/callback?code=abc&state=xyz&guild_id=5&permissions=8",
+			state:    "xyz",
+			redirect: &dasgo.RedirectURL{Code: "abc", State: "xyz", GuildID: 5, Permissions: 8},
+		},
+		{
+			name:  "error",
+			url:   "https://example.com/callback?error=access_denied&error_description=denied&state=xyz",
+			state: "xyz",
+			err:   "oauth2 error: access_denied: denied",
+		},
+		{
+			name:  "mismatched state",
+			url:   "https://example.com/callback?code=abc&state=abc",
+			state: "xyz",
+			err:   "state does not match",
+		},
+		{
+			name:  "missing state",
+			url:   "https://example.com/callback?code=abc",
+			state: "xyz",
+			err:   "state does not match",
+		},
+		{
+			name:  "empty state",
+			url:   "https://example.com/callback?code=abc",
+			state: "",
+			err:   "the authorization state is empty",
+		},
+		{
+			name:  "missing code",
+			url:   "https://example.com/callback?state=xyz",
+			state: "xyz",
+			err:   "code is missing",
+		},
+		{
+			name:  "invalid guild_id",
+			url:   "https://example.com/callback?code=abc&state=xyz&guild_id=guild",
+			state: "xyz",
+			err:   "guild_id",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			redirect, err := dasgo.ParseRedirectURL(u, test.state)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error containing %q, got %v", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *redirect != *test.redirect {
+				t.Fatalf("expected %+v, got %+v", test.redirect, redirect)
+			}
+		})
+	}
+
+	// an authorization error is returned as an *OAuth2Error.
+	u, _ := url.Parse("https://example.com/callback?error=access_denied")
+
+	var oauth2Error *dasgo.OAuth2Error
+	if _, err := dasgo.ParseRedirectURL(u, "xyz"); !errors.As(err, &oauth2Error) || oauth2Error.Code != "access_denied" {
+		t.Fatalf("expected an *OAuth2Error, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Optional represents an optional (non-nullable) field, which is absent or set to a value.
+//
+// Optional is a map, so that an absent (nil) Optional field with an `omitempty` tag is
+// NOT marshalled, while a field set to the zero value of its type IS marshalled.
+//
+// An Optional is nil (absent) or created by NewOptional or OptionalFromPointer. Other maps
+// (i.e Optional[T]{false: v}) are invalid, since the omitempty option only omits an empty map:
+// an invalid Optional returns an error when marshalled, rather than sending null for a non-nullable field.
+type Optional[T any] map[bool]T
+
+// Nullable represents an optional and nullable field, which is absent, null, or set to a value.
+//
+// Nullable is a map, so that an absent (nil) Nullable field with an `omitempty` tag is
+// NOT marshalled, while a null field IS marshalled as null (i.e `"nick": null`).
+//
+// A Nullable is nil (absent) or created by NewNullable, Null, or NullableFromPointer.
+// Other maps (i.e a map with both keys) are invalid, and return an error when marshalled.
+type Nullable[T any] map[bool]T
+
+var (
+	// errInvalidOptional represents an error returned when an invalid Optional is marshalled.
+	errInvalidOptional = errors.New("error encoding Optional: use nil or NewOptional to create an Optional")
+
+	// errInvalidNullable represents an error returned when an invalid Nullable is marshalled.
+	errInvalidNullable = errors.New("error encoding Nullable: use nil, NewNullable, or Null to create a Nullable")
+)
+
+// NewOptional returns an Optional that is set to value.
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{true: value}
+}
+
+// OptionalFromPointer returns an Optional that is absent when value is nil,
+// or set to the value that value points to.
+func OptionalFromPointer[T any](value *T) Optional[T] {
+	if value == nil {
+		return nil
+	}
+
+	return NewOptional(*value)
+}
+
+// Get returns the value of an Optional and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	value, ok := o[true]
+
+	return value, ok
+}
+
+// IsAbsent returns whether an Optional is absent.
+func (o Optional[T]) IsAbsent() bool {
+	_, ok := o[true]
+
+	return !ok
+}
+
+// MarshalJSON marshals an Optional into its value, or null when it is absent.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	value, ok := o[true]
+	switch {
+	case ok && len(o) == 1:
+		return json.Marshal(value)
+	case len(o) == 0:
+		return []byte("null"), nil
+	}
+
+	return nil, errInvalidOptional
+}
+
+// UnmarshalJSON unmarshals JSON data into the value of an Optional,
+// which is absent when the data is null.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = nil
+
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*o = NewOptional(value)
+
+	return nil
+}
+
+// NewNullable returns a Nullable that is set to value.
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{true: value}
+}
+
+// Null returns a Nullable that is null.
+func Null[T any]() Nullable[T] {
+	var zero T
+
+	return Nullable[T]{false: zero}
+}
+
+// NullableFromPointer returns a Nullable that is null when value is nil,
+// or set to the value that value points to.
+func NullableFromPointer[T any](value *T) Nullable[T] {
+	if value == nil {
+		return Null[T]()
+	}
+
+	return NewNullable(*value)
+}
+
+// Get returns the value of a Nullable and whether it is set.
+func (n Nullable[T]) Get() (T, bool) {
+	value, ok := n[true]
+
+	return value, ok
+}
+
+// IsAbsent returns whether a Nullable is absent.
+func (n Nullable[T]) IsAbsent() bool {
+	return len(n) == 0
+}
+
+// IsNull returns whether a Nullable is null.
+func (n Nullable[T]) IsNull() bool {
+	_, value := n[true]
+
+	return !value && len(n) != 0
+}
+
+// MarshalJSON marshals a Nullable into its value, or null when it is null or absent.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	value, ok := n[true]
+	switch {
+	case ok && len(n) == 1:
+		return json.Marshal(value)
+	case !ok && len(n) <= 1:
+		return []byte("null"), nil
+	}
+
+	return nil, errInvalidNullable
+}
+
+// UnmarshalJSON unmarshals JSON data into the value of a Nullable,
+// which is null when the data is null.
+//
+// A Nullable field is absent when its key is not included in the JSON data.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]()
+
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*n = NewNullable(value)
+
+	return nil
+}
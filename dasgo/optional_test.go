@@ -0,0 +1,208 @@
+package dasgo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	value := "value"
+	tests := []struct {
+		name     string
+		optional Optional[string]
+		absent   bool
+		json     string
+	}{
+		{name: "absent", optional: nil, absent: true, json: `{}`},
+		{name: "empty", optional: Optional[string]{}, absent: true, json: `{}`},
+		{name: "absent pointer", optional: OptionalFromPointer[string](nil), absent: true, json: `{}`},
+		{name: "zero value", optional: NewOptional(""), json: `{"name":""}`},
+		{name: "value", optional: NewOptional("value"), json: `{"name":"value"}`},
+		{name: "pointer", optional: OptionalFromPointer(&value), json: `{"name":"value"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.optional.IsAbsent() != test.absent {
+				t.Fatalf("expected IsAbsent to be %v", test.absent)
+			}
+
+			if _, ok := test.optional.Get(); ok == test.absent {
+				t.Fatalf("expected Get to return %v", !test.absent)
+			}
+
+			data, err := json.Marshal(struct {
+				Name Optional[string] `json:"name,omitempty"`
+			}{Name: test.optional})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != test.json {
+				t.Fatalf("expected %s, got %s", test.json, data)
+			}
+
+			// the JSON data is unmarshalled into the same state.
+			var decoded struct {
+				Name Optional[string] `json:"name"`
+			}
+
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			got, _ := decoded.Name.Get()
+			want, _ := test.optional.Get()
+			if decoded.Name.IsAbsent() != test.absent || got != want {
+				t.Fatalf("expected %q (absent %v), got %q (absent %v)", want, test.absent, got, decoded.Name.IsAbsent())
+			}
+		})
+	}
+
+	// an Optional that is unmarshalled from null is absent.
+	var decoded struct {
+		Name Optional[string] `json:"name"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"name":null}`), &decoded); err != nil || !decoded.Name.IsAbsent() {
+		t.Fatalf("expected an absent Optional, got %v (%v)", decoded.Name, err)
+	}
+}
+
+func TestNullable(t *testing.T) {
+	value := "value"
+	tests := []struct {
+		name     string
+		nullable Nullable[string]
+		absent   bool
+		null     bool
+		json     string
+	}{
+		{name: "absent", nullable: nil, absent: true, json: `{}`},
+		{name: "empty", nullable: Nullable[string]{}, absent: true, json: `{}`},
+		{name: "null", nullable: Null[string](), null: true, json: `{"nick":null}`},
+		{name: "null pointer", nullable: NullableFromPointer[string](nil), null: true, json: `{"nick":null}`},
+		{name: "zero value", nullable: NewNullable(""), json: `{"nick":""}`},
+		{name: "value", nullable: NewNullable("value"), json: `{"nick":"value"}`},
+		{name: "pointer", nullable: NullableFromPointer(&value), json: `{"nick":"value"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.nullable.IsAbsent() != test.absent || test.nullable.IsNull() != test.null {
+				t.Fatalf("expected IsAbsent %v and IsNull %v, got %v and %v",
+					test.absent, test.null, test.nullable.IsAbsent(), test.nullable.IsNull())
+			}
+
+			if _, ok := test.nullable.Get(); ok != (!test.absent && !test.null) {
+				t.Fatalf("expected Get to return %v", !test.absent && !test.null)
+			}
+
+			data, err := json.Marshal(struct {
+				Nick Nullable[string] `json:"nick,omitempty"`
+			}{Nick: test.nullable})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != test.json {
+				t.Fatalf("expected %s, got %s", test.json, data)
+			}
+
+			// the JSON data is unmarshalled into the same state.
+			var decoded struct {
+				Nick Nullable[string] `json:"nick"`
+			}
+
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			got, _ := decoded.Nick.Get()
+			want, _ := test.nullable.Get()
+			if decoded.Nick.IsAbsent() != test.absent || decoded.Nick.IsNull() != test.null || got != want {
+				t.Fatalf("expected %q (absent %v, null %v), got %q (absent %v, null %v)",
+					want, test.absent, test.null, got, decoded.Nick.IsAbsent(), decoded.Nick.IsNull())
+			}
+		})
+	}
+}
+
+func TestOptionalInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		err   error
+	}{
+		{name: "optional without a value", value: Optional[string]{false: "value"}, err: errInvalidOptional},
+		{name: "optional with both keys", value: Optional[string]{false: "", true: "value"}, err: errInvalidOptional},
+		{name: "nullable with both keys", value: Nullable[string]{false: "", true: "value"}, err: errInvalidNullable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// an invalid map is not omitted, so it returns an error rather than sending null.
+			_, err := json.Marshal(map[string]interface{}{"field": test.value})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestOptionalPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		request interface{}
+		json    string
+	}{
+		{
+			name:    "absent nick",
+			request: &ModifyGuildMember{GuildID: 1, UserID: 2},
+			json:    `{}`,
+		},
+		{
+			name:    "null nick",
+			request: &ModifyGuildMember{GuildID: 1, UserID: 2, Nick: Null[string]()},
+			json:    `{"nick":null}`,
+		},
+		{
+			name:    "nick",
+			request: &ModifyGuildMember{GuildID: 1, UserID: 2, Nick: NewNullable("nick")},
+			json:    `{"nick":"nick"}`,
+		},
+		{
+			name:    "absent parent",
+			request: &ModifyChannelGuild{ChannelID: 1, Name: NewOptional("general")},
+			json:    `{"name":"general"}`,
+		},
+		{
+			name:    "null parent",
+			request: &ModifyChannelGuild{ChannelID: 1, ParentID: Null[Snowflake]()},
+			json:    `{"parent_id":null}`,
+		},
+		{
+			name:    "parent",
+			request: &ModifyChannelGuild{ChannelID: 1, ParentID: NewNullable(Snowflake(5))},
+			json:    `{"parent_id":"5"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := jsonPayload(test.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(payload) != test.json {
+				t.Fatalf("expected %s, got %s", test.json, payload)
+			}
+		})
+	}
+
+	if _, err := jsonPayload(&ModifyGuildMember{Nick: Nullable[string]{false: "", true: "nick"}}); !errors.Is(err, errInvalidNullable) {
+		t.Fatalf("expected %v, got %v", errInvalidNullable, err)
+	}
+}
@@ -0,0 +1,467 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Pagination Limits
+// https://discord.com/developers/docs/reference#pagination
+const (
+	FlagPaginationLimitGetChannelMessages          = 100
+	FlagPaginationLimitGetGuildBans                = 1000
+	FlagPaginationLimitListGuildMembers            = 1000
+	FlagPaginationLimitGetReactions                = 100
+	FlagPaginationLimitGetGuildAuditLog            = 100
+	FlagPaginationLimitGetCurrentUserGuilds        = 200
+	FlagPaginationLimitGetGuildScheduledEventUsers = 100
+	FlagPaginationLimitListArchivedThreads         = 100
+)
+
+// Fetch represents a function that sends a request and returns its response (i.e a REST client method).
+type Fetch[Request, Response any] func(ctx context.Context, request *Request) (Response, error)
+
+// Iterator represents an iterator over the results of a paginated list endpoint,
+// which fetches the next page of results when the current page is exhausted.
+//
+//	it := PaginateChannelMessages(request, fetch).Limit(500)
+//	for it.Next(ctx) {
+//		message := it.Value()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	// fetch fetches the next page of at most limit results, and returns whether another page exists.
+	fetch func(ctx context.Context, limit int) ([]T, bool, error)
+
+	pageSize int
+	limit    int
+	until    func(T) bool
+
+	page  []T
+	more  bool
+	value T
+	count int
+	done  bool
+	err   error
+}
+
+// newIterator returns a new Iterator that fetches pages of at most pageSize results.
+func newIterator[T any](pageSize int, fetch func(ctx context.Context, limit int) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, pageSize: pageSize, more: true}
+}
+
+// Limit sets the maximum number of results returned by the iterator (unlimited when zero).
+func (it *Iterator[T]) Limit(limit int) *Iterator[T] {
+	it.limit = limit
+	return it
+}
+
+// Until stops the iterator at the first result that matches a predicate, which is not returned.
+func (it *Iterator[T]) Until(stop func(T) bool) *Iterator[T] {
+	it.until = stop
+	return it
+}
+
+// Next advances the iterator to the next result, and returns whether a result is available.
+//
+// A page is fetched with the context when the current page is exhausted.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.done || it.limit != 0 && it.count >= it.limit {
+		it.done = true
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if !it.more {
+			it.done = true
+			return false
+		}
+
+		if err := ctx.Err(); err != nil {
+			it.err, it.done = err, true
+			return false
+		}
+
+		size := it.pageSize
+		if remaining := it.limit - it.count; it.limit != 0 && remaining < size {
+			size = remaining
+		}
+
+		page, more, err := it.fetch(ctx, size)
+		if err != nil {
+			it.err, it.done = err, true
+			return false
+		}
+
+		it.page, it.more = page, more && len(page) != 0
+	}
+
+	value := it.page[0]
+	it.page = it.page[1:]
+
+	if it.until != nil && it.until(value) {
+		it.done = true
+		return false
+	}
+
+	it.value = value
+	it.count++
+
+	return true
+}
+
+// Value returns the current result of the iterator.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iterator, or nil when the iterator is exhausted.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All returns the remaining results of the iterator.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var results []T
+	for it.Next(ctx) {
+		results = append(results, it.Value())
+	}
+
+	return results, it.Err()
+}
+
+// sortPage sorts a page of results by ID in ascending or descending order,
+// and returns the last non-zero ID (which is the cursor of the next page).
+//
+// A page without a cursor (i.e every result is missing its user) is the last page.
+func sortPage[T any](page []T, id func(T) Snowflake, ascending bool) Snowflake {
+	sort.SliceStable(page, func(i, j int) bool {
+		if ascending {
+			return id(page[i]) < id(page[j])
+		}
+
+		return id(page[i]) > id(page[j])
+	})
+
+	for i := len(page) - 1; i >= 0; i-- {
+		if cursor := id(page[i]); cursor != 0 {
+			return cursor
+		}
+	}
+
+	return 0
+}
+
+// userID returns the ID of a user, or zero when it's nil.
+func userID(user *User) Snowflake {
+	if user == nil {
+		return 0
+	}
+
+	return user.ID
+}
+
+// pageSize returns the size of the pages of a request with a limit, which is at most max.
+func pageSize(limit, max int) int {
+	if limit <= 0 || limit > max {
+		return max
+	}
+
+	return limit
+}
+
+// optionalInt returns the value of an optional int, or zero when it's nil.
+func optionalInt(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
+
+// PaginateChannelMessages returns an iterator over the messages of a Get Channel Messages request.
+//
+// Messages are returned from newest to oldest (Before, or neither cursor), or from oldest to newest (After).
+// A request with an Around cursor returns a single page of messages.
+func PaginateChannelMessages(request *GetChannelMessages, fetch Fetch[GetChannelMessages, []*Message]) *Iterator[*Message] {
+	paginated := *request
+	ascending := paginated.After != nil && paginated.Before == nil && paginated.Around == nil
+
+	return newIterator(pageSize(int(request.Limit), FlagPaginationLimitGetChannelMessages),
+		func(ctx context.Context, limit int) ([]*Message, bool, error) {
+			paginated.Limit = Flag(limit)
+			page, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if paginated.Around != nil {
+				return page, false, nil
+			}
+
+			cursor := sortPage(page, func(m *Message) Snowflake { return m.ID }, ascending)
+			if ascending {
+				paginated.After = &cursor
+			} else {
+				paginated.Before = &cursor
+			}
+
+			return page, len(page) == limit && cursor != 0, nil
+		})
+}
+
+// PaginateGuildBans returns an iterator over the bans of a Get Guild Bans request.
+//
+// Bans are returned in ascending order of user ID (After, or neither cursor),
+// or in descending order (Before).
+func PaginateGuildBans(request *GetGuildBans, fetch Fetch[GetGuildBans, []*Ban]) *Iterator[*Ban] {
+	paginated := *request
+	ascending := paginated.Before == nil
+
+	return newIterator(pageSize(optionalInt(request.Limit), FlagPaginationLimitGetGuildBans),
+		func(ctx context.Context, limit int) ([]*Ban, bool, error) {
+			paginated.Limit = &limit
+			page, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			cursor := sortPage(page, func(b *Ban) Snowflake { return userID(b.User) }, ascending)
+			if ascending {
+				paginated.After = &cursor
+			} else {
+				paginated.Before = &cursor
+			}
+
+			return page, len(page) == limit && cursor != 0, nil
+		})
+}
+
+// PaginateGuildMembers returns an iterator over the members of a List Guild Members request,
+// which are returned in ascending order of user ID.
+func PaginateGuildMembers(request *ListGuildMembers, fetch Fetch[ListGuildMembers, []*GuildMember]) *Iterator[*GuildMember] {
+	paginated := *request
+
+	return newIterator(pageSize(optionalInt(request.Limit), FlagPaginationLimitListGuildMembers),
+		func(ctx context.Context, limit int) ([]*GuildMember, bool, error) {
+			paginated.Limit = &limit
+			page, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			cursor := sortPage(page, func(m *GuildMember) Snowflake { return userID(m.User) }, true)
+			paginated.After = &cursor
+
+			return page, len(page) == limit && cursor != 0, nil
+		})
+}
+
+// PaginateReactions returns an iterator over the users of a Get Reactions request,
+// which are returned in ascending order of user ID.
+func PaginateReactions(request *GetReactions, fetch Fetch[GetReactions, []*User]) *Iterator[*User] {
+	paginated := *request
+
+	return newIterator(pageSize(int(request.Limit), FlagPaginationLimitGetReactions),
+		func(ctx context.Context, limit int) ([]*User, bool, error) {
+			paginated.Limit = Flag(limit)
+			page, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			cursor := sortPage(page, func(u *User) Snowflake { return userID(u) }, true)
+			paginated.After = cursor
+
+			return page, len(page) == limit && cursor != 0, nil
+		})
+}
+
+// PaginateGuildAuditLog returns an iterator over the entries of a Get Guild Audit Log request.
+//
+// Entries are returned from newest to oldest (Before, or neither cursor), or from oldest to newest (After).
+// The users, webhooks, and other objects referenced by the entries are not returned.
+func PaginateGuildAuditLog(request *GetGuildAuditLog, fetch Fetch[GetGuildAuditLog, *AuditLog]) *Iterator[*AuditLogEntry] {
+	paginated := *request
+	ascending := paginated.After != 0 && paginated.Before == 0
+
+	return newIterator(pageSize(int(request.Limit), FlagPaginationLimitGetGuildAuditLog),
+		func(ctx context.Context, limit int) ([]*AuditLogEntry, bool, error) {
+			paginated.Limit = Flag(limit)
+			auditLog, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if auditLog == nil {
+				return nil, false, nil
+			}
+
+			page := auditLog.AuditLogEntries
+			cursor := sortPage(page, func(e *AuditLogEntry) Snowflake { return e.ID }, ascending)
+			if ascending {
+				paginated.After = cursor
+			} else {
+				paginated.Before = cursor
+			}
+
+			return page, len(page) == limit && cursor != 0, nil
+		})
+}
+
+// PaginateCurrentUserGuilds returns an iterator over the guilds of a Get Current User Guilds request.
+//
+// Guilds are returned in ascending order of guild ID (After, or neither cursor),
+// or in descending order (Before).
+func PaginateCurrentUserGuilds(request *GetCurrentUserGuilds, fetch Fetch[GetCurrentUserGuilds, []*Guild]) *Iterator[*Guild] {
+	paginated := *request
+	ascending := paginated.Before == nil
+
+	return newIterator(pageSize(optionalInt(request.Limit), FlagPaginationLimitGetCurrentUserGuilds),
+		func(ctx context.Context, limit int) ([]*Guild, bool, error) {
+			paginated.Limit = &limit
+			page, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			cursor := sortPage(page, func(g *Guild) Snowflake { return g.ID }, ascending)
+			if ascending {
+				paginated.After = &cursor
+			} else {
+				paginated.Before = &cursor
+			}
+
+			return page, len(page) == limit && cursor != 0, nil
+		})
+}
+
+// PaginateGuildScheduledEventUsers returns an iterator over the users of a Get Guild Scheduled Event Users request.
+//
+// Users are returned in ascending order of user ID (After, or neither cursor),
+// or in descending order (Before).
+func PaginateGuildScheduledEventUsers(request *GetGuildScheduledEventUsers,
+	fetch Fetch[GetGuildScheduledEventUsers, []*GuildScheduledEventUser]) *Iterator[*GuildScheduledEventUser] {
+	paginated := *request
+	ascending := paginated.Before == nil
+
+	return newIterator(pageSize(optionalInt(request.Limit), FlagPaginationLimitGetGuildScheduledEventUsers),
+		func(ctx context.Context, limit int) ([]*GuildScheduledEventUser, bool, error) {
+			paginated.Limit = &limit
+			page, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			cursor := sortPage(page, func(u *GuildScheduledEventUser) Snowflake { return userID(u.User) }, ascending)
+			if ascending {
+				paginated.After = &cursor
+			} else {
+				paginated.Before = &cursor
+			}
+
+			return page, len(page) == limit && cursor != 0, nil
+		})
+}
+
+// archiveTimestamp returns the archive timestamp of a thread.
+func archiveTimestamp(thread *Channel) time.Time {
+	if thread.ThreadMetadata == nil {
+		return time.Time{}
+	}
+
+	return thread.ThreadMetadata.ArchiveTimestamp
+}
+
+// sortThreads sorts archived threads from the most to the least recently archived.
+func sortThreads(threads []*Channel) {
+	sort.SliceStable(threads, func(i, j int) bool {
+		return archiveTimestamp(threads[i]).After(archiveTimestamp(threads[j]))
+	})
+}
+
+// PaginatePublicArchivedThreads returns an iterator over the threads of a List Public Archived Threads request,
+// which are returned from the most to the least recently archived.
+func PaginatePublicArchivedThreads(request *ListPublicArchivedThreads,
+	fetch Fetch[ListPublicArchivedThreads, *ListPublicArchivedThreadsResponse]) *Iterator[*Channel] {
+	paginated := *request
+
+	return newIterator(pageSize(request.Limit, FlagPaginationLimitListArchivedThreads),
+		func(ctx context.Context, limit int) ([]*Channel, bool, error) {
+			paginated.Limit = limit
+			response, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if response == nil {
+				return nil, false, nil
+			}
+
+			page := response.Threads
+			sortThreads(page)
+			if len(page) != 0 {
+				paginated.Before = archiveTimestamp(page[len(page)-1])
+			}
+
+			return page, response.HasMore, nil
+		})
+}
+
+// PaginatePrivateArchivedThreads returns an iterator over the threads of a List Private Archived Threads request,
+// which are returned from the most to the least recently archived.
+func PaginatePrivateArchivedThreads(request *ListPrivateArchivedThreads,
+	fetch Fetch[ListPrivateArchivedThreads, *ListPrivateArchivedThreadsResponse]) *Iterator[*Channel] {
+	paginated := *request
+
+	return newIterator(pageSize(request.Limit, FlagPaginationLimitListArchivedThreads),
+		func(ctx context.Context, limit int) ([]*Channel, bool, error) {
+			paginated.Limit = limit
+			response, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if response == nil {
+				return nil, false, nil
+			}
+
+			page := response.Threads
+			sortThreads(page)
+			if len(page) != 0 {
+				paginated.Before = archiveTimestamp(page[len(page)-1])
+			}
+
+			return page, response.HasMore, nil
+		})
+}
+
+// PaginateJoinedPrivateArchivedThreads returns an iterator over the threads of a List Joined Private Archived Threads
+// request, which are returned in descending order of thread ID.
+func PaginateJoinedPrivateArchivedThreads(request *ListJoinedPrivateArchivedThreads,
+	fetch Fetch[ListJoinedPrivateArchivedThreads, *ListJoinedPrivateArchivedThreadsResponse]) *Iterator[*Channel] {
+	paginated := *request
+
+	return newIterator(pageSize(request.Limit, FlagPaginationLimitListArchivedThreads),
+		func(ctx context.Context, limit int) ([]*Channel, bool, error) {
+			paginated.Limit = limit
+			response, err := fetch(ctx, &paginated)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if response == nil {
+				return nil, false, nil
+			}
+
+			page := response.Threads
+			paginated.Before = sortPage(page, func(c *Channel) Snowflake { return c.ID }, false)
+
+			return page, response.HasMore, nil
+		})
+}
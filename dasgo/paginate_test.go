@@ -0,0 +1,509 @@
+package dasgo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// pageLimit represents the page size requested by the paginator tests.
+const pageLimit = 50
+
+// cursor represents the cursors of a paginated request.
+type cursor struct {
+	before, after Snowflake
+}
+
+// fakeEndpoint represents a paginated list endpoint of the results with IDs 1 to n.
+type fakeEndpoint struct {
+	n      int
+	limits []int
+}
+
+// fetch returns at most limit IDs before (in descending order) or after (in ascending order) a cursor,
+// and whether more IDs exist. A request without a cursor starts at the last ID when descending.
+//
+// The IDs are returned in reverse order, which is corrected by the paginators.
+func (f *fakeEndpoint) fetch(c cursor, limit int, descending bool) ([]Snowflake, bool) {
+	f.limits = append(f.limits, limit)
+
+	var ids []Snowflake
+	var more bool
+	if c.after != 0 || c.before == 0 && !descending {
+		for id := c.after + 1; id <= Snowflake(f.n) && len(ids) < limit; id++ {
+			ids = append(ids, id)
+		}
+
+		more = len(ids) != 0 && ids[len(ids)-1] < Snowflake(f.n)
+	} else {
+		start := c.before - 1
+		if c.before == 0 {
+			start = Snowflake(f.n)
+		}
+
+		for id := start; id >= 1 && len(ids) < limit; id-- {
+			ids = append(ids, id)
+		}
+
+		more = len(ids) != 0 && ids[len(ids)-1] > 1
+	}
+
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+
+	return ids, more
+}
+
+// expectedIDs returns the IDs that a paginator over a fakeEndpoint returns.
+func expectedIDs(n int, c cursor, descending bool, limit int, until Snowflake) []Snowflake {
+	var ids []Snowflake
+	for i := 1; i <= n; i++ {
+		id := Snowflake(i)
+		if descending {
+			id = Snowflake(n + 1 - i)
+		}
+
+		if c.before != 0 && id >= c.before || c.after != 0 && id <= c.after {
+			continue
+		}
+
+		if id == until || limit != 0 && len(ids) == limit {
+			break
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// collectIDs returns the IDs of the results of an iterator with a limit,
+// which stops at the until ID (when non-zero).
+func collectIDs[T any](ctx context.Context, it *Iterator[T], id func(T) Snowflake, limit int, until Snowflake) ([]Snowflake, error) {
+	it.Limit(limit)
+	if until != 0 {
+		it.Until(func(v T) bool { return id(v) == until })
+	}
+
+	var ids []Snowflake
+	for it.Next(ctx) {
+		ids = append(ids, id(it.Value()))
+	}
+
+	return ids, it.Err()
+}
+
+// optionalSnowflake returns the value of an optional snowflake, or zero when it's nil.
+func optionalSnowflake(s *Snowflake) Snowflake {
+	if s == nil {
+		return 0
+	}
+
+	return *s
+}
+
+// pointerCursor returns the pointer cursors of a paginated request.
+func pointerCursor(c cursor) (before, after *Snowflake) {
+	if c.before != 0 {
+		before = &c.before
+	}
+
+	if c.after != 0 {
+		after = &c.after
+	}
+
+	return before, after
+}
+
+// archiveBase represents the archive timestamp of the thread with ID 0.
+var archiveBase = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// archivedThreads returns the archived threads with IDs, which are archived in order of ID.
+func archivedThreads(ids []Snowflake) []*Channel {
+	threads := make([]*Channel, len(ids))
+	for i, id := range ids {
+		threads[i] = &Channel{ID: id, ThreadMetadata: &ThreadMetadata{ArchiveTimestamp: archiveBase.Add(time.Duration(id) * time.Second)}}
+	}
+
+	return threads
+}
+
+// archiveCursor returns the cursor of an archive timestamp.
+func archiveCursor(before time.Time) cursor {
+	if before.IsZero() {
+		return cursor{}
+	}
+
+	return cursor{before: Snowflake(before.Sub(archiveBase) / time.Second)}
+}
+
+// paginator represents a paginator over a fakeEndpoint.
+type paginator struct {
+	name string
+
+	// descending represents whether results are returned in descending order without a cursor.
+	descending bool
+
+	// before and after represent whether the paginator supports each cursor.
+	before, after bool
+
+	paginate func(ctx context.Context, f *fakeEndpoint, c cursor, limit int, until Snowflake) ([]Snowflake, error)
+}
+
+// paginators returns the paginators over a fakeEndpoint.
+func paginators() []paginator {
+	limit := pageLimit
+	return []paginator{
+		{
+			name: "channel messages", descending: true, before: true, after: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &GetChannelMessages{ChannelID: 1, Limit: pageLimit}
+				request.Before, request.After = pointerCursor(c)
+
+				it := PaginateChannelMessages(request, func(ctx context.Context, r *GetChannelMessages) ([]*Message, error) {
+					ids, _ := f.fetch(cursor{optionalSnowflake(r.Before), optionalSnowflake(r.After)}, int(r.Limit), true)
+					messages := make([]*Message, len(ids))
+					for i, id := range ids {
+						messages[i] = &Message{ID: id}
+					}
+
+					return messages, nil
+				})
+
+				return collectIDs(ctx, it, func(m *Message) Snowflake { return m.ID }, n, until)
+			},
+		},
+		{
+			name: "guild bans", before: true, after: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &GetGuildBans{GuildID: 1, Limit: &limit}
+				request.Before, request.After = pointerCursor(c)
+
+				it := PaginateGuildBans(request, func(ctx context.Context, r *GetGuildBans) ([]*Ban, error) {
+					ids, _ := f.fetch(cursor{optionalSnowflake(r.Before), optionalSnowflake(r.After)}, *r.Limit, false)
+					bans := make([]*Ban, len(ids))
+					for i, id := range ids {
+						bans[i] = &Ban{User: &User{ID: id}}
+					}
+
+					return bans, nil
+				})
+
+				return collectIDs(ctx, it, func(b *Ban) Snowflake { return b.User.ID }, n, until)
+			},
+		},
+		{
+			name: "guild members", after: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &ListGuildMembers{GuildID: 1, Limit: &limit}
+				_, request.After = pointerCursor(c)
+
+				it := PaginateGuildMembers(request, func(ctx context.Context, r *ListGuildMembers) ([]*GuildMember, error) {
+					ids, _ := f.fetch(cursor{after: optionalSnowflake(r.After)}, *r.Limit, false)
+					members := make([]*GuildMember, len(ids))
+					for i, id := range ids {
+						members[i] = &GuildMember{User: &User{ID: id}}
+					}
+
+					return members, nil
+				})
+
+				return collectIDs(ctx, it, func(m *GuildMember) Snowflake { return m.User.ID }, n, until)
+			},
+		},
+		{
+			name: "reactions", after: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &GetReactions{ChannelID: 1, MessageID: 2, Emoji: "👍", After: c.after, Limit: pageLimit}
+
+				it := PaginateReactions(request, func(ctx context.Context, r *GetReactions) ([]*User, error) {
+					ids, _ := f.fetch(cursor{after: r.After}, int(r.Limit), false)
+					users := make([]*User, len(ids))
+					for i, id := range ids {
+						users[i] = &User{ID: id}
+					}
+
+					return users, nil
+				})
+
+				return collectIDs(ctx, it, func(u *User) Snowflake { return u.ID }, n, until)
+			},
+		},
+		{
+			name: "guild audit log", descending: true, before: true, after: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &GetGuildAuditLog{GuildID: 1, Before: c.before, After: c.after, Limit: pageLimit}
+
+				it := PaginateGuildAuditLog(request, func(ctx context.Context, r *GetGuildAuditLog) (*AuditLog, error) {
+					if r.Before != 0 && r.After != 0 {
+						return nil, errors.New("expected a single cursor")
+					}
+
+					ids, _ := f.fetch(cursor{r.Before, r.After}, int(r.Limit), true)
+					auditLog := &AuditLog{AuditLogEntries: make([]*AuditLogEntry, len(ids))}
+					for i, id := range ids {
+						auditLog.AuditLogEntries[i] = &AuditLogEntry{ID: id}
+					}
+
+					return auditLog, nil
+				})
+
+				return collectIDs(ctx, it, func(e *AuditLogEntry) Snowflake { return e.ID }, n, until)
+			},
+		},
+		{
+			name: "current user guilds", before: true, after: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &GetCurrentUserGuilds{Limit: &limit}
+				request.Before, request.After = pointerCursor(c)
+
+				it := PaginateCurrentUserGuilds(request, func(ctx context.Context, r *GetCurrentUserGuilds) ([]*Guild, error) {
+					ids, _ := f.fetch(cursor{optionalSnowflake(r.Before), optionalSnowflake(r.After)}, *r.Limit, false)
+					guilds := make([]*Guild, len(ids))
+					for i, id := range ids {
+						guilds[i] = &Guild{ID: id}
+					}
+
+					return guilds, nil
+				})
+
+				return collectIDs(ctx, it, func(g *Guild) Snowflake { return g.ID }, n, until)
+			},
+		},
+		{
+			name: "guild scheduled event users", before: true, after: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &GetGuildScheduledEventUsers{GuildID: 1, GuildScheduledEventID: 2, Limit: &limit}
+				request.Before, request.After = pointerCursor(c)
+
+				it := PaginateGuildScheduledEventUsers(request,
+					func(ctx context.Context, r *GetGuildScheduledEventUsers) ([]*GuildScheduledEventUser, error) {
+						ids, _ := f.fetch(cursor{optionalSnowflake(r.Before), optionalSnowflake(r.After)}, *r.Limit, false)
+						users := make([]*GuildScheduledEventUser, len(ids))
+						for i, id := range ids {
+							users[i] = &GuildScheduledEventUser{User: &User{ID: id}}
+						}
+
+						return users, nil
+					})
+
+				return collectIDs(ctx, it, func(u *GuildScheduledEventUser) Snowflake { return u.User.ID }, n, until)
+			},
+		},
+		{
+			name: "public archived threads", descending: true, before: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &ListPublicArchivedThreads{ChannelID: 1, Limit: pageLimit}
+				if c.before != 0 {
+					request.Before = archiveBase.Add(time.Duration(c.before) * time.Second)
+				}
+
+				it := PaginatePublicArchivedThreads(request,
+					func(ctx context.Context, r *ListPublicArchivedThreads) (*ListPublicArchivedThreadsResponse, error) {
+						ids, more := f.fetch(archiveCursor(r.Before), r.Limit, true)
+						return &ListPublicArchivedThreadsResponse{Threads: archivedThreads(ids), HasMore: more}, nil
+					})
+
+				return collectIDs(ctx, it, func(c *Channel) Snowflake { return c.ID }, n, until)
+			},
+		},
+		{
+			name: "private archived threads", descending: true, before: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &ListPrivateArchivedThreads{ChannelID: 1, Limit: pageLimit}
+				if c.before != 0 {
+					request.Before = archiveBase.Add(time.Duration(c.before) * time.Second)
+				}
+
+				it := PaginatePrivateArchivedThreads(request,
+					func(ctx context.Context, r *ListPrivateArchivedThreads) (*ListPrivateArchivedThreadsResponse, error) {
+						ids, more := f.fetch(archiveCursor(r.Before), r.Limit, true)
+						return &ListPrivateArchivedThreadsResponse{Threads: archivedThreads(ids), HasMore: more}, nil
+					})
+
+				return collectIDs(ctx, it, func(c *Channel) Snowflake { return c.ID }, n, until)
+			},
+		},
+		{
+			name: "joined private archived threads", descending: true, before: true,
+			paginate: func(ctx context.Context, f *fakeEndpoint, c cursor, n int, until Snowflake) ([]Snowflake, error) {
+				request := &ListJoinedPrivateArchivedThreads{ChannelID: 1, Before: c.before, Limit: pageLimit}
+
+				it := PaginateJoinedPrivateArchivedThreads(request,
+					func(ctx context.Context, r *ListJoinedPrivateArchivedThreads) (*ListJoinedPrivateArchivedThreadsResponse, error) {
+						ids, more := f.fetch(cursor{before: r.Before}, r.Limit, true)
+						return &ListJoinedPrivateArchivedThreadsResponse{Threads: archivedThreads(ids), HasMore: more}, nil
+					})
+
+				return collectIDs(ctx, it, func(c *Channel) Snowflake { return c.ID }, n, until)
+			},
+		},
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	const n = 230
+
+	tests := []struct {
+		name   string
+		cursor cursor
+		limit  int
+		until  Snowflake
+		limits []int
+	}{
+		{name: "all"},
+		{name: "limit", limit: 120, limits: []int{50, 50, 20}},
+		{name: "small limit", limit: 10, limits: []int{10}},
+		{name: "until", until: 100},
+		{name: "before", cursor: cursor{before: 150}},
+		{name: "after", cursor: cursor{after: 150}},
+		{name: "after with a limit", cursor: cursor{after: 150}, limit: 60, limits: []int{50, 10}},
+	}
+
+	for _, p := range paginators() {
+		for _, test := range tests {
+			if test.cursor.before != 0 && !p.before || test.cursor.after != 0 && !p.after {
+				continue
+			}
+
+			t.Run(p.name+"/"+test.name, func(t *testing.T) {
+				descending := test.cursor.before != 0 || test.cursor.after == 0 && p.descending
+
+				f := &fakeEndpoint{n: n}
+				ids, err := p.paginate(context.Background(), f, test.cursor, test.limit, test.until)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if want := expectedIDs(n, test.cursor, descending, test.limit, test.until); !reflect.DeepEqual(ids, want) {
+					t.Fatalf("expected %v, got %v", want, ids)
+				}
+
+				if test.limits != nil && !reflect.DeepEqual(f.limits, test.limits) {
+					t.Fatalf("expected page limits %v, got %v", test.limits, f.limits)
+				}
+
+				for _, limit := range f.limits {
+					if limit > pageLimit {
+						t.Fatalf("expected page limits of at most %d, got %v", pageLimit, f.limits)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPaginateContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the context is canceled while the first page is iterated.
+	var fetches int
+	it := PaginateReactions(&GetReactions{Limit: pageLimit}, func(ctx context.Context, r *GetReactions) ([]*User, error) {
+		fetches++
+		cancel()
+
+		users := make([]*User, int(r.Limit))
+		for i := range users {
+			users[i] = &User{ID: r.After + Snowflake(i) + 1}
+		}
+
+		return users, nil
+	})
+
+	users, err := it.All(ctx)
+	if !errors.Is(err, context.Canceled) || len(users) != pageLimit || fetches != 1 {
+		t.Fatalf("expected context.Canceled after 1 page, got %d users from %d fetches (%v)", len(users), fetches, err)
+	}
+
+	// an iterator that is stopped doesn't fetch another page.
+	if it.Next(context.Background()) || fetches != 1 {
+		t.Fatalf("expected the iterator to be done, got %d fetches", fetches)
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	fetchErr := errors.New("fetch error")
+
+	var fetches int
+	it := PaginateChannelMessages(&GetChannelMessages{Limit: 2}, func(ctx context.Context, r *GetChannelMessages) ([]*Message, error) {
+		fetches++
+		if fetches == 2 {
+			return nil, fetchErr
+		}
+
+		return []*Message{{ID: 10}, {ID: 9}}, nil
+	})
+
+	messages, err := it.All(context.Background())
+	if !errors.Is(err, fetchErr) || len(messages) != 2 {
+		t.Fatalf("expected the fetch error after 2 messages, got %d messages (%v)", len(messages), err)
+	}
+
+	// a request with an Around cursor returns a single page.
+	around := Snowflake(5)
+	fetches = 0
+	it = PaginateChannelMessages(&GetChannelMessages{Around: &around, Limit: 2}, func(ctx context.Context, r *GetChannelMessages) ([]*Message, error) {
+		fetches++
+		return []*Message{{ID: 6}, {ID: 5}}, nil
+	})
+
+	if messages, err := it.All(context.Background()); err != nil || len(messages) != 2 || fetches != 1 {
+		t.Fatalf("expected a single page, got %d messages from %d fetches (%v)", len(messages), fetches, err)
+	}
+}
+
+func TestPaginateNil(t *testing.T) {
+	limit := 2
+
+	// results without a user don't have a cursor.
+	var fetches int
+	bans, err := PaginateGuildBans(&GetGuildBans{Limit: &limit}, func(ctx context.Context, r *GetGuildBans) ([]*Ban, error) {
+		fetches++
+		return []*Ban{{}, {}}, nil
+	}).All(context.Background())
+	if err != nil || len(bans) != 2 || fetches != 1 {
+		t.Fatalf("expected a single page of 2 bans, got %d bans from %d fetches (%v)", len(bans), fetches, err)
+	}
+
+	// a result without a user doesn't reset the cursor of a page.
+	var afters []Snowflake
+	members, err := PaginateGuildMembers(&ListGuildMembers{Limit: &limit}, func(ctx context.Context, r *ListGuildMembers) ([]*GuildMember, error) {
+		afters = append(afters, optionalSnowflake(r.After))
+		if len(afters) == 1 {
+			return []*GuildMember{{User: &User{ID: 5}}, {}}, nil
+		}
+
+		return nil, nil
+	}).All(context.Background())
+	if err != nil || len(members) != 2 || !reflect.DeepEqual(afters, []Snowflake{0, 5}) {
+		t.Fatalf("expected the second page after 5, got %d members after %v (%v)", len(members), afters, err)
+	}
+
+	users, err := PaginateGuildScheduledEventUsers(&GetGuildScheduledEventUsers{Limit: &limit},
+		func(ctx context.Context, r *GetGuildScheduledEventUsers) ([]*GuildScheduledEventUser, error) {
+			return []*GuildScheduledEventUser{{}, {}}, nil
+		}).All(context.Background())
+	if err != nil || len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d (%v)", len(users), err)
+	}
+
+	// a nil response is an empty page.
+	entries, err := PaginateGuildAuditLog(&GetGuildAuditLog{}, func(ctx context.Context, r *GetGuildAuditLog) (*AuditLog, error) {
+		return nil, nil
+	}).All(context.Background())
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected no entries, got %d (%v)", len(entries), err)
+	}
+
+	threads, err := PaginatePublicArchivedThreads(&ListPublicArchivedThreads{},
+		func(ctx context.Context, r *ListPublicArchivedThreads) (*ListPublicArchivedThreadsResponse, error) {
+			return nil, nil
+		}).All(context.Background())
+	if err != nil || len(threads) != 0 {
+		t.Fatalf("expected no threads, got %d (%v)", len(threads), err)
+	}
+}
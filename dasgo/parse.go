@@ -0,0 +1,403 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Content Token Types
+const (
+	FlagContentTokenTypeTEXT            = 0
+	FlagContentTokenTypeCODE            = 1
+	FlagContentTokenTypeUSER            = 2
+	FlagContentTokenTypeROLE            = 3
+	FlagContentTokenTypeCHANNEL         = 4
+	FlagContentTokenTypeEMOJI           = 5
+	FlagContentTokenTypeTIMESTAMP       = 6
+	FlagContentTokenTypeSLASH_COMMAND   = 7
+	FlagContentTokenTypeURL             = 8
+	FlagContentTokenTypeMENTIONEVERYONE = 9
+	FlagContentTokenTypeMENTIONHERE     = 10
+)
+
+// ContentToken represents a token of message content.
+type ContentToken struct {
+	Type Flag
+
+	// Raw represents the text of the token as it appears in the content.
+	Raw string
+
+	// Start and End represent the byte offsets of the token in the content.
+	Start int
+	End   int
+
+	// ID represents the ID of a mentioned user, role, channel, custom emoji, or command.
+	ID Snowflake
+
+	// Name represents the name of a custom emoji or command (including its subcommands).
+	Name string
+
+	// Animated determines whether a custom emoji is animated.
+	Animated bool
+
+	// Timestamp and Style represent a timestamp and its Timestamp Style (empty by default).
+	Timestamp time.Time
+	Style     string
+
+	// URL represents a URL, which is Suppressed when it's wrapped in <>.
+	URL        string
+	Suppressed bool
+}
+
+// IsMention determines whether the token mentions a user, role, channel, @everyone or @here.
+func (t *ContentToken) IsMention() bool {
+	switch t.Type {
+	case FlagContentTokenTypeUSER,
+		FlagContentTokenTypeROLE,
+		FlagContentTokenTypeCHANNEL,
+		FlagContentTokenTypeMENTIONEVERYONE,
+		FlagContentTokenTypeMENTIONHERE:
+		return true
+	}
+
+	return false
+}
+
+// Emoji returns the custom emoji of an EMOJI token.
+func (t *ContentToken) Emoji() *Emoji {
+	name := t.Name
+	animated := t.Animated
+
+	return &Emoji{ID: t.ID, Name: &name, Animated: &animated}
+}
+
+// codeRegex matches code blocks and inline code, which do not contain formatted tokens.
+//
+// Inline code that is wrapped in double backticks can contain a backtick (i.e a`b).
+var codeRegex = regexp.MustCompile("(?s)```.*?```|``.+?``|`[^`]+`")
+
+// contentTokenRegex matches formatted tokens.
+var contentTokenRegex = regexp.MustCompile(
+	`<@!?(\d+)>` +
+		`|<@&(\d+)>` +
+		`|<#(\d+)>` +
+		`|<(a?):(\w+):(\d+)>` +
+		`|<t:(-?\d+)(?::([tTdDfFR]))?>` +
+		`|</([-_\p{L}\p{N}\p{M} ]+):(\d+)>` +
+		`|<(https?://[^\s>]+)>` +
+		`|(https?://[^\s<]*[^\s<.,:;"'\)\]!?])` +
+		`|(@everyone|@here)`,
+)
+
+// ParseContent parses message content into a sequence of tokens.
+//
+// Plain text between formatted tokens is returned as TEXT tokens, while
+// code blocks and inline code are returned as CODE tokens (without being parsed).
+// A formatted token or code that is escaped with a backslash (i.e \<@80351110224678912>)
+// is returned as text.
+func ParseContent(content string) []*ContentToken {
+	var tokens []*ContentToken
+
+	text := func(start, end int) {
+		if start < end {
+			tokens = append(tokens, &ContentToken{Type: FlagContentTokenTypeTEXT, Raw: content[start:end], Start: start, End: end})
+		}
+	}
+
+	parse := func(start, end int) {
+		last := start
+		for _, match := range contentTokenRegex.FindAllStringSubmatchIndex(content[start:end], -1) {
+			token := parseContentToken(content[start:end], match)
+			if token == nil || isEscaped(content, start+token.Start) {
+				continue
+			}
+
+			token.Start += start
+			token.End += start
+			text(last, token.Start)
+			tokens = append(tokens, token)
+			last = token.End
+		}
+
+		text(last, end)
+	}
+
+	last := 0
+	for offset := 0; offset < len(content); {
+		match := codeRegex.FindStringIndex(content[offset:])
+		if match == nil {
+			break
+		}
+
+		start, end := offset+match[0], offset+match[1]
+
+		// an escaped backtick doesn't open code, but the backtick after it can.
+		if isEscaped(content, start) {
+			offset = start + 1
+			continue
+		}
+
+		parse(last, start)
+		tokens = append(tokens, &ContentToken{Type: FlagContentTokenTypeCODE, Raw: content[start:end], Start: start, End: end})
+		last, offset = end, end
+	}
+
+	parse(last, len(content))
+
+	return tokens
+}
+
+// isEscaped determines whether the character at index i of the content is escaped,
+// which is when it's preceded by an odd number of backslashes.
+func isEscaped(content string, i int) bool {
+	n := 0
+	for ; i > 0 && content[i-1] == '\\'; i-- {
+		n++
+	}
+
+	return n%2 == 1
+}
+
+// parseContentToken converts a match of contentTokenRegex to a token.
+//
+// nil is returned when the match contains an invalid value (i.e an overflowing snowflake).
+func parseContentToken(content string, match []int) *ContentToken {
+	group := func(i int) (string, bool) {
+		if match[2*i] < 0 {
+			return "", false
+		}
+
+		return content[match[2*i]:match[2*i+1]], true
+	}
+
+	token := &ContentToken{Raw: content[match[0]:match[1]], Start: match[0], End: match[1]}
+
+	snowflake := func(i int) bool {
+		s, _ := group(i)
+		id, err := strconv.ParseUint(s, 10, 64)
+		token.ID = Snowflake(id)
+
+		return err == nil
+	}
+
+	switch {
+	case match[2] >= 0:
+		token.Type = FlagContentTokenTypeUSER
+		if !snowflake(1) {
+			return nil
+		}
+
+	case match[4] >= 0:
+		token.Type = FlagContentTokenTypeROLE
+		if !snowflake(2) {
+			return nil
+		}
+
+	case match[6] >= 0:
+		token.Type = FlagContentTokenTypeCHANNEL
+		if !snowflake(3) {
+			return nil
+		}
+
+	case match[12] >= 0:
+		token.Type = FlagContentTokenTypeEMOJI
+		animated, _ := group(4)
+		token.Animated = animated == "a"
+		token.Name, _ = group(5)
+		if !snowflake(6) {
+			return nil
+		}
+
+	case match[14] >= 0:
+		token.Type = FlagContentTokenTypeTIMESTAMP
+		s, _ := group(7)
+		unix, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil
+		}
+
+		token.Timestamp = time.Unix(unix, 0).UTC()
+		token.Style, _ = group(8)
+
+	case match[18] >= 0:
+		token.Type = FlagContentTokenTypeSLASH_COMMAND
+		token.Name, _ = group(9)
+		if !snowflake(10) {
+			return nil
+		}
+
+	case match[22] >= 0:
+		token.Type = FlagContentTokenTypeURL
+		token.URL, _ = group(11)
+		token.Suppressed = true
+
+	case match[24] >= 0:
+		token.Type = FlagContentTokenTypeURL
+		token.URL, _ = group(12)
+
+	case match[26] >= 0:
+		if token.Raw == FlagMessageFormatMentionEveryone {
+			token.Type = FlagContentTokenTypeMENTIONEVERYONE
+		} else {
+			token.Type = FlagContentTokenTypeMENTIONHERE
+		}
+	}
+
+	return token
+}
+
+// parseSingleToken parses s as a single token of the given type.
+func parseSingleToken(s string, tokenType Flag) (*ContentToken, bool) {
+	tokens := ParseContent(s)
+	if len(tokens) != 1 || tokens[0].Type != tokenType {
+		return nil, false
+	}
+
+	return tokens[0], true
+}
+
+// ParseUserMention parses a user mention (i.e <@80351110224678912>).
+func ParseUserMention(s string) (Snowflake, bool) {
+	token, ok := parseSingleToken(s, FlagContentTokenTypeUSER)
+	if !ok {
+		return 0, false
+	}
+
+	return token.ID, true
+}
+
+// ParseRoleMention parses a role mention (i.e <@&165511591545143296>).
+func ParseRoleMention(s string) (Snowflake, bool) {
+	token, ok := parseSingleToken(s, FlagContentTokenTypeROLE)
+	if !ok {
+		return 0, false
+	}
+
+	return token.ID, true
+}
+
+// ParseChannelMention parses a channel mention (i.e <#103735883630395392>).
+func ParseChannelMention(s string) (Snowflake, bool) {
+	token, ok := parseSingleToken(s, FlagContentTokenTypeCHANNEL)
+	if !ok {
+		return 0, false
+	}
+
+	return token.ID, true
+}
+
+// ParseCustomEmoji parses a custom emoji (i.e <:mmLol:216154654256398347>).
+func ParseCustomEmoji(s string) (*Emoji, bool) {
+	token, ok := parseSingleToken(s, FlagContentTokenTypeEMOJI)
+	if !ok {
+		return nil, false
+	}
+
+	return token.Emoji(), true
+}
+
+// ParseTimestamp parses a timestamp (i.e <t:1618953630:d>) and returns its time and style.
+func ParseTimestamp(s string) (time.Time, string, bool) {
+	token, ok := parseSingleToken(s, FlagContentTokenTypeTIMESTAMP)
+	if !ok {
+		return time.Time{}, "", false
+	}
+
+	return token.Timestamp, token.Style, true
+}
+
+// MentionCheck represents the result of cross-checking the mentions of message content
+// against the mention fields of the message.
+type MentionCheck struct {
+	// Tokens represents the parsed content of the message.
+	Tokens []*ContentToken
+
+	// Unresolved represents user and role mention tokens that are not present in
+	// Message.Mentions or Message.MentionRoles (i.e due to Allowed Mentions).
+	Unresolved []*ContentToken
+
+	// UnreferencedUsers and UnreferencedRoles represent mentioned IDs without a token in
+	// the content (i.e the author of a replied message).
+	UnreferencedUsers []Snowflake
+	UnreferencedRoles []Snowflake
+
+	// CrosspostedChannels represents channel mention tokens that are present in Message.MentionChannels.
+	CrosspostedChannels []*ContentToken
+
+	// Everyone determines whether an @everyone or @here token is present.
+	Everyone bool
+}
+
+// CheckMentions parses the content of the message and cross-checks its
+// mention tokens against Message.Mentions, Message.MentionRoles, and Message.MentionChannels.
+//
+// Message.MentionChannels only contains channels mentioned in crossposted messages,
+// so channel tokens are only reported in CrosspostedChannels when present.
+func (m *Message) CheckMentions() *MentionCheck {
+	check := &MentionCheck{Tokens: ParseContent(m.Content)}
+
+	users := make(map[Snowflake]bool, len(m.Mentions))
+	for _, user := range m.Mentions {
+		if user != nil {
+			users[user.ID] = false
+		}
+	}
+
+	roles := make(map[Snowflake]bool, len(m.MentionRoles))
+	for _, role := range m.MentionRoles {
+		if role != nil {
+			roles[*role] = false
+		}
+	}
+
+	channels := make(map[Snowflake]bool, len(m.MentionChannels))
+	for _, channel := range m.MentionChannels {
+		if channel != nil {
+			channels[channel.ID] = true
+		}
+	}
+
+	for _, token := range check.Tokens {
+		switch token.Type {
+		case FlagContentTokenTypeUSER:
+			if _, ok := users[token.ID]; !ok {
+				check.Unresolved = append(check.Unresolved, token)
+			}
+
+			users[token.ID] = true
+
+		case FlagContentTokenTypeROLE:
+			if _, ok := roles[token.ID]; !ok {
+				check.Unresolved = append(check.Unresolved, token)
+			}
+
+			roles[token.ID] = true
+
+		case FlagContentTokenTypeCHANNEL:
+			if channels[token.ID] {
+				check.CrosspostedChannels = append(check.CrosspostedChannels, token)
+			}
+
+		case FlagContentTokenTypeMENTIONEVERYONE, FlagContentTokenTypeMENTIONHERE:
+			check.Everyone = true
+		}
+	}
+
+	for _, user := range m.Mentions {
+		if user != nil && !users[user.ID] {
+			check.UnreferencedUsers = append(check.UnreferencedUsers, user.ID)
+			users[user.ID] = true
+		}
+	}
+
+	for _, role := range m.MentionRoles {
+		if role != nil && !roles[*role] {
+			check.UnreferencedRoles = append(check.UnreferencedRoles, *role)
+			roles[*role] = true
+		}
+	}
+
+	return check
+}
@@ -0,0 +1,254 @@
+package dasgo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// tokenSummary represents the fields of a token that are compared by tests.
+type tokenSummary struct {
+	Type Flag
+	Raw  string
+}
+
+func summarizeTokens(tokens []*ContentToken) []tokenSummary {
+	summaries := make([]tokenSummary, len(tokens))
+	for i, token := range tokens {
+		summaries[i] = tokenSummary{Type: token.Type, Raw: token.Raw}
+	}
+
+	return summaries
+}
+
+func TestParseContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		tokens  []tokenSummary
+	}{
+		{
+			name:    "empty",
+			content: "",
+			tokens:  []tokenSummary{},
+		},
+		{
+			name:    "text",
+			content: "hello",
+			tokens:  []tokenSummary{{FlagContentTokenTypeTEXT, "hello"}},
+		},
+		{
+			name:    "mentions",
+			content: "<@1><@!2><@&3><#4>",
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeUSER, "<@1>"},
+				{FlagContentTokenTypeUSER, "<@!2>"},
+				{FlagContentTokenTypeROLE, "<@&3>"},
+				{FlagContentTokenTypeCHANNEL, "<#4>"},
+			},
+		},
+		{
+			name:    "everyone and here",
+			content: "@everyone and @here",
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeMENTIONEVERYONE, "@everyone"},
+				{FlagContentTokenTypeTEXT, " and "},
+				{FlagContentTokenTypeMENTIONHERE, "@here"},
+			},
+		},
+		{
+			name:    "emoji",
+			content: "<:mmLol:216154654256398347> <a:b:1>",
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeEMOJI, "<:mmLol:216154654256398347>"},
+				{FlagContentTokenTypeTEXT, " "},
+				{FlagContentTokenTypeEMOJI, "<a:b:1>"},
+			},
+		},
+		{
+			name:    "timestamps",
+			content: "<t:1618953630><t:1618953630:R><t:-1:d><t:1:x>",
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeTIMESTAMP, "<t:1618953630>"},
+				{FlagContentTokenTypeTIMESTAMP, "<t:1618953630:R>"},
+				{FlagContentTokenTypeTIMESTAMP, "<t:-1:d>"},
+				{FlagContentTokenTypeTEXT, "<t:1:x>"},
+			},
+		},
+		{
+			name:    "slash command",
+			content: "</tag get:5>",
+			tokens:  []tokenSummary{{FlagContentTokenTypeSLASH_COMMAND, "</tag get:5>"}},
+		},
+		{
+			name:    "urls",
+			content: "see https://example.com/a. or <https://example.com/b>",
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeTEXT, "see "},
+				{FlagContentTokenTypeURL, "https://example.com/a"},
+				{FlagContentTokenTypeTEXT, ". or "},
+				{FlagContentTokenTypeURL, "<https://example.com/b>"},
+			},
+		},
+		{
+			name:    "overflowing snowflake",
+			content: "<@99999999999999999999>",
+			tokens:  []tokenSummary{{FlagContentTokenTypeTEXT, "<@99999999999999999999>"}},
+		},
+		{
+			name:    "code",
+			content: "<@1> `<@2>` ``a`b`` ```\n<@3>\n``` <@4>",
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeUSER, "<@1>"},
+				{FlagContentTokenTypeTEXT, " "},
+				{FlagContentTokenTypeCODE, "`<@2>`"},
+				{FlagContentTokenTypeTEXT, " "},
+				{FlagContentTokenTypeCODE, "``a`b``"},
+				{FlagContentTokenTypeTEXT, " "},
+				{FlagContentTokenTypeCODE, "```\n<@3>\n```"},
+				{FlagContentTokenTypeTEXT, " "},
+				{FlagContentTokenTypeUSER, "<@4>"},
+			},
+		},
+		{
+			name:    "escaped mentions",
+			content: `\<@1> \\<@2> \@everyone`,
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeTEXT, `\<@1> \\`},
+				{FlagContentTokenTypeUSER, "<@2>"},
+				{FlagContentTokenTypeTEXT, ` \@everyone`},
+			},
+		},
+		{
+			name:    "escaped code",
+			content: "\\`<@1>\\` \\``<@2>`",
+			tokens: []tokenSummary{
+				{FlagContentTokenTypeTEXT, "\\`"},
+				{FlagContentTokenTypeUSER, "<@1>"},
+				{FlagContentTokenTypeTEXT, "\\` \\`"},
+				{FlagContentTokenTypeCODE, "`<@2>`"},
+			},
+		},
+		{
+			name:    "neutralized mentions",
+			content: EscapeMentions("@everyone <@1> <#2>"),
+			tokens:  []tokenSummary{{FlagContentTokenTypeTEXT, "@\u200beveryone <@\u200b1> <#\u200b2>"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens := ParseContent(test.content)
+			if summaries := summarizeTokens(tokens); !reflect.DeepEqual(summaries, test.tokens) {
+				t.Fatalf("expected tokens %q, got %q", test.tokens, summaries)
+			}
+
+			// the tokens cover the content.
+			end := 0
+			for _, token := range tokens {
+				if token.Start != end || test.content[token.Start:token.End] != token.Raw {
+					t.Fatalf("expected token %q to start at %d", token.Raw, end)
+				}
+
+				end = token.End
+			}
+
+			if end != len(test.content) {
+				t.Fatalf("expected the tokens to end at %d, got %d", len(test.content), end)
+			}
+		})
+	}
+}
+
+func TestParseContentValues(t *testing.T) {
+	tokens := ParseContent("<a:blob:1><t:1618953630:R></tag get:5><https://example.com>")
+	if len(tokens) != 4 {
+		t.Fatalf("expected 4 tokens, got %d", len(tokens))
+	}
+
+	if emoji := tokens[0].Emoji(); emoji.ID != 1 || *emoji.Name != "blob" || !*emoji.Animated {
+		t.Errorf("expected animated emoji blob (1), got %+v", emoji)
+	}
+
+	if timestamp := tokens[1]; !timestamp.Timestamp.Equal(time.Unix(1618953630, 0)) || timestamp.Style != FlagTimestampStyleRelativeTime {
+		t.Errorf("expected a relative timestamp, got %v (%q)", timestamp.Timestamp, timestamp.Style)
+	}
+
+	if command := tokens[2]; command.Name != "tag get" || command.ID != 5 {
+		t.Errorf("expected command tag get (5), got %q (%d)", command.Name, command.ID)
+	}
+
+	if url := tokens[3]; url.URL != "https://example.com" || !url.Suppressed {
+		t.Errorf("expected a suppressed URL, got %q (%v)", url.URL, url.Suppressed)
+	}
+}
+
+func TestParseSingleTokens(t *testing.T) {
+	if id, ok := ParseUserMention("<@!80351110224678912>"); !ok || id != 80351110224678912 {
+		t.Errorf("expected user 80351110224678912, got %d (%v)", id, ok)
+	}
+
+	if _, ok := ParseUserMention("<@1> "); ok {
+		t.Error("expected a user mention with trailing text to be rejected")
+	}
+
+	if id, ok := ParseRoleMention("<@&165511591545143296>"); !ok || id != 165511591545143296 {
+		t.Errorf("expected role 165511591545143296, got %d (%v)", id, ok)
+	}
+
+	if _, ok := ParseRoleMention("<@1>"); ok {
+		t.Error("expected a user mention to be rejected as a role mention")
+	}
+
+	if id, ok := ParseChannelMention("<#103735883630395392>"); !ok || id != 103735883630395392 {
+		t.Errorf("expected channel 103735883630395392, got %d (%v)", id, ok)
+	}
+
+	if emoji, ok := ParseCustomEmoji("<:mmLol:216154654256398347>"); !ok || emoji.ID != 216154654256398347 || *emoji.Animated {
+		t.Errorf("expected emoji mmLol, got %+v (%v)", emoji, ok)
+	}
+
+	if timestamp, style, ok := ParseTimestamp("<t:1618953630:d>"); !ok || timestamp.Unix() != 1618953630 || style != "d" {
+		t.Errorf("expected timestamp 1618953630, got %v %q (%v)", timestamp, style, ok)
+	}
+}
+
+func TestCheckMentions(t *testing.T) {
+	message := &Message{
+		Content:         "<@1> <@2> <@&10> <@&11> <#20> <#21> `<@3>` @here",
+		Mentions:        []*User{{ID: 1}, {ID: 4}, nil},
+		MentionRoles:    []*Snowflake{func() *Snowflake { id := Snowflake(10); return &id }(), func() *Snowflake { id := Snowflake(12); return &id }()},
+		MentionChannels: []*ChannelMention{{ID: 21}},
+	}
+
+	check := message.CheckMentions()
+
+	if unresolved := summarizeTokens(check.Unresolved); !reflect.DeepEqual(unresolved, []tokenSummary{
+		{FlagContentTokenTypeUSER, "<@2>"},
+		{FlagContentTokenTypeROLE, "<@&11>"},
+	}) {
+		t.Errorf("expected <@2> and <@&11> to be unresolved, got %q", unresolved)
+	}
+
+	if !reflect.DeepEqual(check.UnreferencedUsers, []Snowflake{4}) {
+		t.Errorf("expected user 4 to be unreferenced, got %v", check.UnreferencedUsers)
+	}
+
+	if !reflect.DeepEqual(check.UnreferencedRoles, []Snowflake{12}) {
+		t.Errorf("expected role 12 to be unreferenced, got %v", check.UnreferencedRoles)
+	}
+
+	if crossposted := summarizeTokens(check.CrosspostedChannels); !reflect.DeepEqual(crossposted, []tokenSummary{
+		{FlagContentTokenTypeCHANNEL, "<#21>"},
+	}) {
+		t.Errorf("expected <#21> to be crossposted, got %q", crossposted)
+	}
+
+	if !check.Everyone {
+		t.Error("expected @here to be reported")
+	}
+
+	if check := (&Message{Content: `\@everyone`}).CheckMentions(); check.Everyone {
+		t.Error("expected an escaped @everyone to be ignored")
+	}
+}
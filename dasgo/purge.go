@@ -0,0 +1,235 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Bulk Delete Messages Limits
+// https://discord.com/developers/docs/resources/channel#bulk-delete-messages
+const (
+	FlagBulkDeleteLimitMessagesMIN = 2
+	FlagBulkDeleteLimitMessagesMAX = 100
+
+	// FlagBulkDeleteLimitAge represents the maximum age of a message that is bulk deleted.
+	FlagBulkDeleteLimitAge = 14 * 24 * time.Hour
+)
+
+const (
+	// bulkDeleteMargin represents the duration before a message exceeds the Bulk Delete
+	// age limit that it's deleted individually, so that a plan remains valid while it's executed.
+	bulkDeleteMargin = 10 * time.Minute
+
+	// defaultSingleDeleteInterval represents the default duration between Delete Message requests.
+	defaultSingleDeleteInterval = time.Second
+)
+
+// MessageDeletionClient represents a client that sends message deletion requests.
+type MessageDeletionClient interface {
+	BulkDeleteMessages(ctx context.Context, request *BulkDeleteMessages) error
+	DeleteMessage(ctx context.Context, request *DeleteMessage) error
+}
+
+// DeletePlan represents the requests that delete a set of messages in a channel.
+//
+// Messages that are younger than the Bulk Delete age limit are deleted in batches with
+// Bulk Delete Messages, while older messages are deleted one by one with Delete Message.
+type DeletePlan struct {
+	ChannelID Snowflake
+	Bulk      []*BulkDeleteMessages
+	Single    []*DeleteMessage
+
+	// SingleDeleteInterval represents the minimum duration between Delete Message requests,
+	// which are subject to a stricter rate limit than Bulk Delete Messages requests.
+	SingleDeleteInterval time.Duration
+}
+
+// DeleteProgress represents the progress of an executed DeletePlan.
+type DeleteProgress struct {
+	// Deleted represents the number of messages that are deleted.
+	Deleted int
+
+	// Missing represents the number of messages that were already deleted.
+	Missing int
+
+	// Total represents the number of messages in the plan.
+	Total int
+}
+
+// PlanMessageDeletion returns the plan that deletes messages in a channel at a time (i.e time.Now()).
+//
+// Message IDs are deduplicated, and messages are deleted from newest to oldest.
+func PlanMessageDeletion(channelID Snowflake, messageIDs []Snowflake, now time.Time) *DeletePlan {
+	ids := make([]Snowflake, 0, len(messageIDs))
+	seen := make(map[Snowflake]bool, len(messageIDs))
+	for _, id := range messageIDs {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] > ids[j] })
+
+	plan := &DeletePlan{ChannelID: channelID, SingleDeleteInterval: defaultSingleDeleteInterval}
+
+	// messages created before the cutoff are deleted individually.
+	cutoff := SnowflakeFromTime(now.Add(-FlagBulkDeleteLimitAge + bulkDeleteMargin))
+	bulk := sort.Search(len(ids), func(i int) bool { return ids[i] < cutoff })
+
+	var batches [][]Snowflake
+	for start := 0; start < bulk; start += FlagBulkDeleteLimitMessagesMAX {
+		end := start + FlagBulkDeleteLimitMessagesMAX
+		if end > bulk {
+			end = bulk
+		}
+
+		batches = append(batches, ids[start:end])
+	}
+
+	// a final batch with a single message borrows a message from the previous batch,
+	// since a Bulk Delete Messages request contains at least two messages.
+	if n := len(batches); n > 1 && len(batches[n-1]) < FlagBulkDeleteLimitMessagesMIN {
+		last := bulk - FlagBulkDeleteLimitMessagesMIN
+		batches[n-2], batches[n-1] = ids[(n-2)*FlagBulkDeleteLimitMessagesMAX:last], ids[last:bulk]
+	}
+
+	for _, batch := range batches {
+		if len(batch) < FlagBulkDeleteLimitMessagesMIN {
+			plan.Single = append(plan.Single, &DeleteMessage{ChannelID: channelID, MessageID: batch[0]})
+			continue
+		}
+
+		request := &BulkDeleteMessages{ChannelID: channelID, Messages: make([]*Snowflake, len(batch))}
+		for i := range batch {
+			request.Messages[i] = &batch[i]
+		}
+
+		plan.Bulk = append(plan.Bulk, request)
+	}
+
+	for i := bulk; i < len(ids); i++ {
+		plan.Single = append(plan.Single, &DeleteMessage{ChannelID: channelID, MessageID: ids[i]})
+	}
+
+	return plan
+}
+
+// PlanMessageDeletionRange returns the plan that deletes the messages in a channel that are created
+// in a time range [after, before), and that match a filter (when non-nil).
+//
+// The messages of the channel are fetched with Get Channel Messages.
+func PlanMessageDeletionRange(ctx context.Context, channelID Snowflake, after, before time.Time,
+	filter func(*Message) bool, fetch Fetch[GetChannelMessages, []*Message]) (*DeletePlan, error) {
+	now := time.Now()
+	if before.IsZero() || before.After(now) {
+		before = now
+	}
+
+	cursor := SnowflakeFromTime(before)
+	oldest := SnowflakeFromTime(after)
+
+	messages := PaginateChannelMessages(&GetChannelMessages{ChannelID: channelID, Before: &cursor}, fetch).
+		Until(func(message *Message) bool { return message.ID < oldest })
+
+	var ids []Snowflake
+	for messages.Next(ctx) {
+		if message := messages.Value(); filter == nil || filter(message) {
+			ids = append(ids, message.ID)
+		}
+	}
+
+	if err := messages.Err(); err != nil {
+		return nil, fmt.Errorf("error fetching messages of channel %d: %w", channelID, err)
+	}
+
+	return PlanMessageDeletion(channelID, ids, now), nil
+}
+
+// Total returns the number of messages that are deleted by the plan.
+func (p *DeletePlan) Total() int {
+	total := len(p.Single)
+	for _, request := range p.Bulk {
+		total += len(request.Messages)
+	}
+
+	return total
+}
+
+// Execute executes the plan with a client, and reports its progress after each request (when progress is non-nil).
+//
+// Messages that are already deleted are counted as missing, and a batch that is rejected because
+// its messages are too old is deleted individually. Execution stops at the first other error,
+// or when the context is canceled.
+func (p *DeletePlan) Execute(ctx context.Context, client MessageDeletionClient, progress func(DeleteProgress)) (DeleteProgress, error) {
+	status := DeleteProgress{Total: p.Total()}
+	report := func() {
+		if progress != nil {
+			progress(status)
+		}
+	}
+
+	single := p.Single
+	for _, request := range p.Bulk {
+		if err := ctx.Err(); err != nil {
+			return status, err
+		}
+
+		err := client.BulkDeleteMessages(ctx, request)
+		if isErrorCode(err, FlagJSONErrorCodeMessageTooOldToBulkDelete) {
+			for _, id := range request.Messages {
+				single = append(single, &DeleteMessage{ChannelID: request.ChannelID, MessageID: *id})
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return status, fmt.Errorf("error bulk deleting messages in channel %d: %w", request.ChannelID, err)
+		}
+
+		status.Deleted += len(request.Messages)
+		report()
+	}
+
+	for i, request := range single {
+		if i != 0 && p.SingleDeleteInterval > 0 {
+			timer := time.NewTimer(p.SingleDeleteInterval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return status, ctx.Err()
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return status, err
+		}
+
+		err := client.DeleteMessage(ctx, request)
+		switch {
+		case isErrorCode(err, FlagJSONErrorCodeUnknownMessage):
+			status.Missing++
+		case err != nil:
+			return status, fmt.Errorf("error deleting message %d in channel %d: %w", request.MessageID, request.ChannelID, err)
+		default:
+			status.Deleted++
+		}
+
+		report()
+	}
+
+	return status, nil
+}
+
+// isErrorCode returns whether an error is an ErrorResponse with a JSON Error Code.
+func isErrorCode(err error, code int) bool {
+	var errorResponse *ErrorResponse
+
+	return errors.As(err, &errorResponse) && errorResponse.Code == code
+}
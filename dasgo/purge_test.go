@@ -0,0 +1,227 @@
+package dasgo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recentIDs returns n unique message IDs that are created an hour before a time.
+func recentIDs(now time.Time, n int) []Snowflake {
+	ids := make([]Snowflake, n)
+	for i := range ids {
+		ids[i] = SnowflakeFromTime(now.Add(-time.Hour)) + Snowflake(i)
+	}
+
+	return ids
+}
+
+// batchSizes returns the number of messages in each Bulk Delete Messages request of a plan.
+func batchSizes(plan *DeletePlan) []int {
+	var sizes []int
+	for _, request := range plan.Bulk {
+		sizes = append(sizes, len(request.Messages))
+	}
+
+	return sizes
+}
+
+func TestPlanMessageDeletion(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		n      int
+		bulk   []int
+		single int
+	}{
+		{name: "1", n: 1, single: 1},
+		{name: "2", n: 2, bulk: []int{2}},
+		{name: "100", n: 100, bulk: []int{100}},
+		{name: "101", n: 101, bulk: []int{99, 2}},
+		{name: "200", n: 200, bulk: []int{100, 100}},
+		{name: "201", n: 201, bulk: []int{100, 99, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ids := recentIDs(now, test.n)
+			plan := PlanMessageDeletion(1, ids, now)
+
+			if sizes := batchSizes(plan); !reflect.DeepEqual(sizes, test.bulk) || len(plan.Single) != test.single {
+				t.Fatalf("expected batches %v and %d single deletes, got %v and %d", test.bulk, test.single, sizes, len(plan.Single))
+			}
+
+			if plan.Total() != test.n {
+				t.Fatalf("expected a total of %d, got %d", test.n, plan.Total())
+			}
+
+			// messages are deleted from newest to oldest, and every message is deleted once.
+			var deleted []Snowflake
+			for _, request := range plan.Bulk {
+				for _, id := range request.Messages {
+					deleted = append(deleted, *id)
+				}
+			}
+
+			for _, request := range plan.Single {
+				deleted = append(deleted, request.MessageID)
+			}
+
+			for i := range deleted {
+				if i != 0 && deleted[i] >= deleted[i-1] {
+					t.Fatalf("expected messages in descending order, got %d after %d", deleted[i], deleted[i-1])
+				}
+			}
+
+			if len(deleted) != test.n {
+				t.Fatalf("expected %d deleted messages, got %d", test.n, len(deleted))
+			}
+		})
+	}
+}
+
+func TestPlanMessageDeletionCutoff(t *testing.T) {
+	now := time.Now()
+	cutoff := now.Add(-FlagBulkDeleteLimitAge)
+
+	young := SnowflakeFromTime(cutoff.Add(bulkDeleteMargin + time.Minute))
+	margin := SnowflakeFromTime(cutoff.Add(bulkDeleteMargin - time.Minute))
+	old := SnowflakeFromTime(cutoff.Add(-time.Hour))
+
+	plan := PlanMessageDeletion(1, []Snowflake{old, young, margin, young + 1}, now)
+	if len(plan.Bulk) != 1 || *plan.Bulk[0].Messages[0] != young+1 || *plan.Bulk[0].Messages[1] != young {
+		t.Fatalf("expected the young messages to be bulk deleted, got %v", batchSizes(plan))
+	}
+
+	// messages within the margin of the age limit are deleted individually.
+	if len(plan.Single) != 2 || plan.Single[0].MessageID != margin || plan.Single[1].MessageID != old {
+		t.Fatalf("expected the old messages to be deleted individually, got %d single deletes", len(plan.Single))
+	}
+}
+
+func TestPlanMessageDeletionDedup(t *testing.T) {
+	now := time.Now()
+	ids := recentIDs(now, 3)
+
+	plan := PlanMessageDeletion(1, []Snowflake{ids[0], ids[1], 0, ids[0], ids[2], ids[1]}, now)
+	if plan.Total() != 3 || len(plan.Bulk) != 1 {
+		t.Fatalf("expected 3 messages in a single batch, got %d in %v", plan.Total(), batchSizes(plan))
+	}
+
+	if plan := PlanMessageDeletion(1, nil, now); plan.Total() != 0 || len(plan.Bulk) != 0 || len(plan.Single) != 0 {
+		t.Fatalf("expected an empty plan, got %d messages", plan.Total())
+	}
+}
+
+// deletionClient represents a MessageDeletionClient that records its requests, and returns
+// an error for the requests with a message that has an error.
+type deletionClient struct {
+	mu       sync.Mutex
+	bulk     [][]Snowflake
+	single   []Snowflake
+	bulkErrs map[Snowflake]error
+	errs     map[Snowflake]error
+}
+
+// BulkDeleteMessages records a Bulk Delete Messages request.
+func (c *deletionClient) BulkDeleteMessages(ctx context.Context, request *BulkDeleteMessages) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := make([]Snowflake, len(request.Messages))
+	for i, id := range request.Messages {
+		ids[i] = *id
+	}
+
+	c.bulk = append(c.bulk, ids)
+	for _, id := range ids {
+		if err := c.bulkErrs[id]; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteMessage records a Delete Message request.
+func (c *deletionClient) DeleteMessage(ctx context.Context, request *DeleteMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.single = append(c.single, request.MessageID)
+
+	return c.errs[request.MessageID]
+}
+
+func TestDeletePlanExecute(t *testing.T) {
+	now := time.Now()
+	ids := recentIDs(now, 103)
+	old := SnowflakeFromTime(now.Add(-FlagBulkDeleteLimitAge - time.Hour))
+
+	plan := PlanMessageDeletion(1, append(ids, old), now)
+	plan.SingleDeleteInterval = 0
+
+	// the second batch is rejected because its messages are too old, and a message is already deleted.
+	client := &deletionClient{
+		bulkErrs: map[Snowflake]error{ids[0]: &ErrorResponse{Code: FlagJSONErrorCodeMessageTooOldToBulkDelete}},
+		errs:     map[Snowflake]error{old: &ErrorResponse{Code: FlagJSONErrorCodeUnknownMessage}},
+	}
+
+	var reports []DeleteProgress
+	status, err := plan.Execute(context.Background(), client, func(progress DeleteProgress) {
+		reports = append(reports, progress)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (DeleteProgress{Deleted: 103, Missing: 1, Total: 104}); status != want {
+		t.Fatalf("expected %+v, got %+v", want, status)
+	}
+
+	// the rejected batch is deleted individually, after the planned single deletes.
+	if len(client.bulk) != 2 || len(client.single) != 1+len(client.bulk[1]) || client.single[0] != old {
+		t.Fatalf("expected the rejected batch to be deleted individually, got %d bulk and %d single deletes",
+			len(client.bulk), len(client.single))
+	}
+
+	if len(reports) != 1+len(client.single) || reports[len(reports)-1] != status {
+		t.Fatalf("expected a report after each successful request, got %d", len(reports))
+	}
+}
+
+func TestDeletePlanExecuteError(t *testing.T) {
+	now := time.Now()
+	ids := recentIDs(now, 1)
+
+	// an error other than an unknown message stops the execution.
+	plan := PlanMessageDeletion(1, ids, now)
+	client := &deletionClient{errs: map[Snowflake]error{ids[0]: &ErrorResponse{Code: 50013}}}
+
+	status, err := plan.Execute(context.Background(), client, nil)
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.Code != 50013 || status.Deleted != 0 {
+		t.Fatalf("expected a missing permissions error, got %+v (%v)", status, err)
+	}
+
+	// the single delete interval is interrupted when the context is canceled.
+	plan = PlanMessageDeletion(1, []Snowflake{
+		SnowflakeFromTime(now.Add(-FlagBulkDeleteLimitAge - time.Hour)),
+		SnowflakeFromTime(now.Add(-FlagBulkDeleteLimitAge - 2*time.Hour)),
+	}, now)
+	plan.SingleDeleteInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	client = new(deletionClient)
+	status, err = plan.Execute(ctx, client, nil)
+	if !errors.Is(err, context.DeadlineExceeded) || status.Deleted != 1 || len(client.single) != 1 {
+		t.Fatalf("expected context.DeadlineExceeded after 1 delete, got %+v (%v)", status, err)
+	}
+}
@@ -0,0 +1,234 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Recording Directions
+const (
+	FlagRecordingDirectionINBOUND  = "in"
+	FlagRecordingDirectionOUTBOUND = "out"
+)
+
+// redactedToken represents the value of a redacted token.
+const redactedToken = "[REDACTED]"
+
+// maxRecordingLineSize represents the maximum size of a line in a recording.
+const maxRecordingLineSize = 16 << 20
+
+// RecordedPayload represents a Gateway Payload in a recording, which is stored as a line of JSON.
+type RecordedPayload struct {
+	Time time.Time `json:"time"`
+
+	// Direction represents the Recording Direction of the payload (in or out).
+	Direction string `json:"dir"`
+
+	*GatewayPayload
+}
+
+// Recorder represents a recorder that writes the payloads of a Gateway session to a JSONL log.
+type Recorder struct {
+	// Redact determines whether the values of "token" fields are redacted from payloads
+	// (i.e Identify, Resume, and Interaction Create).
+	Redact bool
+
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewRecorder returns a new Recorder that writes to w.
+func NewRecorder(w io.Writer, redact bool) *Recorder {
+	return &Recorder{Redact: redact, encoder: json.NewEncoder(w)}
+}
+
+// Record records a payload received from (in) or sent to (out) the Gateway.
+func (r *Recorder) Record(direction string, payload *GatewayPayload) error {
+	recorded := &RecordedPayload{
+		Time:      time.Now().UTC(),
+		Direction: direction,
+		GatewayPayload: &GatewayPayload{
+			Op:             payload.Op,
+			Data:           payload.Data,
+			SequenceNumber: payload.SequenceNumber,
+			EventName:      payload.EventName,
+		},
+	}
+
+	if len(recorded.Data) != 0 {
+		data := new(bytes.Buffer)
+		if err := json.Compact(data, recorded.Data); err != nil {
+			return fmt.Errorf("error recording payload: %w", err)
+		}
+
+		recorded.Data = data.Bytes()
+	}
+
+	if r.Redact {
+		data, err := redact(recorded.Data)
+		if err != nil {
+			return fmt.Errorf("error recording payload: %w", err)
+		}
+
+		recorded.Data = data
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.encoder.Encode(recorded); err != nil {
+		return fmt.Errorf("error recording payload: %w", err)
+	}
+
+	return nil
+}
+
+// RecordMessage records a message received from (in) or sent to (out) the Gateway.
+func (r *Recorder) RecordMessage(direction string, message []byte) error {
+	payload := new(GatewayPayload)
+	if err := json.Unmarshal(message, payload); err != nil {
+		return fmt.Errorf("error recording message: %w", err)
+	}
+
+	return r.Record(direction, payload)
+}
+
+// redact redacts the values of "token" fields from JSON.
+func redact(data json.RawMessage) (json.RawMessage, error) {
+	if !bytes.Contains(data, []byte(`"token"`)) {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("error redacting payload: %w", err)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil, fmt.Errorf("error redacting payload: %w", err)
+	}
+
+	return redacted, nil
+}
+
+// redactValue redacts the values of "token" fields from a decoded JSON value.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if key == "token" {
+				if _, ok := field.(string); ok {
+					v[key] = redactedToken
+					continue
+				}
+			}
+
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+	}
+
+	return value
+}
+
+// ReadRecording reads the payloads of a recording.
+func ReadRecording(r io.Reader) ([]*RecordedPayload, error) {
+	var payloads []*RecordedPayload
+	err := readRecording(r, func(payload *RecordedPayload) error {
+		payloads = append(payloads, payload)
+		return nil
+	})
+
+	return payloads, err
+}
+
+// readRecording calls fn with each payload of a recording.
+func readRecording(r io.Reader, fn func(*RecordedPayload) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordingLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		payload := &RecordedPayload{GatewayPayload: new(GatewayPayload)}
+		if err := json.Unmarshal(scanner.Bytes(), payload); err != nil {
+			return fmt.Errorf("error reading recording line %d: %w", line, err)
+		}
+
+		if err := fn(payload); err != nil {
+			return fmt.Errorf("error replaying recording line %d: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading recording: %w", err)
+	}
+
+	return nil
+}
+
+// Replay replays the inbound dispatch payloads of a recording to a Dispatcher.
+//
+// Payloads are decoded with DecodeEvent and replayed at speed times the recorded rate,
+// or without delay when speed is zero. Events without an event struct are skipped.
+// Replay returns once every event is dispatched: Use Dispatcher.Wait to wait until
+// the events are handled.
+func Replay(ctx context.Context, r io.Reader, d *Dispatcher, speed float64) error {
+	var start, first time.Time
+	return readRecording(r, func(payload *RecordedPayload) error {
+		if payload.Direction != FlagRecordingDirectionINBOUND ||
+			payload.Op == nil || *payload.Op != FlagGatewayOpcodeDispatch {
+			return nil
+		}
+
+		if speed > 0 {
+			if first.IsZero() {
+				start, first = time.Now(), payload.Time
+			}
+
+			delay := time.Until(start.Add(time.Duration(float64(payload.Time.Sub(first)) / speed)))
+			if delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				}
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if NewEvent(payload.EventName) == nil {
+			return nil
+		}
+
+		event, err := DecodeEvent(payload.EventName, payload.Data)
+		if err != nil {
+			return err
+		}
+
+		d.Dispatch(event)
+
+		return nil
+	})
+}
@@ -1,12 +1,70 @@
 // Package dasgo provides Type Definitions for the Discord API.
 package dasgo
 
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
 // Version
 // https://discord.com/developers/docs/reference#api-versioning
 const (
 	VersionDiscordAPI = "10"
 )
 
+// Snowflakes
+// https://discord.com/developers/docs/reference#snowflakes
+const (
+	// Discord Epoch (the first second of 2015) in milliseconds.
+	FlagSnowflakeEpoch = 1420070400000
+
+	FlagSnowflakeTimestampShift = 22
+)
+
+// MarshalJSON encodes a snowflake as a string, which is how the Discord API represents
+// snowflakes that exceed the integer precision of some languages.
+func (s Snowflake) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, strconv.FormatUint(uint64(s), 10)), nil
+}
+
+// UnmarshalJSON decodes a snowflake from a string or number.
+func (s *Snowflake) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("error decoding snowflake %s: %w", data, err)
+	}
+
+	*s = Snowflake(id)
+
+	return nil
+}
+
+// Time returns the time the snowflake was created.
+func (s Snowflake) Time() time.Time {
+	return time.UnixMilli(int64(s>>FlagSnowflakeTimestampShift) + FlagSnowflakeEpoch)
+}
+
+// SnowflakeFromTime returns the lowest snowflake created at a time, which is
+// used to paginate by time (i.e before, after, and around).
+func SnowflakeFromTime(t time.Time) Snowflake {
+	ms := t.UnixMilli() - FlagSnowflakeEpoch
+	if ms < 0 {
+		return 0
+	}
+
+	return Snowflake(ms) << FlagSnowflakeTimestampShift
+}
+
 // Locales
 // https://discord.com/developers/docs/reference#locales
 const (
@@ -2,6 +2,7 @@
 package dasgo
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -19,12 +20,12 @@ type GetGlobalApplicationCommands struct {
 type CreateGlobalApplicationCommand struct {
 	ApplicationID            Snowflake
 	Name                     string                      `json:"name,omitempty"`
-	NameLocalizations        map[Flag]string             `json:"name_localizations,omitempty"`
+	NameLocalizations        map[string]string           `json:"name_localizations,omitempty"`
 	Description              string                      `json:"description,omitempty"`
-	DescriptionLocalizations map[Flag]string             `json:"description_localizations,omitempty"`
+	DescriptionLocalizations map[string]string           `json:"description_localizations,omitempty"`
 	Options                  []*ApplicationCommandOption `json:"options,omitempty"`
-	DefaultMemberPermissions string                      `json:"default_member_permissions,omitempty"`
-	DMPermission             bool                        `json:"dm_permission,omitempty"`
+	DefaultMemberPermissions *string                     `json:"default_member_permissions,omitempty"`
+	DMPermission             *bool                       `json:"dm_permission,omitempty"`
 	Type                     Flag                        `json:"type,omitempty"`
 }
 
@@ -42,13 +43,13 @@ type GetGlobalApplicationCommand struct {
 type EditGlobalApplicationCommand struct {
 	ApplicationID            Snowflake
 	CommandID                Snowflake
-	Name                     string                      `json:"name,omitempty"`
-	NameLocalizations        map[string]string             `json:"name_localizations"`
-	Description              string                      `json:"description,omitempty"`
-	DescriptionLocalizations map[string]string             `json:"description_localizations"`
-	Options                  []*ApplicationCommandOption `json:"options,omitempty"`
-	DefaultMemberPermissions *string                      `json:"default_member_permissions,omitempty"`
-	DMPermission             *bool                        `json:"dm_permission,omitempty"`
+	Name                     Optional[string]                      `json:"name,omitempty"`
+	NameLocalizations        Nullable[map[string]string]           `json:"name_localizations,omitempty"`
+	Description              Optional[string]                      `json:"description,omitempty"`
+	DescriptionLocalizations Nullable[map[string]string]           `json:"description_localizations,omitempty"`
+	Options                  Optional[[]*ApplicationCommandOption] `json:"options,omitempty"`
+	DefaultMemberPermissions Nullable[string]                      `json:"default_member_permissions,omitempty"`
+	DMPermission             Nullable[bool]                        `json:"dm_permission,omitempty"`
 }
 
 // Delete Global Application Command
@@ -64,7 +65,12 @@ type DeleteGlobalApplicationCommand struct {
 // https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-global-application-commands
 type BulkOverwriteGlobalApplicationCommands struct {
 	ApplicationID       Snowflake
-	ApplicationCommands []*ApplicationCommand `json:"commands,omitempty"`
+	ApplicationCommands []*ApplicationCommand
+}
+
+// MarshalJSON encodes the request's commands as a JSON array.
+func (r BulkOverwriteGlobalApplicationCommands) MarshalJSON() ([]byte, error) {
+	return marshalBulkOverwriteCommands(r.ApplicationCommands)
 }
 
 // Get Guild Application Commands
@@ -83,7 +89,7 @@ type CreateGuildApplicationCommand struct {
 	ApplicationID            Snowflake
 	GuildID                  Snowflake
 	Name                     string                      `json:"name"`
-	NameLocalizations        map[string]string             `json:"name_localization"`
+	NameLocalizations        map[string]string             `json:"name_localizations"`
 	Description              string                      `json:"description"`
 	DescriptionLocalizations map[string]string             `json:"description_localizations"`
 	Options                  []*ApplicationCommandOption `json:"options,omitempty"`
@@ -108,13 +114,13 @@ type EditGuildApplicationCommand struct {
 	ApplicationID            Snowflake
 	GuildID                  Snowflake
 	CommandID                Snowflake
-	Name                     string                      `json:"name,omitempty"`
-	NameLocalizations        map[string]string             `json:"name_localizations"`
-	Description              string                      `json:"description,omitempty"`
-	DescriptionLocalizations map[string]string             `json:"description_localizations"`
-	Options                  []*ApplicationCommandOption `json:"options,omitempty"`
-	DefaultMemberPermissions *string                      `json:"default_member_permissions,omitempty"`
-	DMPermission             *bool                        `json:"dm_permission,omitempty"`
+	Name                     Optional[string]                      `json:"name,omitempty"`
+	NameLocalizations        Nullable[map[string]string]           `json:"name_localizations,omitempty"`
+	Description              Optional[string]                      `json:"description,omitempty"`
+	DescriptionLocalizations Nullable[map[string]string]           `json:"description_localizations,omitempty"`
+	Options                  Optional[[]*ApplicationCommandOption] `json:"options,omitempty"`
+	DefaultMemberPermissions Nullable[string]                      `json:"default_member_permissions,omitempty"`
+	DMPermission             Nullable[bool]                        `json:"dm_permission,omitempty"`
 }
 
 // Delete Guild Application Command
@@ -130,16 +136,33 @@ type DeleteGuildApplicationCommand struct {
 // PUT /applications/{application.id}/guilds/{guild.id}/commands
 // https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-guild-application-commands
 type BulkOverwriteGuildApplicationCommands struct {
-	ApplicationID            Snowflake
-	GuildID                  Snowflake
-	Name                     string                      `json:"name"`
-	NameLocalizations        map[string]string             `json:"name_localizations"`
-	Description              string                      `json:"description"`
-	DescriptionLocalizations map[string]string             `json:"description_localizations"`
-	Options                  []*ApplicationCommandOption `json:"options,omitempty"`
-	DefaultMemberPermissions *string                      `json:"default_member_permissions,omitempty"`
-	DMPermission             *bool                        `json:"dm_permission,omitempty"`
-	Type                     *Flag                        `json:"type,omitempty"`
+	ApplicationID       Snowflake
+	GuildID             Snowflake
+	ApplicationCommands []*ApplicationCommand
+}
+
+// MarshalJSON encodes the request's commands as a JSON array.
+func (r BulkOverwriteGuildApplicationCommands) MarshalJSON() ([]byte, error) {
+	return marshalBulkOverwriteCommands(r.ApplicationCommands)
+}
+
+// marshalBulkOverwriteCommands encodes the commands of a Bulk Overwrite request,
+// which omits the ID of a command that isn't registered yet.
+func marshalBulkOverwriteCommands(commands []*ApplicationCommand) ([]byte, error) {
+	type applicationCommand ApplicationCommand
+	type bulkOverwriteCommand struct {
+		ID Snowflake `json:"id,omitempty"`
+		*applicationCommand
+	}
+
+	overwrites := make([]*bulkOverwriteCommand, len(commands))
+	for i, command := range commands {
+		if command != nil {
+			overwrites[i] = &bulkOverwriteCommand{ID: command.ID, applicationCommand: (*applicationCommand)(command)}
+		}
+	}
+
+	return json.Marshal(overwrites)
 }
 
 // Get Guild Application Command Permissions
@@ -249,10 +272,11 @@ type DeleteFollowupMessage struct {
 // https://discord.com/developers/docs/resources/audit-log#get-guild-audit-log
 type GetGuildAuditLog struct {
 	GuildID    Snowflake
-	UserID     Snowflake `url:"user_id"`
-	ActionType Flag      `url:"action_type"`
-	Before     Snowflake `url:"before"`
-	Limit      Flag      `url:"limit"`
+	UserID     Snowflake `url:"user_id,omitempty"`
+	ActionType Flag      `url:"action_type,omitempty"`
+	Before     Snowflake `url:"before,omitempty"`
+	After      Snowflake `url:"after,omitempty"`
+	Limit      Flag      `url:"limit,omitempty"`
 }
 
 // Get Channel
@@ -274,8 +298,8 @@ type ModifyChannel struct {
 // https://discord.com/developers/docs/resources/channel#modify-channel-json-params-group-dm
 type ModifyChannelGroupDM struct {
 	ChannelID Snowflake
-	Name      string `json:"name"`
-	Icon      int    `json:"icon"`
+	Name      Optional[string] `json:"name,omitempty"`
+	Icon      Optional[string] `json:"icon,omitempty"`
 }
 
 // Modify Channel Guild
@@ -283,19 +307,19 @@ type ModifyChannelGroupDM struct {
 // https://discord.com/developers/docs/resources/channel#modify-channel-json-params-guild-channel
 type ModifyChannelGuild struct {
 	ChannelID                  Snowflake
-	Name                       *string                `json:"name"`
-	Type                       *Flag                  `json:"type"`
-	Position                   *uint                  `json:"position"`
-	Topic                      *string                `json:"topic"`
-	NSFW                       bool                   `json:"nsfw"`
-	RateLimitPerUser           *CodeFlag              `json:"rate_limit_per_user"`
-	Bitrate                    *int                   `json:"bitrate"`
-	UserLimit                  *int                   `json:"user_limit"`
-	PermissionOverwrites       *[]PermissionOverwrite `json:"permission_overwrites"`
-	ParentID                   *Snowflake             `json:"parent_id"`
-	RTCRegion                  *string                `json:"rtc_region"`
-	VideoQualityMode           Flag                   `json:"video_quality_mode"`
-	DefaultAutoArchiveDuration int                    `json:"default_auto_archive_duration"`
+	Name                       Optional[string]                `json:"name,omitempty"`
+	Type                       Optional[Flag]                  `json:"type,omitempty"`
+	Position                   Nullable[uint]                  `json:"position,omitempty"`
+	Topic                      Nullable[string]                `json:"topic,omitempty"`
+	NSFW                       Nullable[bool]                  `json:"nsfw,omitempty"`
+	RateLimitPerUser           Nullable[CodeFlag]              `json:"rate_limit_per_user,omitempty"`
+	Bitrate                    Nullable[int]                   `json:"bitrate,omitempty"`
+	UserLimit                  Nullable[int]                   `json:"user_limit,omitempty"`
+	PermissionOverwrites       Nullable[[]PermissionOverwrite] `json:"permission_overwrites,omitempty"`
+	ParentID                   Nullable[Snowflake]             `json:"parent_id,omitempty"`
+	RTCRegion                  Nullable[string]                `json:"rtc_region,omitempty"`
+	VideoQualityMode           Nullable[Flag]                  `json:"video_quality_mode,omitempty"`
+	DefaultAutoArchiveDuration Nullable[int]                   `json:"default_auto_archive_duration,omitempty"`
 }
 
 // Modify Channel
@@ -303,12 +327,12 @@ type ModifyChannelGuild struct {
 // https://discord.com/developers/docs/resources/channel#modify-channel-json-params-thread
 type ModifyChannelThread struct {
 	ChannelID           Snowflake
-	Name                string    `json:"name"`
-	Archived            bool      `json:"archived"`
-	AutoArchiveDuration CodeFlag  `json:"auto_archive_duration"`
-	Locked              bool      `json:"locked"`
-	Invitable           bool      `json:"invitable"`
-	RateLimitPerUser    *CodeFlag `json:"rate_limit_per_user"`
+	Name                Optional[string]   `json:"name,omitempty"`
+	Archived            Optional[bool]     `json:"archived,omitempty"`
+	AutoArchiveDuration Optional[CodeFlag] `json:"auto_archive_duration,omitempty"`
+	Locked              Optional[bool]     `json:"locked,omitempty"`
+	Invitable           Optional[bool]     `json:"invitable,omitempty"`
+	RateLimitPerUser    Nullable[CodeFlag] `json:"rate_limit_per_user,omitempty"`
 }
 
 // Delete/Close Channel
@@ -349,7 +373,7 @@ type CreateMessage struct {
 	AllowedMentions *AllowedMentions  `json:"allowed_mentions,omitempty"`
 	Reference       *MessageReference `json:"message_reference,omitempty"`
 	StickerID       []*Snowflake      `json:"sticker_ids,omitempty"`
-	Components      []*Component      `json:"components,omitempty"`
+	Components      Components        `json:"components,omitempty"`
 	Files           []byte            `dasgo:"files,omitempty"`
 	PayloadJSON     *string           `json:"payload_json,omitempty"`
 	Attachments     []*Attachment     `json:"attachments,omitempty"`
@@ -426,14 +450,14 @@ type DeleteAllReactionsforEmoji struct {
 type EditMessage struct {
 	ChannelID       Snowflake
 	MessageID       Snowflake
-	Content         *string          `json:"content"`
-	Embeds          []*Embed         `json:"embeds"`
-	Flags           *BitFlag         `json:"flags"`
-	AllowedMentions *AllowedMentions `json:"allowed_mentions"`
-	Components      []*Component     `json:"components"`
-	Files           []byte           `dasgo:"files"`
-	PayloadJSON     *string          `json:"payload_json"`
-	Attachments     []*Attachment    `json:"attachments"`
+	Content         Nullable[string]          `json:"content,omitempty"`
+	Embeds          Nullable[[]*Embed]        `json:"embeds,omitempty"`
+	Flags           Nullable[BitFlag]         `json:"flags,omitempty"`
+	AllowedMentions Nullable[AllowedMentions] `json:"allowed_mentions,omitempty"`
+	Components      Nullable[Components]      `json:"components,omitempty"`
+	Files           []byte                    `dasgo:"files"`
+	PayloadJSON     Optional[string]          `json:"payload_json,omitempty"`
+	Attachments     Nullable[[]*Attachment]   `json:"attachments,omitempty"`
 }
 
 // Delete Message
@@ -588,7 +612,7 @@ type ForumThreadMessageParams struct {
 	Content         *string          `json:"content,omitempty"`
 	Embeds          []*Embed         `json:"embeds,omitempty"`
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
-	Components      []*Component     `json:"components,omitempty"`
+	Components      Components       `json:"components,omitempty"`
 	StickerIDS      []*Snowflake     `json:"sticker_ids,omitempty"`
 	Attachments     []*Attachment    `json:"attachments,omitempty"`
 	Files           []byte           `dasgo:"files"`
@@ -655,7 +679,7 @@ type ListActiveChannelThreads struct {
 // https://discord.com/developers/docs/resources/channel#list-public-archived-threads
 type ListPublicArchivedThreads struct {
 	ChannelID Snowflake
-	Before    Snowflake `url:"before,omitempty"`
+	Before    time.Time `url:"before,omitempty"`
 	Limit     int       `url:"limit,omitempty"`
 }
 
@@ -664,7 +688,7 @@ type ListPublicArchivedThreads struct {
 // https://discord.com/developers/docs/resources/channel#list-private-archived-threads
 type ListPrivateArchivedThreads struct {
 	ChannelID Snowflake
-	Before    Snowflake `url:"before,omitempty"`
+	Before    time.Time `url:"before,omitempty"`
 	Limit     int       `url:"limit,omitempty"`
 }
 
@@ -708,8 +732,8 @@ type CreateGuildEmoji struct {
 type ModifyGuildEmoji struct {
 	GuildID Snowflake
 	EmojiID Snowflake
-	Name    string       `json:"name"`
-	Roles   []*Snowflake `json:"roles"`
+	Name    Optional[string]       `json:"name,omitempty"`
+	Roles   Nullable[[]*Snowflake] `json:"roles,omitempty"`
 }
 
 // Delete Guild Emoji
@@ -758,26 +782,26 @@ type GetGuildPreview struct {
 // https://discord.com/developers/docs/resources/guild#modify-guild
 type ModifyGuild struct {
 	GuildID                     Snowflake
-	Name                        string     `json:"name"`
-	Region                      string     `json:"region"`
-	VerificationLevel           *Flag      `json:"verification_lvl"`
-	DefaultMessageNotifications *Flag      `json:"default_message_notifications"`
-	ExplicitContentFilter       *Flag      `json:"explicit_content_filter"`
-	AFKChannelID                Snowflake  `json:"afk_channel_id"`
-	AfkTimeout                  int        `json:"afk_timeout"`
-	Icon                        *string    `json:"icon"`
-	OwnerID                     Snowflake  `json:"owner_id"`
-	Splash                      *string    `json:"splash"`
-	DiscoverySplash             *string    `json:"discovery_splash"`
-	Banner                      *string    `json:"banner"`
-	SystemChannelID             *Snowflake `json:"system_channel_id"`
-	SystemChannelFlags          BitFlag    `json:"system_channel_flags"`
-	RulesChannelID              *Snowflake `json:"rules_channel_id"`
-	PublicUpdatesChannelID      *Snowflake `json:"public_updates_channel_id"`
-	PreferredLocale             *string    `json:"preferred_locale"`
-	Features                    []*string  `json:"features"`
-	Description                 *string    `json:"description"`
-	PremiumProgressBarEnabled   bool       `json:"premium_progress_bar_enabled"`
+	Name                        Optional[string]    `json:"name,omitempty"`
+	Region                      Nullable[string]    `json:"region,omitempty"`
+	VerificationLevel           Nullable[Flag]      `json:"verification_level,omitempty"`
+	DefaultMessageNotifications Nullable[Flag]      `json:"default_message_notifications,omitempty"`
+	ExplicitContentFilter       Nullable[Flag]      `json:"explicit_content_filter,omitempty"`
+	AFKChannelID                Nullable[Snowflake] `json:"afk_channel_id,omitempty"`
+	AfkTimeout                  Optional[int]       `json:"afk_timeout,omitempty"`
+	Icon                        Nullable[string]    `json:"icon,omitempty"`
+	OwnerID                     Optional[Snowflake] `json:"owner_id,omitempty"`
+	Splash                      Nullable[string]    `json:"splash,omitempty"`
+	DiscoverySplash             Nullable[string]    `json:"discovery_splash,omitempty"`
+	Banner                      Nullable[string]    `json:"banner,omitempty"`
+	SystemChannelID             Nullable[Snowflake] `json:"system_channel_id,omitempty"`
+	SystemChannelFlags          Optional[BitFlag]   `json:"system_channel_flags,omitempty"`
+	RulesChannelID              Nullable[Snowflake] `json:"rules_channel_id,omitempty"`
+	PublicUpdatesChannelID      Nullable[Snowflake] `json:"public_updates_channel_id,omitempty"`
+	PreferredLocale             Nullable[string]    `json:"preferred_locale,omitempty"`
+	Features                    Optional[[]*string] `json:"features,omitempty"`
+	Description                 Nullable[string]    `json:"description,omitempty"`
+	PremiumProgressBarEnabled   Optional[bool]      `json:"premium_progress_bar_enabled,omitempty"`
 }
 
 // Delete Guild
@@ -817,10 +841,10 @@ type CreateGuildChannel struct {
 // https://discord.com/developers/docs/resources/guild#modify-guild-channel-positions
 type ModifyGuildChannelPositions struct {
 	GuildID         Snowflake
-	ID              Snowflake  `json:"id"`
-	Position        int        `json:"position"`
-	LockPermissions bool       `json:"lock_permissions"`
-	ParentID        *Snowflake `json:"parent_id"`
+	ID              Snowflake           `json:"id"`
+	Position        Nullable[int]       `json:"position,omitempty"`
+	LockPermissions Nullable[bool]      `json:"lock_permissions,omitempty"`
+	ParentID        Nullable[Snowflake] `json:"parent_id,omitempty"`
 }
 
 // List Active Guild Threads
@@ -877,12 +901,12 @@ type AddGuildMember struct {
 type ModifyGuildMember struct {
 	GuildID                    Snowflake
 	UserID                     Snowflake
-	Nick                       string      `json:"nick"`
-	Roles                      []Snowflake `json:"roles"`
-	Mute                       bool        `json:"mute"`
-	Deaf                       bool        `json:"deaf"`
-	ChannelID                  Snowflake   `json:"channel_id"`
-	CommunicationDisabledUntil *time.Time  `json:"communication_disabled_until"`
+	Nick                       Nullable[string]      `json:"nick,omitempty"`
+	Roles                      Nullable[[]Snowflake] `json:"roles,omitempty"`
+	Mute                       Nullable[bool]        `json:"mute,omitempty"`
+	Deaf                       Nullable[bool]        `json:"deaf,omitempty"`
+	ChannelID                  Nullable[Snowflake]   `json:"channel_id,omitempty"`
+	CommunicationDisabledUntil Nullable[time.Time]   `json:"communication_disabled_until,omitempty"`
 }
 
 // Modify Current Member
@@ -890,7 +914,7 @@ type ModifyGuildMember struct {
 // https://discord.com/developers/docs/resources/guild#modify-current-member
 type ModifyCurrentMember struct {
 	GuildID Snowflake
-	Nick    *string `json:"nick,omitempty"`
+	Nick    Nullable[string] `json:"nick,omitempty"`
 }
 
 // Add Guild Member Role
@@ -981,8 +1005,8 @@ type CreateGuildRole struct {
 // https://discord.com/developers/docs/resources/guild#modify-guild-role-positions
 type ModifyGuildRolePositions struct {
 	GuildID  Snowflake
-	ID       Snowflake `json:"id"`
-	Position *int      `json:"position,omitempty"`
+	ID       Snowflake     `json:"id"`
+	Position Nullable[int] `json:"position,omitempty"`
 }
 
 // Modify Guild Role
@@ -991,13 +1015,13 @@ type ModifyGuildRolePositions struct {
 type ModifyGuildRole struct {
 	GuildID      Snowflake
 	RoleID       Snowflake
-	Name         string  `json:"name"`
-	Permissions  BitFlag   `json:"permissions"`
-	Color        *int    `json:"color"`
-	Hoist        bool    `json:"hoist"`
-	Icon         *string `json:"icon"`
-	UnicodeEmoji *string `json:"unicode_emoji"`
-	Mentionable  bool    `json:"mentionable"`
+	Name         Nullable[string]  `json:"name,omitempty"`
+	Permissions  Nullable[BitFlag] `json:"permissions,omitempty"`
+	Color        Nullable[int]     `json:"color,omitempty"`
+	Hoist        Nullable[bool]    `json:"hoist,omitempty"`
+	Icon         Nullable[string]  `json:"icon,omitempty"`
+	UnicodeEmoji Nullable[string]  `json:"unicode_emoji,omitempty"`
+	Mentionable  Nullable[bool]    `json:"mentionable,omitempty"`
 }
 
 // Delete Guild Role
@@ -1121,9 +1145,9 @@ type GetGuildWelcomeScreen struct {
 // https://discord.com/developers/docs/resources/guild#modify-guild-welcome-screen
 type ModifyGuildWelcomeScreen struct {
 	GuildID         Snowflake
-	Enabled         bool                    `json:"enabled"`
-	WelcomeChannels []*WelcomeScreenChannel `json:"welcome_channels"`
-	Description     *string                 `json:"description"`
+	Enabled         Nullable[bool]                    `json:"enabled,omitempty"`
+	WelcomeChannels Nullable[[]*WelcomeScreenChannel] `json:"welcome_channels,omitempty"`
+	Description     Nullable[string]                  `json:"description,omitempty"`
 }
 
 // Modify Current User Voice State
@@ -1131,8 +1155,8 @@ type ModifyGuildWelcomeScreen struct {
 // https://discord.com/developers/docs/resources/guild#modify-current-user-voice-state
 type ModifyCurrentUserVoiceState struct {
 	GuildID                 Snowflake
-	Suppress                bool       `json:"suppress,omitempty"`
-	RequestToSpeakTimestamp *time.Time `json:"request_to_speak_timestamp,omitempty"`
+	Suppress                Optional[bool]      `json:"suppress,omitempty"`
+	RequestToSpeakTimestamp Nullable[time.Time] `json:"request_to_speak_timestamp,omitempty"`
 }
 
 // Modify User Voice State
@@ -1141,7 +1165,7 @@ type ModifyCurrentUserVoiceState struct {
 type ModifyUserVoiceState struct {
 	GuildID  Snowflake
 	UserID   Snowflake
-	Suppress *bool `json:"suppress,omitempty"`
+	Suppress Optional[bool] `json:"suppress,omitempty"`
 }
 
 // List Scheduled Events for Guild
@@ -1183,16 +1207,16 @@ type GetGuildScheduledEvent struct {
 type ModifyGuildScheduledEvent struct {
 	GuildID               Snowflake
 	GuildScheduledEventID Snowflake
-	ChannelID             *Snowflake                         `json:"channel_id,omitempty"`
-	EntityMetadata        *GuildScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
-	Name                  *string                            `json:"name,omitempty"`
-	PrivacyLevel          Flag                               `json:"privacy_level,omitempty"`
-	ScheduledStartTime    Snowflake                          `json:"scheduled_start_time,omitempty"`
-	ScheduledEndTime      Snowflake                          `json:"scheduled_end_time,omitempty"`
-	Description           *string                            `json:"description,omitempty"`
-	EntityType            *Flag                              `json:"entity_type,omitempty"`
-	Status                Flag                               `json:"status,omitempty"`
-	Image                 *string                            `json:"image,omitempty"`
+	ChannelID             Nullable[Snowflake]                         `json:"channel_id,omitempty"`
+	EntityMetadata        Nullable[GuildScheduledEventEntityMetadata] `json:"entity_metadata,omitempty"`
+	Name                  Optional[string]                            `json:"name,omitempty"`
+	PrivacyLevel          Optional[Flag]                              `json:"privacy_level,omitempty"`
+	ScheduledStartTime    Optional[time.Time]                         `json:"scheduled_start_time,omitempty"`
+	ScheduledEndTime      Optional[time.Time]                         `json:"scheduled_end_time,omitempty"`
+	Description           Nullable[string]                            `json:"description,omitempty"`
+	EntityType            Optional[Flag]                              `json:"entity_type,omitempty"`
+	Status                Optional[Flag]                              `json:"status,omitempty"`
+	Image                 Optional[string]                            `json:"image,omitempty"`
 }
 
 // Delete Guild Scheduled Event
@@ -1261,8 +1285,8 @@ type SyncGuildTemplate struct {
 type ModifyGuildTemplate struct {
 	GuildID      Snowflake
 	TemplateCode string
-	Name         string  `json:"name,omitempty"`
-	Description  *string `json:"description,omitempty"`
+	Name         Optional[string] `json:"name,omitempty"`
+	Description  Nullable[string] `json:"description,omitempty"`
 }
 
 // Delete Guild Template
@@ -1312,8 +1336,8 @@ type GetStageInstance struct {
 // https://discord.com/developers/docs/resources/stage-instance#modify-stage-instance
 type ModifyStageInstance struct {
 	ChannelID    Snowflake
-	Topic        string `json:"topic,omitempty"`
-	PrivacyLevel Flag   `json:"privacy_level,omitempty"`
+	Topic        Optional[string] `json:"topic,omitempty"`
+	PrivacyLevel Optional[Flag]   `json:"privacy_level,omitempty"`
 }
 
 // Delete Stage Instance
@@ -1369,9 +1393,9 @@ type CreateGuildSticker struct {
 type ModifyGuildSticker struct {
 	GuildID     Snowflake
 	StickerID   Snowflake
-	Name        string  `json:"name,omitempty"`
-	Description string  `json:"description,omitempty"`
-	Tags        *string `json:"tags,omitempty"`
+	Name        Optional[string] `json:"name,omitempty"`
+	Description Nullable[string] `json:"description,omitempty"`
+	Tags        Optional[string] `json:"tags,omitempty"`
 }
 
 // Delete Guild Sticker
@@ -1398,17 +1422,17 @@ type GetUser struct {
 // PATCH /users/@me
 // https://discord.com/developers/docs/resources/user#modify-current-user
 type ModifyCurrentUser struct {
-	Username string  `json:"username"`
-	Avatar   *string `json:"avatar"`
+	Username Optional[string] `json:"username,omitempty"`
+	Avatar   Nullable[string] `json:"avatar,omitempty"`
 }
 
 // Get Current User Guilds
 // GET /users/@me/guilds
 // https://discord.com/developers/docs/resources/user#get-current-user-guilds
 type GetCurrentUserGuilds struct {
-	Before *Snowflake `json:"before"`
-	After  *Snowflake `json:"after"`
-	Limit  *int       `json:"limit"`
+	Before *Snowflake `url:"before,omitempty"`
+	After  *Snowflake `url:"after,omitempty"`
+	Limit  *int       `url:"limit,omitempty"`
 }
 
 // Get Current User Guild Member
@@ -1493,9 +1517,9 @@ type GetWebhookwithToken struct {
 // https://discord.com/developers/docs/resources/webhook#modify-webhook
 type ModifyWebhook struct {
 	WebhookID Snowflake
-	Name      string    `json:"name"`
-	Avatar    string    `json:"avatar"`
-	ChannelID Snowflake `json:"channel_id"`
+	Name      Optional[string]    `json:"name,omitempty"`
+	Avatar    Nullable[string]    `json:"avatar,omitempty"`
+	ChannelID Optional[Snowflake] `json:"channel_id,omitempty"`
 }
 
 // Modify Webhook with Token
@@ -1527,19 +1551,19 @@ type DeleteWebhookwithToken struct {
 type ExecuteWebhook struct {
 	WebhookID       Snowflake
 	WebhookToken    string
-	Wait            bool             `url:"wait"`
-	ThreadID        Snowflake        `url:"thread_id"`
-	Content         string           `json:"content"`
-	Username        string           `json:"username"`
-	AvatarURL       string           `json:"avatar_url"`
-	TTS             bool             `json:"tts"`
-	Embeds          []*Embed         `json:"embeds"`
-	AllowedMentions *AllowedMentions `json:"allowed_mentions"`
-	Components      []Component      `json:"components"`
+	Wait            bool             `url:"wait,omitempty"`
+	ThreadID        Snowflake        `url:"thread_id,omitempty"`
+	Content         string           `json:"content,omitempty"`
+	Username        string           `json:"username,omitempty"`
+	AvatarURL       string           `json:"avatar_url,omitempty"`
+	TTS             bool             `json:"tts,omitempty"`
+	Embeds          []*Embed         `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Components      Components       `json:"components,omitempty"`
 	Files           []byte           `dasgo:"files"`
-	PayloadJSON     string           `json:"payload_json"`
-	Attachments     []*Attachment    `json:"attachments"`
-	Flags           BitFlag          `json:"flags"`
+	PayloadJSON     string           `json:"payload_json,omitempty"`
+	Attachments     []*Attachment    `json:"attachments,omitempty"`
+	Flags           BitFlag          `json:"flags,omitempty"`
 }
 
 // Execute Slack-Compatible Webhook
@@ -1548,8 +1572,9 @@ type ExecuteWebhook struct {
 type ExecuteSlackCompatibleWebhook struct {
 	WebhookID    Snowflake
 	WebhookToken string
-	ThreadID     Snowflake `url:"thread_id"`
-	Wait         bool      `url:"wait"`
+	ThreadID     Snowflake `url:"thread_id,omitempty"`
+	Wait         bool      `url:"wait,omitempty"`
+	SlackWebhookPayload
 }
 
 // Execute GitHub-Compatible Webhook
@@ -1558,8 +1583,10 @@ type ExecuteSlackCompatibleWebhook struct {
 type ExecuteGitHubCompatibleWebhook struct {
 	WebhookID    Snowflake
 	WebhookToken string
-	ThreadID     Snowflake `url:"thread_id"`
-	Wait         bool      `url:"wait"`
+	ThreadID     Snowflake `url:"thread_id,omitempty"`
+	Wait         bool      `url:"wait,omitempty"`
+	Event        string    `http:"X-GitHub-Event,omitempty"`
+	GitHubWebhookPayload
 }
 
 // Get Webhook Message
@@ -1569,7 +1596,7 @@ type GetWebhookMessage struct {
 	WebhookID    Snowflake
 	WebhookToken string
 	MessageID    Snowflake
-	ThreadID     Snowflake `url:"thread_id"`
+	ThreadID     Snowflake `url:"thread_id,omitempty"`
 }
 
 // Edit Webhook Message
@@ -1579,14 +1606,14 @@ type EditWebhookMessage struct {
 	WebhookID       Snowflake
 	WebhookToken    string
 	MessageID       Snowflake
-	ThreadID        Snowflake        `url:"thread_id"`
-	Content         *string          `json:"content"`
-	Embeds          []*Embed         `json:"embeds"`
-	Components      []*Component     `json:"components"`
-	Files           []byte           `dasgo:"files"`
-	AllowedMentions *AllowedMentions `json:"allowed_mentions"`
-	PayloadJSON     string           `json:"payload_json"`
-	Attachments     []*Attachment    `json:"attachments"`
+	ThreadID        Snowflake                 `url:"thread_id,omitempty"`
+	Content         Nullable[string]          `json:"content,omitempty"`
+	Embeds          Nullable[[]*Embed]        `json:"embeds,omitempty"`
+	Components      Nullable[Components]      `json:"components,omitempty"`
+	Files           []byte                    `dasgo:"files"`
+	AllowedMentions Nullable[AllowedMentions] `json:"allowed_mentions,omitempty"`
+	PayloadJSON     Optional[string]          `json:"payload_json,omitempty"`
+	Attachments     Nullable[[]*Attachment]   `json:"attachments,omitempty"`
 }
 
 // Delete Webhook Message
@@ -1596,7 +1623,7 @@ type DeleteWebhookMessage struct {
 	WebhookID    Snowflake
 	WebhookToken string
 	MessageID    Snowflake
-	ThreadID     *Snowflake `url:"thread_id"`
+	ThreadID     *Snowflake `url:"thread_id,omitempty"`
 }
 
 // Get Current Bot Application Information
@@ -1629,6 +1656,11 @@ type AuthorizationURL struct {
 	State        string    `url:"state,omitempty"`
 	RedirectURI  string    `url:"redirect_uri,omitempty"`
 	Prompt       string    `url:"prompt,omitempty"`
+
+	// https://discord.com/developers/docs/topics/oauth2#advanced-bot-authorization
+	Permissions        BitFlag   `url:"permissions,omitempty"`
+	GuildID            Snowflake `url:"guild_id,omitempty"`
+	DisableGuildSelect bool      `url:"disable_guild_select,omitempty"`
 }
 
 // Access Token Exchange
@@ -1666,7 +1698,7 @@ type ClientCredentialsTokenRequest struct {
 type BotAuth struct {
 	ClientID           Snowflake `url:"client_id"`
 	Scope              string    `url:"scope"`
-	Permissions        BitFlag   `url:"permissions"`
-	GuildID            Snowflake `url:"guild_id"`
-	DisableGuildSelect bool      `url:"disable_guild_select"`
+	Permissions        BitFlag   `url:"permissions,omitempty"`
+	GuildID            Snowflake `url:"guild_id,omitempty"`
+	DisableGuildSelect bool      `url:"disable_guild_select,omitempty"`
 }
@@ -2,6 +2,8 @@
 package dasgo
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -13,9 +15,9 @@ type ApplicationCommand struct {
 	ApplicationID            Snowflake                   `json:"application_id"`
 	GuildID                  Snowflake                   `json:"guild_id,omitempty"`
 	Name                     string                      `json:"name"`
-	NameLocalizations        map[Flag]string             `json:"name_localizations"`
+	NameLocalizations        map[string]string           `json:"name_localizations"`
 	Description              string                      `json:"description"`
-	DescriptionLocalizations map[Flag]string             `json:"description_localizations"`
+	DescriptionLocalizations map[string]string           `json:"description_localizations"`
 	Options                  []*ApplicationCommandOption `json:"options,omitempty"`
 	DefaultMemberPermissions *string                      `json:"default_member_permissions"`
 	DMPermission             *bool                        `json:"dm_permission,omitempty"`
@@ -35,9 +37,9 @@ const (
 type ApplicationCommandOption struct {
 	Type                     Flag                              `json:"type"`
 	Name                     string                            `json:"name"`
-	NameLocalizations        map[Flag]string                   `json:"name_localizations"`
+	NameLocalizations        map[string]string                 `json:"name_localizations"`
 	Description              string                            `json:"description"`
-	DescriptionLocalizations map[Flag]string                   `json:"description_localizations"`
+	DescriptionLocalizations map[string]string                 `json:"description_localizations"`
 	Required                 *bool                              `json:"required,omitempty"`
 	Choices                  []*ApplicationCommandOptionChoice `json:"choices,omitempty"`
 	Options                  []*ApplicationCommandOption       `json:"options,omitempty"`
@@ -67,7 +69,7 @@ const (
 // https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-choice-structure
 type ApplicationCommandOptionChoice struct {
 	Name              string          `json:"name"`
-	NameLocalizations map[Flag]string `json:"name_localizations"`
+	NameLocalizations map[string]string `json:"name_localizations"`
 	Value             interface{}     `json:"value"`
 }
 
@@ -107,9 +109,13 @@ const (
 
 // Component Object
 type Component interface {
-	Type()
+	Type() Flag
 }
 
+// Components represents a list of components, which are decoded by component type
+// into pointers (i.e *Button).
+type Components []Component
+
 // Component Types
 // https://discord.com/developers/docs/interactions/message-components#component-object-component-types
 const (
@@ -135,9 +141,93 @@ func (c TextInput) Type() Flag {
 	return FlagComponentTypeTextInput
 }
 
+// MarshalJSON encodes an Action Row with its component type.
+func (c ActionsRow) MarshalJSON() ([]byte, error) {
+	type actionsRow ActionsRow
+	return json.Marshal(struct {
+		Type Flag `json:"type"`
+		actionsRow
+	}{c.Type(), actionsRow(c)})
+}
+
+// MarshalJSON encodes a Button with its component type.
+func (c Button) MarshalJSON() ([]byte, error) {
+	type button Button
+	return json.Marshal(struct {
+		Type Flag `json:"type"`
+		button
+	}{c.Type(), button(c)})
+}
+
+// MarshalJSON encodes a Select Menu with its component type.
+func (c SelectMenu) MarshalJSON() ([]byte, error) {
+	type selectMenu SelectMenu
+	return json.Marshal(struct {
+		Type Flag `json:"type"`
+		selectMenu
+	}{c.Type(), selectMenu(c)})
+}
+
+// MarshalJSON encodes a Text Input with its component type.
+func (c TextInput) MarshalJSON() ([]byte, error) {
+	type textInput TextInput
+	return json.Marshal(struct {
+		Type Flag `json:"type"`
+		textInput
+	}{c.Type(), textInput(c)})
+}
+
+// UnmarshalJSON decodes a list of components by component type.
+func (c *Components) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("error decoding components: %w", err)
+	}
+
+	if raw == nil {
+		*c = nil
+		return nil
+	}
+
+	components := make(Components, len(raw))
+	for i, data := range raw {
+		var header struct {
+			Type Flag `json:"type"`
+		}
+
+		if err := json.Unmarshal(data, &header); err != nil {
+			return fmt.Errorf("error decoding component: %w", err)
+		}
+
+		var component Component
+		switch header.Type {
+		case FlagComponentTypeActionRow:
+			component = new(ActionsRow)
+		case FlagComponentTypeButton:
+			component = new(Button)
+		case FlagComponentTypeSelectMenu:
+			component = new(SelectMenu)
+		case FlagComponentTypeTextInput:
+			component = new(TextInput)
+		default:
+			return fmt.Errorf("error decoding component: unknown component type %d", header.Type)
+		}
+
+		if err := json.Unmarshal(data, component); err != nil {
+			return fmt.Errorf("error decoding component: %w", err)
+		}
+
+		components[i] = component
+	}
+
+	*c = components
+
+	return nil
+}
+
 // https://discord.com/developers/docs/interactions/message-components#component-object
 type ActionsRow struct {
-	Components []Component `json:"components"`
+	Components Components `json:"components"`
 }
 
 
@@ -252,7 +342,7 @@ type InteractionData struct {
 	ComponentType Flag                                       `json:"component_type,omitempty"`
 	Values        []*string                                  `json:"values,omitempty"`
 	TargetID      Snowflake                                  `json:"target_id,omitempty"`
-	Components    []*Component                               `json:"components,omitempty"`
+	Components    Components                                 `json:"components,omitempty"`
 }
 
 // Resolved Data Structure
@@ -307,7 +397,7 @@ type Messages struct {
 	Embeds          []*Embed         `json:"embeds,omitempty"`
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
 	Flags           *BitFlag          `json:"flags,omitempty"`
-	Components      []Component      `json:"components,omitempty"`
+	Components      Components       `json:"components,omitempty"`
 	Attachments     []*Attachment    `json:"attachments,omitempty"`
 }
 
@@ -322,7 +412,7 @@ type Autocomplete struct {
 type ModalSubmitInteractionData struct {
 	CustomID   *string     `json:"custom_id"`
 	Title      string      `json:"title"`
-	Components []Component `json:"components"`
+	Components Components `json:"components"`
 }
 
 // Application Object
@@ -344,7 +434,7 @@ type Application struct {
 	PrimarySKUID        Snowflake      `json:"primary_sku_id,omitempty"`
 	Slug                *string        `json:"slug,omitempty"`
 	CoverImage          *string         `json:"cover_image,omitempty"`
-	Flags               *Flag           `json:"flags,omitempty"`
+	Flags               *BitFlag        `json:"flags,omitempty"`
 	Tags                []string       `json:"tags,omitempty"`
 	InstallParams       *InstallParams `json:"install_params,omitempty"`
 	CustomInstallURL    string         `json:"custom_install_url,omitempty"`
@@ -483,19 +573,19 @@ type Channel struct {
 	Topic                      *string               `json:"topic,omitempty"`
 	NSFW                       *bool                  `json:"nsfw,omitempty"`
 	LastMessageID              *Snowflake             `json:"last_message_id"`
-	Bitrate                    *Flag                  `json:"bitrate,omitempty"`
-	UserLimit                  *Flag                  `json:"user_limit,omitempty"`
+	Bitrate                    *int                  `json:"bitrate,omitempty"`
+	UserLimit                  *int                  `json:"user_limit,omitempty"`
 	RateLimitPerUser           *CodeFlag             `json:"rate_limit_per_user,omitempty"`
 	Recipients                 []*User               `json:"recipients,omitempty"`
 	Icon                       *string                `json:"icon"`
 	OwnerID                    Snowflake             `json:"owner_id,omitempty"`
 	ApplicationID              Snowflake             `json:"application_id,omitempty"`
 	ParentID                   *Snowflake             `json:"parent_id"`
-	LastPinTimestamp           time.Time             `json:"last_pin_timestamp"`
+	LastPinTimestamp           Timestamp             `json:"last_pin_timestamp"`
 	RTCRegion                  *string                `json:"rtc_region"`
 	VideoQualityMode           Flag                  `json:"video_quality_mode,omitempty"`
-	MessageCount               *Flag                  `json:"message_count,omitempty"`
-	MemberCount                *Flag                  `json:"member_count,omitempty"`
+	MessageCount               *int                  `json:"message_count,omitempty"`
+	MemberCount                *int                  `json:"member_count,omitempty"`
 	ThreadMetadata             *ThreadMetadata       `json:"thread_metadata,omitempty"`
 	Member                     *ThreadMember         `json:"member,omitempty"`
 	DefaultAutoArchiveDuration int                   `json:"default_auto_archive_duration,omitempty"`
@@ -543,7 +633,7 @@ type Message struct {
 	Member            *GuildMember      `json:"member,omitempty"`
 	Content           string            `json:"content"`
 	Timestamp         time.Time         `json:"timestamp"`
-	EditedTimestamp   time.Time         `json:"edited_timestamp"`
+	EditedTimestamp   Timestamp         `json:"edited_timestamp"`
 	TTS               bool              `json:"tts"`
 	MentionEveryone   bool              `json:"mention_everyone"`
 	Mentions          []*User           `json:"mentions"`
@@ -562,9 +652,9 @@ type Message struct {
 	MessageReference  *MessageReference `json:"message_reference,omitempty"`
 	Flags             *CodeFlag          `json:"flags,omitempty"`
 	ReferencedMessage *Message          `json:"referenced_message,omitempty"`
-	Interaction       *Interaction      `json:"interaction,omitempty"`
+	Interaction       *MessageInteraction `json:"interaction,omitempty"`
 	Thread            *Channel          `json:"thread,omitempty"`
-	Components        []*Component      `json:"components,omitempty"`
+	Components        Components        `json:"components,omitempty"`
 	StickerItems      []*StickerItem    `json:"sticker_items,omitempty"`
 }
 
@@ -664,6 +754,7 @@ type PermissionOverwrite struct {
 type ThreadMetadata struct {
 	Archived            bool      `json:"archived"`
 	AutoArchiveDuration int       `json:"auto_archive_duration"`
+	ArchiveTimestamp    time.Time `json:"archive_timestamp"`
 	Locked              bool      `json:"locked"`
 	Invitable           *bool      `json:"invitable,omitempty"`
 	CreateTimestamp     *time.Time `json:"create_timestamp"`
@@ -685,8 +776,8 @@ type Embed struct {
 	Type        *string          `json:"type,omitempty"`
 	Description *string         `json:"description,omitempty"`
 	URL         *string          `json:"url,omitempty"`
-	Timestamp   time.Time       `json:"timestamp,omitempty"`
-	Color       CodeFlag        `json:"color,omitempty"`
+	Timestamp   *time.Time      `json:"timestamp,omitempty"`
+	Color       *int            `json:"color,omitempty"`
 	Footer      *EmbedFooter    `json:"footer,omitempty"`
 	Image       *EmbedImage     `json:"image,omitempty"`
 	Thumbnail   *EmbedThumbnail `json:"thumbnail,omitempty"`
@@ -765,6 +856,10 @@ const (
 	FlagEmbedLimitFieldValue  = 1024
 	FlagEmbedLimitFooterText  = 2048
 	FlagEmbedLimitAuthorName  = 256
+	FlagEmbedLimitTotal       = 6000
+
+	// https://discord.com/developers/docs/resources/channel#create-message-jsonform-params
+	FlagEmbedLimitMessage = 10
 )
 
 // Message Attachment Object
@@ -961,7 +1056,7 @@ const (
 // Guild Preview Object
 // https://discord.com/developers/docs/resources/guild#guild-preview-object-guild-preview-structure
 type GuildPreview struct {
-	ID                       string     `json:"id"`
+	ID                       Snowflake  `json:"id"`
 	Name                     string     `json:"name"`
 	Icon                     string     `json:"icon"`
 	Splash                   string     `json:"splash"`
@@ -1087,7 +1182,7 @@ type GuildScheduledEvent struct {
 	Name               string                            `json:"name"`
 	Description        *string                            `json:"description"`
 	ScheduledStartTime time.Time                         `json:"scheduled_start_time"`
-	ScheduledEndTime   time.Time                         `json:"scheduled_end_time"`
+	ScheduledEndTime   Timestamp                         `json:"scheduled_end_time"`
 	PrivacyLevel       Flag                              `json:"privacy_level"`
 	Status             Flag                              `json:"status"`
 	EntityType         Flag                              `json:"entity_type"`
@@ -1164,7 +1259,7 @@ type Invite struct {
 	ApproximatePresenceCount int                  `json:"approximate_presence_count,omitempty"`
 	ApproximateMemberCount   int                  `json:"approximate_member_count,omitempty"`
 	ExpiresAt                *time.Time            `json:"expires_at"`
-	StageInstance            StageInstance        `json:"stage_instance,omitempty"`
+	StageInstance            *InviteStageInstance `json:"stage_instance,omitempty"`
 	GuildScheduledEvent      *GuildScheduledEvent `json:"guild_scheduled_event,omitempty"`
 }
 
@@ -1175,6 +1270,15 @@ const (
 	FlagInviteTargetTypeEMBEDDED_APPLICATION = 2
 )
 
+// Invite Stage Instance Object
+// https://discord.com/developers/docs/resources/invite#invite-stage-instance-object-invite-stage-instance-structure
+type InviteStageInstance struct {
+	Members          []*GuildMember `json:"members"`
+	ParticipantCount int            `json:"participant_count"`
+	SpeakerCount     int            `json:"speaker_count"`
+	Topic            string         `json:"topic"`
+}
+
 // Invite Metadata Object
 // https://discord.com/developers/docs/resources/invite#invite-metadata-object-invite-metadata-structure
 type InviteMetadata struct {
@@ -1234,6 +1338,7 @@ const (
 	FlagStickerFormatTypePNG    = 1
 	FlagStickerFormatTypeAPNG   = 2
 	FlagStickerFormatTypeLOTTIE = 3
+	FlagStickerFormatTypeGIF    = 4
 )
 
 // Sticker Item Object
@@ -1271,9 +1376,9 @@ type User struct {
 	Locale        string    `json:"locale,omitempty"`
 	Verified      bool      `json:"verified,omitempty"`
 	Email         *string   `json:"email"`
-	Flags         *BitFlag  `json:"flag,omitempty"`
+	Flags         *BitFlag  `json:"flags,omitempty"`
 	PremiumType   *Flag     `json:"premium_type,omitempty"`
-	PublicFlags   BitFlag   `json:"public_flag,omitempty"`
+	PublicFlags   BitFlag   `json:"public_flags,omitempty"`
 }
 
 // User Flags
@@ -1,7 +1,11 @@
 // Package dasgo provides Type Definitions for the Discord API.
 package dasgo
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // List Public Archived Threads Response Body
 // https://discord.com/developers/docs/resources/channel#list-public-archived-threads-response-body
@@ -38,7 +42,7 @@ type ListActiveGuildThreadsResponse struct {
 // https://discord.com/developers/docs/topics/oauth2#get-current-authorization-information
 type CurrentAuthorizationInformationResponse struct {
 	Application *Application `json:"application"`
-	Scopes      []*int       `json:"scopes"`
+	Scopes      []string     `json:"scopes"`
 	Expires     *time.Time   `json:"expires"`
 	User        *User        `json:"user,omitempty"`
 }
@@ -73,7 +77,7 @@ type RedirectURL struct {
 type AccessTokenResponse struct {
 	AccessToken  string        `json:"access_token,omitempty"`
 	TokenType    string        `json:"token_type,omitempty"`
-	ExpiresIn    time.Duration `json:"expires_in,omitempty"`
+	ExpiresIn    Seconds       `json:"expires_in,omitempty"`
 	RefreshToken string        `json:"refresh_token,omitempty"`
 	Scope        string        `json:"scope,omitempty"`
 }
@@ -83,7 +87,7 @@ type AccessTokenResponse struct {
 type RedirectURI struct {
 	AccessToken string        `url:"access_token,omitempty"`
 	TokenType   string        `url:"token_type,omitempty"`
-	ExpiresIn   time.Duration `url:"expires_in,omitempty"`
+	ExpiresIn   Seconds       `url:"expires_in,omitempty"`
 	Scope       string        `url:"scope,omitempty"`
 	State       string        `url:"state,omitempty"`
 }
@@ -93,7 +97,7 @@ type RedirectURI struct {
 type ClientCredentialsAccessTokenResponse struct {
 	AccessToken string        `json:"access_token,omitempty"`
 	TokenType   string        `json:"token_type,omitempty"`
-	ExpiresIn   time.Duration `json:"expires_in,omitempty"`
+	ExpiresIn   Seconds       `json:"expires_in,omitempty"`
 	Scope       string        `json:"scope,omitempty"`
 }
 
@@ -103,7 +107,7 @@ type WebhookTokenResponse struct {
 	TokenType    string        `json:"token_type,omitempty"`
 	AccessToken  string        `json:"access_token,omitempty"`
 	Scope        string        `json:"scope,omitempty"`
-	ExpiresIn    time.Duration `json:"expires_in,omitempty"`
+	ExpiresIn    Seconds       `json:"expires_in,omitempty"`
 	RefreshToken string        `json:"refresh_token,omitempty"`
 	Webhook      *Webhook      `json:"webhook,omitempty"`
 }
@@ -115,6 +119,19 @@ type ExtendedBotAuthorizationAccessTokenResponse struct {
 	Guild        *Guild        `json:"guild,omitempty"`
 	AccessToken  string        `json:"access_token,omitempty"`
 	Scope        string        `json:"scope,omitempty"`
-	ExpiresIn    time.Duration `json:"expires_in,omitempty"`
+	ExpiresIn    Seconds       `json:"expires_in,omitempty"`
 	RefreshToken string        `json:"refresh_token,omitempty"`
-}
\ No newline at end of file
+}
+
+// Error Response
+// https://discord.com/developers/docs/reference#error-messages
+type ErrorResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Errors  json.RawMessage `json:"errors,omitempty"`
+}
+
+// Error returns the error message of the response.
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("discord error %d: %s", e.Code, e.Message)
+}
@@ -0,0 +1,238 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Slack Attachment Colors
+// https://api.slack.com/reference/messaging/attachments#fields
+const (
+	FlagSlackColorGOOD    = "good"
+	FlagSlackColorWARNING = "warning"
+	FlagSlackColorDANGER  = "danger"
+)
+
+// slackColors maps a Slack Attachment Color to its RGB value.
+var slackColors = map[string]int{
+	FlagSlackColorGOOD:    0x2EB886,
+	FlagSlackColorWARNING: 0xDAA038,
+	FlagSlackColorDANGER:  0xA30200,
+}
+
+// Slack Webhook Payload
+// https://api.slack.com/messaging/webhooks
+type SlackWebhookPayload struct {
+	Text        string             `json:"text,omitempty"`
+	Username    string             `json:"username,omitempty"`
+	IconURL     string             `json:"icon_url,omitempty"`
+	Attachments []*SlackAttachment `json:"attachments,omitempty"`
+}
+
+// Slack Attachment Structure
+// https://api.slack.com/reference/messaging/attachments
+type SlackAttachment struct {
+	Fallback   string        `json:"fallback,omitempty"`
+	Color      string        `json:"color,omitempty"`
+	Pretext    string        `json:"pretext,omitempty"`
+	AuthorName string        `json:"author_name,omitempty"`
+	AuthorLink string        `json:"author_link,omitempty"`
+	AuthorIcon string        `json:"author_icon,omitempty"`
+	Title      string        `json:"title,omitempty"`
+	TitleLink  string        `json:"title_link,omitempty"`
+	Text       string        `json:"text,omitempty"`
+	Fields     []*SlackField `json:"fields,omitempty"`
+	ImageURL   string        `json:"image_url,omitempty"`
+	ThumbURL   string        `json:"thumb_url,omitempty"`
+	Footer     string        `json:"footer,omitempty"`
+	FooterIcon string        `json:"footer_icon,omitempty"`
+
+	// Timestamp represents the Unix time (in seconds) of the attachment.
+	Timestamp int64 `json:"ts,omitempty"`
+}
+
+// Slack Attachment Field Structure
+// https://api.slack.com/reference/messaging/attachments#field_objects
+type SlackField struct {
+	Title string `json:"title,omitempty"`
+	Value string `json:"value,omitempty"`
+	Short bool   `json:"short,omitempty"`
+}
+
+var (
+	// slackControlRegex matches a Slack control sequence (i.e <https://discord.com|Discord>).
+	slackControlRegex = regexp.MustCompile(`<([^<>\n]+)>`)
+
+	// slackBoldRegex matches Slack bold text (i.e *bold*).
+	slackBoldRegex = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*\n]*[^*\s])?)\*`)
+
+	// slackStrikethroughRegex matches Slack strikethrough text (i.e ~strike~).
+	slackStrikethroughRegex = regexp.MustCompile(`(^|[^\w~])~([^~\s](?:[^~\n]*[^~\s])?)~`)
+
+	// slackEntityReplacer unescapes the HTML entities that Slack escapes in text.
+	slackEntityReplacer = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+)
+
+// SlackMarkdown converts Slack message formatting (mrkdwn) into Discord markdown.
+//
+// Links (i.e <https://discord.com|Discord>) are converted into masked links, special mentions
+// (i.e <!here>) into Discord mentions, and bold (*) and strikethrough (~) text into Discord markdown.
+func SlackMarkdown(text string) string {
+	text = slackControlRegex.ReplaceAllStringFunc(text, func(match string) string {
+		control, label, labeled := strings.Cut(match[1:len(match)-1], "|")
+		switch {
+		case control == "!here":
+			return "@here"
+		case control == "!channel", control == "!everyone":
+			return "@everyone"
+		case strings.HasPrefix(control, "!"):
+			if labeled {
+				return label
+			}
+
+			return match
+		case strings.HasPrefix(control, "@"), strings.HasPrefix(control, "#"):
+			if labeled {
+				return control[:1] + label
+			}
+
+			return control
+		case labeled:
+			return "[" + label + "](" + control + ")"
+		}
+
+		return control
+	})
+
+	text = slackBoldRegex.ReplaceAllString(text, "$1**$2**")
+	text = slackStrikethroughRegex.ReplaceAllString(text, "$1~~$2~~")
+
+	return slackEntityReplacer.Replace(text)
+}
+
+// ExecuteWebhook returns the Execute Webhook request that is equivalent to a Slack webhook payload,
+// which converts the payload's attachments into embeds.
+//
+// Attachments that exceed the maximum number of embeds in a message are dropped.
+func (p *SlackWebhookPayload) ExecuteWebhook() *ExecuteWebhook {
+	execute := &ExecuteWebhook{
+		Content:   SlackMarkdown(p.Text),
+		Username:  p.Username,
+		AvatarURL: p.IconURL,
+	}
+
+	for _, attachment := range p.Attachments {
+		if attachment == nil {
+			continue
+		}
+
+		if len(execute.Embeds) == FlagEmbedLimitMessage {
+			break
+		}
+
+		execute.Embeds = append(execute.Embeds, attachment.Embed())
+	}
+
+	return execute
+}
+
+// Embed returns the embed that is equivalent to a Slack attachment.
+//
+// The attachment's Pretext and Text form the embed's description, which falls back
+// to the attachment's Fallback when the embed is otherwise empty. Values that exceed
+// the Embed Limits are truncated.
+func (a *SlackAttachment) Embed() *Embed {
+	embed := NewEmbed()
+	if a.Title != "" {
+		embed.Title(slackEntityReplacer.Replace(a.Title))
+	}
+
+	if a.TitleLink != "" {
+		embed.URL(a.TitleLink)
+	}
+
+	var description []string
+	for _, text := range []string{a.Pretext, a.Text} {
+		if text != "" {
+			description = append(description, SlackMarkdown(text))
+		}
+	}
+
+	if len(description) == 0 && a.Title == "" && len(a.Fields) == 0 && a.ImageURL == "" && a.Fallback != "" {
+		description = append(description, SlackMarkdown(a.Fallback))
+	}
+
+	if len(description) != 0 {
+		embed.Description(strings.Join(description, "\n"))
+	}
+
+	if color, ok := slackColor(a.Color); ok {
+		embed.Color(color)
+	}
+
+	if a.AuthorName != "" {
+		embed.Author(slackEntityReplacer.Replace(a.AuthorName), a.AuthorLink, a.AuthorIcon)
+	}
+
+	for _, field := range a.Fields {
+		if field == nil {
+			continue
+		}
+
+		// field names and values can't be empty in an embed.
+		name, value := slackEntityReplacer.Replace(field.Title), SlackMarkdown(field.Value)
+		if name == "" {
+			name = "\u200b"
+		}
+
+		if value == "" {
+			value = "\u200b"
+		}
+
+		if field.Short {
+			embed.InlineField(name, value)
+		} else {
+			embed.Field(name, value)
+		}
+	}
+
+	if a.ImageURL != "" {
+		embed.Image(a.ImageURL)
+	}
+
+	if a.ThumbURL != "" {
+		embed.Thumbnail(a.ThumbURL)
+	}
+
+	if a.Footer != "" {
+		embed.Footer(slackEntityReplacer.Replace(a.Footer), a.FooterIcon)
+	}
+
+	if a.Timestamp != 0 {
+		embed.Timestamp(time.Unix(a.Timestamp, 0).UTC())
+	}
+
+	return embed.Truncate().Build()
+}
+
+// slackColor returns the RGB value of a Slack Attachment Color (i.e good, #36A64F).
+func slackColor(color string) (int, bool) {
+	if rgb, ok := slackColors[color]; ok {
+		return rgb, true
+	}
+
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) != 6 {
+		return 0, false
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int(rgb), true
+}
@@ -0,0 +1,208 @@
+package dasgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestSlackMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "labeled link", text: "<https://discord.com|Discord>", want: "[Discord](https://discord.com)"},
+		{name: "link", text: "see <https://discord.com>", want: "see https://discord.com"},
+		{name: "here", text: "<!here> hello", want: "@here hello"},
+		{name: "channel", text: "<!channel>", want: "@everyone"},
+		{name: "everyone", text: "<!everyone>", want: "@everyone"},
+		{name: "labeled special", text: "<!date^1392734382^{date}|Feb 18, 2014>", want: "Feb 18, 2014"},
+		{name: "special", text: "<!subteam^S123>", want: "<!subteam^S123>"},
+		{name: "user", text: "<@U123>", want: "@U123"},
+		{name: "labeled user", text: "<@U123|bob>", want: "@bob"},
+		{name: "labeled channel", text: "<#C123|general>", want: "#general"},
+		{name: "bold", text: "*bold*", want: "**bold**"},
+		{name: "bold words", text: "a *b c* d", want: "a **b c** d"},
+		{name: "bold in a word", text: "2*3*4", want: "2*3*4"},
+		{name: "spaced asterisks", text: "* not bold *", want: "* not bold *"},
+		{name: "strikethrough", text: "~strike~", want: "~~strike~~"},
+		{name: "entities", text: "&lt;tag&gt; &amp; more", want: "<tag> & more"},
+		{name: "escaped link", text: "&lt;https://discord.com|Discord&gt;", want: "<https://discord.com|Discord>"},
+		{name: "empty", text: "", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if markdown := SlackMarkdown(test.text); markdown != test.want {
+				t.Fatalf("expected %q, got %q", test.want, markdown)
+			}
+		})
+	}
+}
+
+func TestSlackAttachmentEmbed(t *testing.T) {
+	embed := (&SlackAttachment{
+		Fallback:   "fallback",
+		Color:      FlagSlackColorGOOD,
+		Pretext:    "pretext",
+		AuthorName: "author &amp; co",
+		AuthorLink: "https://example.com/author",
+		AuthorIcon: "https://example.com/author.png",
+		Title:      "title &lt;1&gt;",
+		TitleLink:  "https://example.com",
+		Text:       "*text*",
+		Fields: []*SlackField{
+			{Title: "short", Value: "value", Short: true},
+			{Title: "long", Value: "<https://example.com|link>"},
+			nil,
+			{},
+		},
+		ImageURL:   "https://example.com/image.png",
+		ThumbURL:   "https://example.com/thumb.png",
+		Footer:     "footer",
+		FooterIcon: "https://example.com/footer.png",
+		Timestamp:  1618953630,
+	}).Embed()
+
+	if embed.Title == nil || *embed.Title != "title <1>" || embed.URL == nil || *embed.URL != "https://example.com" {
+		t.Fatalf("expected the title and its link, got %v and %v", embed.Title, embed.URL)
+	}
+
+	if embed.Description == nil || *embed.Description != "pretext\n**text**" {
+		t.Fatalf("expected the pretext and text, got %v", embed.Description)
+	}
+
+	if embed.Color == nil || *embed.Color != 0x2EB886 {
+		t.Fatalf("expected the good color, got %v", embed.Color)
+	}
+
+	if embed.Author == nil || embed.Author.Name != "author & co" || *embed.Author.URL != "https://example.com/author" ||
+		*embed.Author.IconURL != "https://example.com/author.png" {
+		t.Fatalf("expected the author, got %+v", embed.Author)
+	}
+
+	if len(embed.Fields) != 3 {
+		t.Fatalf("expected 3 fields, got %d", len(embed.Fields))
+	}
+
+	if field := embed.Fields[0]; field.Name != "short" || field.Value != "value" || field.Inline == nil || !*field.Inline {
+		t.Fatalf("expected an inline field, got %+v", field)
+	}
+
+	if field := embed.Fields[1]; field.Value != "[link](https://example.com)" || field.Inline != nil && *field.Inline {
+		t.Fatalf("expected a field with a masked link, got %+v", field)
+	}
+
+	// field names and values can't be empty.
+	if field := embed.Fields[2]; field.Name != "\u200b" || field.Value != "\u200b" {
+		t.Fatalf("expected a field with zero width spaces, got %+v", field)
+	}
+
+	if embed.Image == nil || embed.Image.URL != "https://example.com/image.png" ||
+		embed.Thumbnail == nil || embed.Thumbnail.URL != "https://example.com/thumb.png" {
+		t.Fatalf("expected the image and thumbnail, got %+v and %+v", embed.Image, embed.Thumbnail)
+	}
+
+	if embed.Footer == nil || embed.Footer.Text != "footer" || *embed.Footer.IconURL != "https://example.com/footer.png" {
+		t.Fatalf("expected the footer, got %+v", embed.Footer)
+	}
+
+	if embed.Timestamp == nil || !embed.Timestamp.Equal(time.Unix(1618953630, 0)) {
+		t.Fatalf("expected the timestamp, got %v", embed.Timestamp)
+	}
+}
+
+func TestSlackAttachmentEmbedFallback(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachment  *SlackAttachment
+		description string
+	}{
+		{name: "fallback", attachment: &SlackAttachment{Fallback: "*fallback*"}, description: "**fallback**"},
+		{name: "text", attachment: &SlackAttachment{Fallback: "fallback", Text: "text"}, description: "text"},
+		{name: "title", attachment: &SlackAttachment{Fallback: "fallback", Title: "title"}},
+		{name: "fields", attachment: &SlackAttachment{Fallback: "fallback", Fields: []*SlackField{{Title: "a", Value: "b"}}}},
+		{name: "image", attachment: &SlackAttachment{Fallback: "fallback", ImageURL: "https://example.com/image.png"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			embed := test.attachment.Embed()
+
+			var description string
+			if embed.Description != nil {
+				description = *embed.Description
+			}
+
+			if description != test.description {
+				t.Fatalf("expected %q, got %q", test.description, description)
+			}
+		})
+	}
+}
+
+func TestSlackAttachmentEmbedColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  int
+		ok    bool
+	}{
+		{color: FlagSlackColorWARNING, want: 0xDAA038, ok: true},
+		{color: FlagSlackColorDANGER, want: 0xA30200, ok: true},
+		{color: "#36a64f", want: 0x36A64F, ok: true},
+		{color: "36A64F", want: 0x36A64F, ok: true},
+		{color: "#36a64", ok: false},
+		{color: "#gggggg", ok: false},
+		{color: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.color, func(t *testing.T) {
+			embed := (&SlackAttachment{Text: "text", Color: test.color}).Embed()
+			if (embed.Color != nil) != test.ok || test.ok && *embed.Color != test.want {
+				t.Fatalf("expected color %#x (%v), got %v", test.want, test.ok, embed.Color)
+			}
+		})
+	}
+}
+
+func TestSlackAttachmentEmbedTruncate(t *testing.T) {
+	embed := (&SlackAttachment{Title: strings.Repeat("t", FlagEmbedLimitTitle+1), Text: "text"}).Embed()
+
+	if embed.Title == nil || utf8.RuneCountInString(*embed.Title) > FlagEmbedLimitTitle {
+		t.Fatalf("expected the title to be truncated to %d characters, got %v", FlagEmbedLimitTitle, embed.Title)
+	}
+
+	if err := embed.Validate(); err != nil {
+		t.Fatalf("expected a valid embed, got %v", err)
+	}
+}
+
+func TestSlackWebhookPayloadExecuteWebhook(t *testing.T) {
+	payload := &SlackWebhookPayload{
+		Text:     "<!here> *deployed*",
+		Username: "deploy",
+		IconURL:  "https://example.com/icon.png",
+	}
+
+	payload.Attachments = append(payload.Attachments, nil)
+	for i := 0; i <= FlagEmbedLimitMessage; i++ {
+		payload.Attachments = append(payload.Attachments, &SlackAttachment{Text: "attachment"})
+	}
+
+	execute := payload.ExecuteWebhook()
+	if execute.Content != "@here **deployed**" || execute.Username != "deploy" || execute.AvatarURL != "https://example.com/icon.png" {
+		t.Fatalf("expected the converted text, username, and icon, got %+v", execute)
+	}
+
+	// nil attachments are skipped, and the attachments that exceed the embed limit are dropped.
+	if len(execute.Embeds) != FlagEmbedLimitMessage {
+		t.Fatalf("expected %d embeds, got %d", FlagEmbedLimitMessage, len(execute.Embeds))
+	}
+
+	if err := ValidateEmbeds(execute.Embeds); err != nil {
+		t.Fatalf("expected valid embeds, got %v", err)
+	}
+}
@@ -0,0 +1,952 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State Cache Flags
+const (
+	FlagStateCacheGUILDS       = 1 << 0
+	FlagStateCacheCHANNELS     = 1 << 1
+	FlagStateCacheTHREADS      = 1 << 2
+	FlagStateCacheMEMBERS      = 1 << 3
+	FlagStateCacheROLES        = 1 << 4
+	FlagStateCacheEMOJIS       = 1 << 5
+	FlagStateCacheSTICKERS     = 1 << 6
+	FlagStateCacheVOICE_STATES = 1 << 7
+	FlagStateCachePRESENCES    = 1 << 8
+	FlagStateCacheALL          = 1<<9 - 1
+)
+
+// CachedGuild represents a cached guild, which retains the fields of the guild's Guild Create event.
+type CachedGuild struct {
+	Guild
+	JoinedAt time.Time `json:"joined_at,omitempty"`
+	Large    bool      `json:"large,omitempty"`
+
+	// MemberCount is updated by Guild Member Add and Guild Member Remove events.
+	MemberCount int `json:"member_count,omitempty"`
+}
+
+// State represents a cache of Discord entities maintained by applying gateway events.
+//
+// Cached entities are replaced (rather than modified) when an event is applied, so
+// values returned from accessors are safe to read concurrently but must not be modified.
+type State struct {
+	// Cache represents the State Cache Flags of the entity types that are cached.
+	Cache BitFlag
+
+	mu      sync.RWMutex
+	user    *User
+	storage *Storage
+}
+
+// NewState returns a new State that caches the entity types of the given State Cache Flags.
+//
+// A nil storage stores entities in memory.
+func NewState(cache BitFlag, storage *Storage) *State {
+	if storage == nil {
+		storage = NewMemoryStorage()
+	}
+
+	return &State{Cache: cache, storage: storage}
+}
+
+// enabled determines whether an entity type is cached.
+func (s *State) enabled(flag BitFlag) bool {
+	return s.Cache&flag != 0
+}
+
+// Apply applies a gateway event to the state.
+//
+// Events that do not affect cached entities are ignored.
+func (s *State) Apply(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	switch e := event.(type) {
+	case *Ready:
+		err = s.applyReady(e)
+	case *UserUpdate:
+		if e.User != nil {
+			s.user = e.User
+		}
+
+	case *GuildCreate:
+		err = s.applyGuildCreate(e)
+	case *GuildUpdate:
+		if e.Guild != nil {
+			err = s.applyGuildUpdate(e.Guild)
+		}
+
+	case *GuildDelete:
+		if e.Guild != nil {
+			err = s.applyGuildDelete(e.Guild)
+		}
+
+	case *ChannelCreate:
+		err = s.putChannel(e.Channel)
+	case *ChannelUpdate:
+		err = s.putChannel(e.Channel)
+	case *ChannelDelete:
+		err = s.applyChannelDelete(e.Channel)
+	case *ChannelPinsUpdate:
+		err = s.updateChannel(e.GuildID, e.ChannelID, func(channel *Channel) {
+			channel.LastPinTimestamp = e.LastPinTimestamp
+		})
+
+	case *MessageCreate:
+		err = s.applyMessageCreate(e)
+
+	case *ThreadCreate:
+		err = s.putThread(e.Channel)
+	case *ThreadUpdate:
+		err = s.putThread(e.Channel)
+	case *ThreadDelete:
+		if e.Channel != nil {
+			err = s.storage.Threads.Delete(e.GuildID, e.ID)
+		}
+
+	case *ThreadListSync:
+		err = s.applyThreadListSync(e)
+	case *ThreadMemberUpdate:
+		err = s.applyThreadMemberUpdate(e)
+	case *ThreadMembersUpdate:
+		err = s.applyThreadMembersUpdate(e)
+
+	case *GuildMemberAdd:
+		if e.GuildMember != nil {
+			err = s.applyGuildMemberAdd(e)
+		}
+
+	case *GuildMemberUpdate:
+		if e.GuildMember != nil {
+			err = s.applyGuildMemberUpdate(e.GuildMember)
+		}
+
+	case *GuildMemberRemove:
+		if e.User != nil {
+			err = s.applyGuildMemberRemove(e)
+		}
+
+	case *GuildMembersChunk:
+		err = s.applyGuildMembersChunk(e)
+
+	case *GuildRoleCreate:
+		err = s.putRole(e.GuildID, e.Role)
+	case *GuildRoleUpdate:
+		err = s.putRole(e.GuildID, e.Role)
+	case *GuildRoleDelete:
+		err = s.applyGuildRoleDelete(e)
+
+	case *GuildEmojisUpdate:
+		err = s.putEmojis(e.GuildID, e.Emojis)
+	case *GuildStickersUpdate:
+		err = s.putStickers(e.GuildID, e.Stickers)
+
+	case *VoiceStateUpdate:
+		if e.VoiceState != nil {
+			err = s.applyVoiceStateUpdate(e.VoiceState)
+		}
+
+	case *PresenceUpdate:
+		err = s.putPresence(e.GuildID, e)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error applying %T to state: %w", event, err)
+	}
+
+	return nil
+}
+
+// applyReady applies a Ready event.
+func (s *State) applyReady(e *Ready) error {
+	if e.User != nil {
+		s.user = e.User
+	}
+
+	if !s.enabled(FlagStateCacheGUILDS) {
+		return nil
+	}
+
+	for _, guild := range e.Guilds {
+		if guild == nil {
+			continue
+		}
+
+		cached, err := s.storage.Guilds.Get(0, guild.ID)
+		if err != nil {
+			return err
+		}
+
+		if cached == nil {
+			if err := s.storage.Guilds.Put(0, guild.ID, &CachedGuild{Guild: Guild{ID: guild.ID, Unavailable: true}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyGuildCreate applies a GuildCreate event.
+func (s *State) applyGuildCreate(e *GuildCreate) error {
+	if e.Guild == nil {
+		return nil
+	}
+
+	guildID := e.ID
+	if err := s.putGuild(e.Guild, func(cached *CachedGuild) {
+		cached.JoinedAt = e.JoinedAt
+		cached.Large = e.Large
+		cached.MemberCount = e.MemberCount
+	}); err != nil {
+		return err
+	}
+
+	if err := s.putStickers(guildID, e.Stickers); err != nil {
+		return err
+	}
+
+	for _, channel := range e.Channels {
+		if channel != nil {
+			cached := *channel
+			cached.GuildID = guildID
+			if err := s.putChannel(&cached); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, thread := range e.Threads {
+		if thread != nil {
+			cached := *thread
+			cached.GuildID = guildID
+			if err := s.putThread(&cached); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, member := range e.Members {
+		if err := s.putMember(guildID, member); err != nil {
+			return err
+		}
+	}
+
+	for _, voiceState := range e.VoiceStates {
+		if voiceState != nil {
+			cached := *voiceState
+			cached.GuildID = guildID
+			if err := s.applyVoiceStateUpdate(&cached); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, presence := range e.Presences {
+		if err := s.putPresence(guildID, presence); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyGuildUpdate applies a guild object, which retains the Guild Create fields of the cached guild.
+func (s *State) applyGuildUpdate(guild *Guild) error {
+	return s.putGuild(guild, nil)
+}
+
+// putGuild puts a guild object, which contains the roles and emojis of the guild,
+// and calls update (when non-nil) with the cached guild before it's stored.
+//
+// Roles, emojis, and stickers are cached separately from the guild.
+func (s *State) putGuild(guild *Guild, update func(*CachedGuild)) error {
+	if guild.Roles != nil {
+		if err := s.putRoles(guild.ID, guild.Roles); err != nil {
+			return err
+		}
+	}
+
+	if guild.Emojis != nil {
+		if err := s.putEmojis(guild.ID, guild.Emojis); err != nil {
+			return err
+		}
+	}
+
+	if !s.enabled(FlagStateCacheGUILDS) {
+		return nil
+	}
+
+	previous, err := s.storage.Guilds.Get(0, guild.ID)
+	if err != nil {
+		return err
+	}
+
+	var cached CachedGuild
+	if previous != nil {
+		cached = *previous
+	}
+
+	cached.Guild = *guild
+	cached.Roles = nil
+	cached.Emojis = nil
+	cached.Stickers = nil
+
+	if update != nil {
+		update(&cached)
+	}
+
+	return s.storage.Guilds.Put(0, guild.ID, &cached)
+}
+
+// updateMemberCount adds delta to the member count of a cached guild.
+func (s *State) updateMemberCount(guildID Snowflake, delta int) error {
+	cached, err := s.storage.Guilds.Get(0, guildID)
+	if err != nil || cached == nil {
+		return err
+	}
+
+	guild := *cached
+	guild.MemberCount += delta
+
+	return s.storage.Guilds.Put(0, guildID, &guild)
+}
+
+// applyGuildDelete applies a guild deletion.
+//
+// A guild that becomes unavailable (i.e due to an outage) retains its cached entities.
+func (s *State) applyGuildDelete(guild *Guild) error {
+	if guild.Unavailable {
+		cached, err := s.storage.Guilds.Get(0, guild.ID)
+		if err != nil || cached == nil {
+			return err
+		}
+
+		unavailable := *cached
+		unavailable.Unavailable = true
+
+		return s.storage.Guilds.Put(0, guild.ID, &unavailable)
+	}
+
+	if err := s.storage.Guilds.Delete(0, guild.ID); err != nil {
+		return err
+	}
+
+	for _, deleteGuild := range []func(Snowflake) error{
+		s.storage.Channels.DeleteGuild,
+		s.storage.Threads.DeleteGuild,
+		s.storage.Members.DeleteGuild,
+		s.storage.Roles.DeleteGuild,
+		s.storage.Emojis.DeleteGuild,
+		s.storage.Stickers.DeleteGuild,
+		s.storage.VoiceStates.DeleteGuild,
+		s.storage.Presences.DeleteGuild,
+	} {
+		if err := deleteGuild(guild.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// putChannel puts a channel.
+func (s *State) putChannel(channel *Channel) error {
+	if channel == nil || !s.enabled(FlagStateCacheCHANNELS) {
+		return nil
+	}
+
+	return s.storage.Channels.Put(channel.GuildID, channel.ID, channel)
+}
+
+// applyChannelDelete deletes a channel and its threads.
+func (s *State) applyChannelDelete(channel *Channel) error {
+	if channel == nil {
+		return nil
+	}
+
+	if err := s.storage.Channels.Delete(channel.GuildID, channel.ID); err != nil {
+		return err
+	}
+
+	var threads []Snowflake
+	err := s.storage.Threads.Iterate(channel.GuildID, func(id Snowflake, thread *Channel) bool {
+		if thread.ParentID != nil && *thread.ParentID == channel.ID {
+			threads = append(threads, id)
+		}
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range threads {
+		if err := s.storage.Threads.Delete(channel.GuildID, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateChannel updates a copy of a cached channel or thread.
+func (s *State) updateChannel(guildID, id Snowflake, update func(*Channel)) error {
+	for _, store := range []Store[Channel]{s.storage.Channels, s.storage.Threads} {
+		cached, err := store.Get(guildID, id)
+		if err != nil {
+			return err
+		}
+
+		if cached != nil {
+			channel := *cached
+			update(&channel)
+
+			return store.Put(guildID, id, &channel)
+		}
+	}
+
+	return nil
+}
+
+// applyMessageCreate updates the last message ID of a channel or thread.
+func (s *State) applyMessageCreate(e *MessageCreate) error {
+	if e.Message == nil || e.ChannelID == nil {
+		return nil
+	}
+
+	var guildID Snowflake
+	if e.GuildID != nil {
+		guildID = *e.GuildID
+	}
+
+	id := e.ID
+
+	return s.updateChannel(guildID, *e.ChannelID, func(channel *Channel) {
+		channel.LastMessageID = &id
+	})
+}
+
+// putThread puts a thread.
+//
+// The thread member of the current user is retained when the thread does not contain one.
+func (s *State) putThread(thread *Channel) error {
+	if thread == nil || !s.enabled(FlagStateCacheTHREADS) {
+		return nil
+	}
+
+	if thread.Member == nil {
+		cached, err := s.storage.Threads.Get(thread.GuildID, thread.ID)
+		if err != nil {
+			return err
+		}
+
+		if cached != nil && cached.Member != nil {
+			merged := *thread
+			merged.Member = cached.Member
+			thread = &merged
+		}
+	}
+
+	return s.storage.Threads.Put(thread.GuildID, thread.ID, thread)
+}
+
+// applyThreadListSync applies a ThreadListSync event.
+//
+// Threads of the synced parent channels (or the entire guild) that are not
+// contained in the event are no longer active and are removed.
+func (s *State) applyThreadListSync(e *ThreadListSync) error {
+	if !s.enabled(FlagStateCacheTHREADS) {
+		return nil
+	}
+
+	parents := make(map[Snowflake]bool, len(e.ChannelIDs))
+	for _, id := range e.ChannelIDs {
+		parents[id] = true
+	}
+
+	var inactive []Snowflake
+	err := s.storage.Threads.Iterate(e.GuildID, func(id Snowflake, thread *Channel) bool {
+		if len(parents) == 0 || (thread.ParentID != nil && parents[*thread.ParentID]) {
+			inactive = append(inactive, id)
+		}
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range inactive {
+		if err := s.storage.Threads.Delete(e.GuildID, id); err != nil {
+			return err
+		}
+	}
+
+	members := make(map[Snowflake]*ThreadMember, len(e.Members))
+	for _, member := range e.Members {
+		if member != nil {
+			members[member.ThreadID] = member
+		}
+	}
+
+	for _, thread := range e.Threads {
+		if thread == nil {
+			continue
+		}
+
+		cached := *thread
+		cached.GuildID = e.GuildID
+		if member, ok := members[thread.ID]; ok {
+			cached.Member = member
+		}
+
+		if err := s.storage.Threads.Put(e.GuildID, thread.ID, &cached); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyThreadMemberUpdate updates the thread member of the current user.
+func (s *State) applyThreadMemberUpdate(e *ThreadMemberUpdate) error {
+	if e.ThreadMember == nil {
+		return nil
+	}
+
+	cached, err := s.storage.Threads.Get(e.GuildID, e.ThreadID)
+	if err != nil || cached == nil {
+		return err
+	}
+
+	thread := *cached
+	thread.Member = e.ThreadMember
+
+	return s.storage.Threads.Put(e.GuildID, e.ThreadID, &thread)
+}
+
+// applyThreadMembersUpdate updates the member count of a thread, along with
+// the thread member of the current user when it's added or removed.
+func (s *State) applyThreadMembersUpdate(e *ThreadMembersUpdate) error {
+	cached, err := s.storage.Threads.Get(e.GuildID, e.ID)
+	if err != nil || cached == nil {
+		return err
+	}
+
+	thread := *cached
+	count := e.MemberCount
+	thread.MemberCount = &count
+
+	if s.user != nil {
+		for _, member := range e.AddedMembers {
+			if member != nil && member.UserID == s.user.ID {
+				thread.Member = member
+			}
+		}
+
+		for _, id := range e.RemovedMembers {
+			if id == s.user.ID {
+				thread.Member = nil
+			}
+		}
+	}
+
+	return s.storage.Threads.Put(e.GuildID, e.ID, &thread)
+}
+
+// putMember puts a guild member.
+func (s *State) putMember(guildID Snowflake, member *GuildMember) error {
+	if member == nil || member.User == nil || !s.enabled(FlagStateCacheMEMBERS) {
+		return nil
+	}
+
+	cached := *member
+	cached.GuildID = guildID
+
+	return s.storage.Members.Put(guildID, member.User.ID, &cached)
+}
+
+// applyGuildMemberUpdate applies a partial guild member.
+//
+// A Guild Member Update does not reliably contain the deaf and mute
+// fields of the member, so the cached values are retained.
+func (s *State) applyGuildMemberUpdate(member *GuildMember) error {
+	if member.User == nil || !s.enabled(FlagStateCacheMEMBERS) {
+		return nil
+	}
+
+	cached, err := s.storage.Members.Get(member.GuildID, member.User.ID)
+	if err != nil {
+		return err
+	}
+
+	merged := *member
+	if cached != nil {
+		merged.Deaf = cached.Deaf
+		merged.Mute = cached.Mute
+
+		if merged.Permissions == nil {
+			merged.Permissions = cached.Permissions
+		}
+	}
+
+	return s.storage.Members.Put(member.GuildID, member.User.ID, &merged)
+}
+
+// applyGuildMemberAdd puts a guild member, and increments the member count of its guild.
+func (s *State) applyGuildMemberAdd(e *GuildMemberAdd) error {
+	if err := s.updateMemberCount(e.GuildID, 1); err != nil {
+		return err
+	}
+
+	return s.putMember(e.GuildID, e.GuildMember)
+}
+
+// applyGuildMemberRemove deletes a guild member and its presence, and decrements
+// the member count of its guild.
+func (s *State) applyGuildMemberRemove(e *GuildMemberRemove) error {
+	if err := s.updateMemberCount(e.GuildID, -1); err != nil {
+		return err
+	}
+
+	if err := s.storage.Members.Delete(e.GuildID, e.User.ID); err != nil {
+		return err
+	}
+
+	return s.storage.Presences.Delete(e.GuildID, e.User.ID)
+}
+
+// applyGuildMembersChunk puts the members and presences of a GuildMembersChunk event.
+func (s *State) applyGuildMembersChunk(e *GuildMembersChunk) error {
+	for _, member := range e.Members {
+		if err := s.putMember(e.GuildID, member); err != nil {
+			return err
+		}
+	}
+
+	for _, presence := range e.Presences {
+		if err := s.putPresence(e.GuildID, presence); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// putRole puts a role.
+func (s *State) putRole(guildID Snowflake, role *Role) error {
+	if role == nil || !s.enabled(FlagStateCacheROLES) {
+		return nil
+	}
+
+	return s.storage.Roles.Put(guildID, role.ID, role)
+}
+
+// putRoles replaces the roles of a guild.
+func (s *State) putRoles(guildID Snowflake, roles []*Role) error {
+	if !s.enabled(FlagStateCacheROLES) {
+		return nil
+	}
+
+	if err := s.storage.Roles.DeleteGuild(guildID); err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		if err := s.putRole(guildID, role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyGuildRoleDelete deletes a role and removes it from the members of the guild.
+func (s *State) applyGuildRoleDelete(e *GuildRoleDelete) error {
+	if err := s.storage.Roles.Delete(e.GuildID, e.RoleID); err != nil {
+		return err
+	}
+
+	updated := make(map[Snowflake]*GuildMember)
+	err := s.storage.Members.Iterate(e.GuildID, func(id Snowflake, member *GuildMember) bool {
+		roles := make([]*Snowflake, 0, len(member.Roles))
+		for _, role := range member.Roles {
+			if role != nil && *role != e.RoleID {
+				roles = append(roles, role)
+			}
+		}
+
+		if len(roles) != len(member.Roles) {
+			update := *member
+			update.Roles = roles
+			updated[id] = &update
+		}
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for id, member := range updated {
+		if err := s.storage.Members.Put(e.GuildID, id, member); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// putEmojis replaces the emojis of a guild.
+func (s *State) putEmojis(guildID Snowflake, emojis []*Emoji) error {
+	if !s.enabled(FlagStateCacheEMOJIS) {
+		return nil
+	}
+
+	if err := s.storage.Emojis.DeleteGuild(guildID); err != nil {
+		return err
+	}
+
+	for _, emoji := range emojis {
+		if emoji != nil {
+			if err := s.storage.Emojis.Put(guildID, emoji.ID, emoji); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// putStickers replaces the stickers of a guild.
+func (s *State) putStickers(guildID Snowflake, stickers []*Sticker) error {
+	if !s.enabled(FlagStateCacheSTICKERS) {
+		return nil
+	}
+
+	if err := s.storage.Stickers.DeleteGuild(guildID); err != nil {
+		return err
+	}
+
+	for _, sticker := range stickers {
+		if sticker != nil {
+			if err := s.storage.Stickers.Put(guildID, sticker.ID, sticker); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyVoiceStateUpdate puts a voice state, or deletes it when the user leaves a voice channel.
+//
+// The member of the voice state is also cached.
+func (s *State) applyVoiceStateUpdate(voiceState *VoiceState) error {
+	if voiceState.Member != nil {
+		if err := s.putMember(voiceState.GuildID, voiceState.Member); err != nil {
+			return err
+		}
+	}
+
+	if !s.enabled(FlagStateCacheVOICE_STATES) {
+		return nil
+	}
+
+	if voiceState.ChannelID == nil {
+		return s.storage.VoiceStates.Delete(voiceState.GuildID, voiceState.UserID)
+	}
+
+	return s.storage.VoiceStates.Put(voiceState.GuildID, voiceState.UserID, voiceState)
+}
+
+// putPresence puts a presence, or deletes it when the user is offline.
+func (s *State) putPresence(guildID Snowflake, presence *PresenceUpdate) error {
+	if presence == nil || presence.User == nil || !s.enabled(FlagStateCachePRESENCES) {
+		return nil
+	}
+
+	if presence.Status == FlagStatusTypeOffline {
+		return s.storage.Presences.Delete(guildID, presence.User.ID)
+	}
+
+	cached := *presence
+	cached.GuildID = guildID
+
+	return s.storage.Presences.Put(guildID, presence.User.ID, &cached)
+}
+
+// all returns every entity of a guild in a store.
+func all[T any](store Store[T], guildID Snowflake) ([]*T, error) {
+	var entities []*T
+	err := store.Iterate(guildID, func(_ Snowflake, entity *T) bool {
+		entities = append(entities, entity)
+		return true
+	})
+
+	return entities, err
+}
+
+// User returns the current user.
+func (s *State) User() *User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.user
+}
+
+// Guild returns a cached guild, or nil when the guild is not cached.
+//
+// The roles, emojis, and stickers of a guild are accessed using their respective accessors.
+func (s *State) Guild(id Snowflake) (*CachedGuild, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Guilds.Get(0, id)
+}
+
+// Guilds returns the cached guilds.
+func (s *State) Guilds() ([]*CachedGuild, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Guilds, 0)
+}
+
+// Channel returns a cached channel, or nil when the channel is not cached.
+//
+// DM channels use guild ID 0.
+func (s *State) Channel(guildID, id Snowflake) (*Channel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Channels.Get(guildID, id)
+}
+
+// Channels returns the cached channels of a guild.
+func (s *State) Channels(guildID Snowflake) ([]*Channel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Channels, guildID)
+}
+
+// Thread returns a cached thread, or nil when the thread is not cached.
+func (s *State) Thread(guildID, id Snowflake) (*Channel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Threads.Get(guildID, id)
+}
+
+// Threads returns the cached threads of a guild.
+func (s *State) Threads(guildID Snowflake) ([]*Channel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Threads, guildID)
+}
+
+// Member returns a cached guild member, or nil when the member is not cached.
+func (s *State) Member(guildID, userID Snowflake) (*GuildMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Members.Get(guildID, userID)
+}
+
+// Members returns the cached members of a guild.
+func (s *State) Members(guildID Snowflake) ([]*GuildMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Members, guildID)
+}
+
+// Role returns a cached role, or nil when the role is not cached.
+func (s *State) Role(guildID, roleID Snowflake) (*Role, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Roles.Get(guildID, roleID)
+}
+
+// Roles returns the cached roles of a guild.
+func (s *State) Roles(guildID Snowflake) ([]*Role, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Roles, guildID)
+}
+
+// Emoji returns a cached emoji, or nil when the emoji is not cached.
+func (s *State) Emoji(guildID, emojiID Snowflake) (*Emoji, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Emojis.Get(guildID, emojiID)
+}
+
+// Emojis returns the cached emojis of a guild.
+func (s *State) Emojis(guildID Snowflake) ([]*Emoji, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Emojis, guildID)
+}
+
+// Sticker returns a cached sticker, or nil when the sticker is not cached.
+func (s *State) Sticker(guildID, stickerID Snowflake) (*Sticker, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Stickers.Get(guildID, stickerID)
+}
+
+// Stickers returns the cached stickers of a guild.
+func (s *State) Stickers(guildID Snowflake) ([]*Sticker, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Stickers, guildID)
+}
+
+// VoiceState returns the cached voice state of a user, or nil when the voice state is not cached.
+func (s *State) VoiceState(guildID, userID Snowflake) (*VoiceState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.VoiceStates.Get(guildID, userID)
+}
+
+// VoiceStates returns the cached voice states of a guild.
+func (s *State) VoiceStates(guildID Snowflake) ([]*VoiceState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.VoiceStates, guildID)
+}
+
+// Presence returns the cached presence of a user, or nil when the presence is not cached.
+func (s *State) Presence(guildID, userID Snowflake) (*PresenceUpdate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.storage.Presences.Get(guildID, userID)
+}
+
+// Presences returns the cached presences of a guild.
+func (s *State) Presences(guildID Snowflake) ([]*PresenceUpdate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return all(s.storage.Presences, guildID)
+}
@@ -0,0 +1,171 @@
+package dasgo
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// replayState replays a recording into a new State that caches every entity type.
+func replayState(t *testing.T, name string) *State {
+	t.Helper()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	state := NewState(FlagStateCacheALL, nil)
+	d := NewDispatcher(FlagDispatchModeSERIAL)
+	d.Use(func(next EventHandler) EventHandler {
+		return func(event Event) {
+			if err := state.Apply(event); err != nil {
+				t.Error(err)
+			}
+
+			next(event)
+		}
+	})
+
+	if err := Replay(context.Background(), f, d, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	d.Wait()
+
+	return state
+}
+
+func TestStateReplay(t *testing.T) {
+	state := replayState(t, "testdata/state.jsonl")
+
+	if user := state.User(); user == nil || user.ID != 1 {
+		t.Fatalf("expected user 1, got %v", user)
+	}
+
+	guild, err := state.Guild(100)
+	if err != nil || guild == nil || guild.Name != "Guild" {
+		t.Fatalf("expected guild 100, got %v (%v)", guild, err)
+	}
+
+	// GUILD_CREATE fields are retained on the cached guild, and GUILD_MEMBER_REMOVE updates the member count.
+	if joinedAt := time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC); !guild.JoinedAt.Equal(joinedAt) {
+		t.Errorf("expected the guild to be joined at %v, got %v", joinedAt, guild.JoinedAt)
+	}
+
+	if !guild.Large || guild.MemberCount != 1 {
+		t.Errorf("expected a large guild with 1 member, got large %v with %d members", guild.Large, guild.MemberCount)
+	}
+
+	// GUILD_ROLE_DELETE removes the role from the guild and its members.
+	roles, err := state.Roles(100)
+	if err != nil || len(roles) != 1 || roles[0].ID != 100 {
+		t.Fatalf("expected only the @everyone role, got %v (%v)", roles, err)
+	}
+
+	// GUILD_MEMBER_UPDATE replaces the member, but retains the fields it doesn't contain.
+	member, err := state.Member(100, 400)
+	if err != nil || member == nil {
+		t.Fatalf("expected member 400, got %v (%v)", member, err)
+	}
+
+	if member.Nick == nil || *member.Nick != "Updated" {
+		t.Errorf("expected nick %q, got %v", "Updated", member.Nick)
+	}
+
+	if !member.Deaf {
+		t.Error("expected the deaf field of the member to be retained")
+	}
+
+	if len(member.Roles) != 0 {
+		t.Errorf("expected the deleted role to be removed from the member, got %d roles", len(member.Roles))
+	}
+
+	// GUILD_MEMBER_REMOVE deletes the member.
+	if member, err := state.Member(100, 401); err != nil || member != nil {
+		t.Errorf("expected member 401 to be deleted, got %v (%v)", member, err)
+	}
+
+	// CHANNEL_DELETE deletes the channel.
+	channels, err := state.Channels(100)
+	if err != nil || len(channels) != 1 || channels[0].ID != 200 {
+		t.Fatalf("expected only channel 200, got %v (%v)", channels, err)
+	}
+
+	if channels[0].GuildID != 100 {
+		t.Errorf("expected the channel to be cached with guild 100, got %d", channels[0].GuildID)
+	}
+}
+
+func TestStateCacheFlags(t *testing.T) {
+	f, err := os.Open("testdata/state.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	state := NewState(FlagStateCacheGUILDS|FlagStateCacheROLES, nil)
+	payloads, err := ReadRecording(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, payload := range payloads {
+		if payload.Direction != FlagRecordingDirectionINBOUND {
+			continue
+		}
+
+		event, err := DecodeEvent(payload.EventName, payload.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := state.Apply(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if members, err := state.Members(100); err != nil || len(members) != 0 {
+		t.Errorf("expected no cached members, got %d (%v)", len(members), err)
+	}
+
+	if channels, err := state.Channels(100); err != nil || len(channels) != 0 {
+		t.Errorf("expected no cached channels, got %d (%v)", len(channels), err)
+	}
+
+	if roles, err := state.Roles(100); err != nil || len(roles) != 1 {
+		t.Errorf("expected 1 cached role, got %d (%v)", len(roles), err)
+	}
+}
+
+func TestStateGuildUpdate(t *testing.T) {
+	state := NewState(FlagStateCacheALL, nil)
+	events := []Event{
+		&GuildCreate{Guild: &Guild{ID: 1, Name: "a"}, JoinedAt: time.Unix(1, 0), Large: true, MemberCount: 10},
+		&GuildUpdate{Guild: &Guild{ID: 1, Name: "b"}},
+		&GuildMemberAdd{GuildID: 1, GuildMember: &GuildMember{User: &User{ID: 2}}},
+		&GuildDelete{Guild: &Guild{ID: 1, Unavailable: true}},
+	}
+
+	for _, event := range events {
+		if err := state.Apply(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	guild, err := state.Guild(1)
+	if err != nil || guild == nil {
+		t.Fatalf("expected guild 1, got %v (%v)", guild, err)
+	}
+
+	if guild.Name != "b" || !guild.Unavailable {
+		t.Errorf("expected the unavailable guild b, got %q (unavailable %v)", guild.Name, guild.Unavailable)
+	}
+
+	if !guild.JoinedAt.Equal(time.Unix(1, 0)) || !guild.Large || guild.MemberCount != 11 {
+		t.Errorf("expected the GuildCreate fields to be retained with 11 members, got %v, %v, %d",
+			guild.JoinedAt, guild.Large, guild.MemberCount)
+	}
+}
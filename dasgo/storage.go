@@ -0,0 +1,325 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Store represents a storage backend for an entity type, keyed by guild ID and entity ID.
+//
+// Entities that do not belong to a guild (i.e DM channels) are stored under guild ID 0.
+// A Store must be safe for concurrent use.
+type Store[T any] interface {
+	// Get returns an entity, or nil when the entity is not stored.
+	Get(guildID, id Snowflake) (*T, error)
+
+	// Put stores an entity, replacing any existing entity with the same ID.
+	Put(guildID, id Snowflake, entity *T) error
+
+	// Delete deletes an entity.
+	Delete(guildID, id Snowflake) error
+
+	// DeleteGuild deletes every entity of a guild.
+	DeleteGuild(guildID Snowflake) error
+
+	// Iterate calls fn for every entity of a guild until fn returns false.
+	//
+	// fn must not call other methods of the Store.
+	Iterate(guildID Snowflake, fn func(id Snowflake, entity *T) bool) error
+}
+
+// Storage represents the stores used by a State.
+//
+// Guilds are stored under guild ID 0.
+type Storage struct {
+	Guilds      Store[CachedGuild]
+	Channels    Store[Channel]
+	Threads     Store[Channel]
+	Members     Store[GuildMember]
+	Roles       Store[Role]
+	Emojis      Store[Emoji]
+	Stickers    Store[Sticker]
+	VoiceStates Store[VoiceState]
+	Presences   Store[PresenceUpdate]
+}
+
+// NewMemoryStorage returns a Storage that stores entities in memory.
+func NewMemoryStorage() *Storage {
+	return &Storage{
+		Guilds:      NewMemoryStore[CachedGuild](),
+		Channels:    NewMemoryStore[Channel](),
+		Threads:     NewMemoryStore[Channel](),
+		Members:     NewMemoryStore[GuildMember](),
+		Roles:       NewMemoryStore[Role](),
+		Emojis:      NewMemoryStore[Emoji](),
+		Stickers:    NewMemoryStore[Sticker](),
+		VoiceStates: NewMemoryStore[VoiceState](),
+		Presences:   NewMemoryStore[PresenceUpdate](),
+	}
+}
+
+// NewDiskStorage returns a Storage that stores entities in subdirectories of dir.
+//
+// Stored entities are retained when the Storage is reopened.
+func NewDiskStorage(dir string) (*Storage, error) {
+	storage := new(Storage)
+	stores := []struct {
+		name string
+		open func(string) error
+	}{
+		{"guilds", func(d string) (err error) { storage.Guilds, err = NewDiskStore[CachedGuild](d); return }},
+		{"channels", func(d string) (err error) { storage.Channels, err = NewDiskStore[Channel](d); return }},
+		{"threads", func(d string) (err error) { storage.Threads, err = NewDiskStore[Channel](d); return }},
+		{"members", func(d string) (err error) { storage.Members, err = NewDiskStore[GuildMember](d); return }},
+		{"roles", func(d string) (err error) { storage.Roles, err = NewDiskStore[Role](d); return }},
+		{"emojis", func(d string) (err error) { storage.Emojis, err = NewDiskStore[Emoji](d); return }},
+		{"stickers", func(d string) (err error) { storage.Stickers, err = NewDiskStore[Sticker](d); return }},
+		{"voice_states", func(d string) (err error) { storage.VoiceStates, err = NewDiskStore[VoiceState](d); return }},
+		{"presences", func(d string) (err error) { storage.Presences, err = NewDiskStore[PresenceUpdate](d); return }},
+	}
+
+	for _, store := range stores {
+		if err := store.open(filepath.Join(dir, store.name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return storage, nil
+}
+
+// MemoryStore represents a Store that stores entities in memory.
+//
+// Entities are copied when they're stored and returned, so modifying the fields of an entity
+// doesn't modify the stored entity. The copy is shallow, so slices and maps are shared.
+type MemoryStore[T any] struct {
+	mu       sync.RWMutex
+	entities map[Snowflake]map[Snowflake]*T
+}
+
+// NewMemoryStore returns a new MemoryStore.
+func NewMemoryStore[T any]() *MemoryStore[T] {
+	return &MemoryStore[T]{entities: make(map[Snowflake]map[Snowflake]*T)}
+}
+
+// Get returns an entity, or nil when the entity is not stored.
+func (s *MemoryStore[T]) Get(guildID, id Snowflake) (*T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entity, ok := s.entities[guildID][id]
+	if !ok {
+		return nil, nil
+	}
+
+	cached := *entity
+
+	return &cached, nil
+}
+
+// Put stores an entity, replacing any existing entity with the same ID.
+func (s *MemoryStore[T]) Put(guildID, id Snowflake, entity *T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entities, ok := s.entities[guildID]
+	if !ok {
+		entities = make(map[Snowflake]*T)
+		s.entities[guildID] = entities
+	}
+
+	cached := *entity
+	entities[id] = &cached
+
+	return nil
+}
+
+// Delete deletes an entity.
+func (s *MemoryStore[T]) Delete(guildID, id Snowflake) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entities[guildID], id)
+	if len(s.entities[guildID]) == 0 {
+		delete(s.entities, guildID)
+	}
+
+	return nil
+}
+
+// DeleteGuild deletes every entity of a guild.
+func (s *MemoryStore[T]) DeleteGuild(guildID Snowflake) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entities, guildID)
+
+	return nil
+}
+
+// Iterate calls fn for every entity of a guild until fn returns false.
+func (s *MemoryStore[T]) Iterate(guildID Snowflake, fn func(id Snowflake, entity *T) bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for id, entity := range s.entities[guildID] {
+		cached := *entity
+		if !fn(id, &cached) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// DiskStore represents a Store that stores entities as JSON files in a directory.
+//
+// Each guild is stored in a subdirectory, which contains a file for each entity.
+type DiskStore[T any] struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewDiskStore returns a new DiskStore that stores entities in dir, which is created when it does not exist.
+func NewDiskStore[T any](dir string) (*DiskStore[T], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating store directory: %w", err)
+	}
+
+	return &DiskStore[T]{dir: dir}, nil
+}
+
+// guildDir returns the directory of a guild.
+func (s *DiskStore[T]) guildDir(guildID Snowflake) string {
+	return filepath.Join(s.dir, strconv.FormatUint(uint64(guildID), 10))
+}
+
+// path returns the file of an entity.
+func (s *DiskStore[T]) path(guildID, id Snowflake) string {
+	return filepath.Join(s.guildDir(guildID), strconv.FormatUint(uint64(id), 10)+".json")
+}
+
+// Get returns an entity, or nil when the entity is not stored.
+func (s *DiskStore[T]) Get(guildID, id Snowflake) (*T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.read(s.path(guildID, id))
+}
+
+// read reads an entity from a file.
+func (s *DiskStore[T]) read(path string) (*T, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading entity: %w", err)
+	}
+
+	entity := new(T)
+	if err := json.Unmarshal(data, entity); err != nil {
+		return nil, fmt.Errorf("error decoding entity %s: %w", filepath.Base(path), err)
+	}
+
+	return entity, nil
+}
+
+// Put stores an entity, replacing any existing entity with the same ID.
+//
+// The entity is written to a temporary file that replaces the existing file,
+// so an interrupted write never corrupts a stored entity.
+func (s *DiskStore[T]) Put(guildID, id Snowflake, entity *T) error {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return fmt.Errorf("error encoding entity: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.guildDir(guildID), 0o755); err != nil {
+		return fmt.Errorf("error creating guild directory: %w", err)
+	}
+
+	path := s.path(guildID, id)
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return fmt.Errorf("error writing entity: %w", err)
+	}
+
+	if err := os.Rename(temp, path); err != nil {
+		return fmt.Errorf("error replacing entity: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes an entity.
+func (s *DiskStore[T]) Delete(guildID, id Snowflake) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(guildID, id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error deleting entity: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteGuild deletes every entity of a guild.
+func (s *DiskStore[T]) DeleteGuild(guildID Snowflake) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.RemoveAll(s.guildDir(guildID)); err != nil {
+		return fmt.Errorf("error deleting guild directory: %w", err)
+	}
+
+	return nil
+}
+
+// Iterate calls fn for every entity of a guild until fn returns false.
+func (s *DiskStore[T]) Iterate(guildID Snowflake, fn func(id Snowflake, entity *T) bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.guildDir(guildID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading guild directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), ".json"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		entity, err := s.read(filepath.Join(s.guildDir(guildID), entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		if entity != nil && !fn(Snowflake(id), entity) {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package dasgo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// testStore tests the behavior that every Store implements.
+func testStore(t *testing.T, store Store[Role]) {
+	t.Helper()
+
+	if role, err := store.Get(1, 10); err != nil || role != nil {
+		t.Fatalf("expected no role, got %v (%v)", role, err)
+	}
+
+	for _, id := range []Snowflake{10, 11, 12} {
+		if err := store.Put(1, id, &Role{ID: id, Name: "role"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Put(2, 20, &Role{ID: 20}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(1, 10, &Role{ID: 10, Name: "replaced"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if role, err := store.Get(1, 10); err != nil || role == nil || role.Name != "replaced" {
+		t.Fatalf("expected the replaced role, got %v (%v)", role, err)
+	}
+
+	if err := store.Delete(1, 11); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Delete(1, 99); err != nil {
+		t.Fatalf("expected deleting a missing entity to succeed, got %v", err)
+	}
+
+	var ids []int
+	if err := store.Iterate(1, func(id Snowflake, role *Role) bool {
+		if role.ID != id {
+			t.Errorf("expected role %d, got %d", id, role.ID)
+		}
+
+		ids = append(ids, int(id))
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Ints(ids)
+	if len(ids) != 2 || ids[0] != 10 || ids[1] != 12 {
+		t.Fatalf("expected roles 10 and 12, got %v", ids)
+	}
+
+	calls := 0
+	if err := store.Iterate(1, func(Snowflake, *Role) bool {
+		calls++
+		return false
+	}); err != nil || calls != 1 {
+		t.Fatalf("expected Iterate to stop after 1 call, got %d (%v)", calls, err)
+	}
+
+	if err := store.DeleteGuild(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Iterate(1, func(Snowflake, *Role) bool {
+		t.Error("expected the guild to be deleted")
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if role, err := store.Get(2, 20); err != nil || role == nil {
+		t.Fatalf("expected the role of another guild to be retained, got %v (%v)", role, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore[Role]())
+}
+
+func TestMemoryStoreCopies(t *testing.T) {
+	store := NewMemoryStore[Role]()
+
+	role := &Role{ID: 1, Name: "a"}
+	if err := store.Put(0, 1, role); err != nil {
+		t.Fatal(err)
+	}
+
+	role.Name = "modified after put"
+
+	cached, err := store.Get(0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cached.Name != "a" {
+		t.Fatalf("expected the stored role to be a copy, got %q", cached.Name)
+	}
+
+	cached.Name = "modified after get"
+
+	if err := store.Iterate(0, func(_ Snowflake, role *Role) bool {
+		if role.Name != "a" {
+			t.Errorf("expected the returned role to be a copy, got %q", role.Name)
+		}
+
+		role.Name = "modified during iterate"
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if cached, _ := store.Get(0, 1); cached.Name != "a" {
+		t.Fatalf("expected the iterated role to be a copy, got %q", cached.Name)
+	}
+}
+
+func TestDiskStore(t *testing.T) {
+	store, err := NewDiskStore[Role](filepath.Join(t.TempDir(), "roles"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testStore(t, store)
+}
+
+func TestDiskStoreReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	storage, err := NewDiskStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := NewState(FlagStateCacheALL, storage)
+	if err := state.Apply(&GuildCreate{
+		Guild:       &Guild{ID: 1, Name: "guild", Roles: []*Role{{ID: 1, Name: "@everyone"}}},
+		MemberCount: 5,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewDiskStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state = NewState(FlagStateCacheALL, reopened)
+
+	guild, err := state.Guild(1)
+	if err != nil || guild == nil || guild.Name != "guild" || guild.MemberCount != 5 {
+		t.Fatalf("expected guild 1 to survive a reopen, got %v (%v)", guild, err)
+	}
+
+	if roles, err := state.Roles(1); err != nil || len(roles) != 1 || roles[0].Name != "@everyone" {
+		t.Fatalf("expected the @everyone role to survive a reopen, got %v (%v)", roles, err)
+	}
+}
+
+func TestDiskStoreWrite(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDiskStore[Role](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(1, 10, &Role{ID: 10, Name: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the temporary file is renamed over the entity file.
+	entries, err := os.ReadDir(filepath.Join(dir, "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].Name() != "10.json" {
+		t.Fatalf("expected only 10.json, got %v", entries)
+	}
+
+	// an interrupted write leaves a temporary file, which doesn't replace the stored entity.
+	if err := os.WriteFile(filepath.Join(dir, "1", "10.json.tmp"), []byte(`{"id":"10","na`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if role, err := store.Get(1, 10); err != nil || role == nil || role.Name != "a" {
+		t.Fatalf("expected the stored role, got %v (%v)", role, err)
+	}
+
+	calls := 0
+	if err := store.Iterate(1, func(Snowflake, *Role) bool {
+		calls++
+		return true
+	}); err != nil || calls != 1 {
+		t.Fatalf("expected Iterate to skip the temporary file, got %d calls (%v)", calls, err)
+	}
+
+	// the next write replaces the temporary file.
+	if err := store.Put(1, 10, &Role{ID: 10, Name: "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "1", "10.json.tmp")); !os.IsNotExist(err) {
+		t.Fatalf("expected the temporary file to be renamed, got %v", err)
+	}
+
+	if role, err := store.Get(1, 10); err != nil || role == nil || role.Name != "b" {
+		t.Fatalf("expected the replaced role, got %v (%v)", role, err)
+	}
+}
@@ -0,0 +1,220 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/switchupcb/dasgo/dasgo/internal/jsonfield"
+)
+
+// StrictDecoder represents a decoder that reports the JSON keys which are not decoded
+// into a field of their type (i.e fields added to the Discord API after the type).
+//
+// Unknown keys are reported rather than failing a decode, so a StrictDecoder can be
+// used by a running bot: Use Unknown to log the unknown keys received for each type.
+type StrictDecoder struct {
+	// OnUnknown is called (when set) the first time an unknown key is received for a type.
+	OnUnknown func(typeName, key string)
+
+	mu      sync.Mutex
+	unknown map[string]map[string]int
+}
+
+// UnknownKeys represents the unknown keys received for a type.
+type UnknownKeys struct {
+	Type string
+
+	// Keys maps each unknown key to the number of times it is received.
+	Keys map[string]int
+}
+
+// NewStrictDecoder returns a new StrictDecoder.
+func NewStrictDecoder() *StrictDecoder {
+	return &StrictDecoder{unknown: make(map[string]map[string]int)}
+}
+
+// Decode decodes JSON data into v, and records the keys of data that are not decoded into v.
+//
+// The unknown keys of a type that embeds another type (i.e ChannelCreate) are recorded
+// for the embedded type (i.e Channel).
+func (d *StrictDecoder) Decode(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error decoding %T: %w", v, err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("error decoding %T: %w", v, err)
+	}
+
+	var found [][2]string
+	d.mu.Lock()
+	d.walk(value, reflect.ValueOf(v), &found)
+	d.mu.Unlock()
+
+	if d.OnUnknown != nil {
+		for _, unknown := range found {
+			d.OnUnknown(unknown[0], unknown[1])
+		}
+	}
+
+	return nil
+}
+
+// DecodeEvent decodes the data of a Gateway Event into the event struct of its name,
+// and records the keys of data that are not decoded into the event.
+func (d *StrictDecoder) DecodeEvent(name string, data json.RawMessage) (Event, error) {
+	event := NewEvent(name)
+	if event == nil {
+		return nil, fmt.Errorf("error decoding event: unknown event name %q", name)
+	}
+
+	if err := d.Decode(data, event); err != nil {
+		return nil, fmt.Errorf("error decoding %s event: %w", name, err)
+	}
+
+	return event, nil
+}
+
+// DecodePayload decodes a Gateway Payload and the event of a dispatch payload, and records
+// the keys of each that are not decoded.
+//
+// The returned event is nil when the payload is not a dispatch or its event name is unknown.
+func (d *StrictDecoder) DecodePayload(data []byte) (*GatewayPayload, Event, error) {
+	payload := new(GatewayPayload)
+	if err := d.Decode(data, payload); err != nil {
+		return nil, nil, err
+	}
+
+	if payload.Op == nil || *payload.Op != FlagGatewayOpcodeDispatch || NewEvent(payload.EventName) == nil {
+		return payload, nil, nil
+	}
+
+	event, err := d.DecodeEvent(payload.EventName, payload.Data)
+	if err != nil {
+		return payload, nil, err
+	}
+
+	return payload, event, nil
+}
+
+// Unknown returns the unknown keys received for each type in order of type name.
+func (d *StrictDecoder) Unknown() []*UnknownKeys {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	names := make([]string, 0, len(d.unknown))
+	for name := range d.unknown {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	unknown := make([]*UnknownKeys, len(names))
+	for i, name := range names {
+		keys := make(map[string]int, len(d.unknown[name]))
+		for key, count := range d.unknown[name] {
+			keys[key] = count
+		}
+
+		unknown[i] = &UnknownKeys{Type: name, Keys: keys}
+	}
+
+	return unknown
+}
+
+// Reset clears the unknown keys that have been received.
+func (d *StrictDecoder) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.unknown = make(map[string]map[string]int)
+}
+
+// String returns a description of the unknown keys (in order of count) received for a type.
+func (u *UnknownKeys) String() string {
+	keys := make([]string, 0, len(u.Keys))
+	for key := range u.Keys {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if u.Keys[keys[i]] != u.Keys[keys[j]] {
+			return u.Keys[keys[i]] > u.Keys[keys[j]]
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	return fmt.Sprintf("`%s` received unknown keys: %s", u.Type, strings.Join(keys, ", "))
+}
+
+// record records an unknown key of a type, and adds it to found when it is new.
+func (d *StrictDecoder) record(typeName, key string, found *[][2]string) {
+	if d.unknown == nil {
+		d.unknown = make(map[string]map[string]int)
+	}
+
+	keys, ok := d.unknown[typeName]
+	if !ok {
+		keys = make(map[string]int)
+		d.unknown[typeName] = keys
+	}
+
+	if keys[key] == 0 {
+		*found = append(*found, [2]string{typeName, key})
+	}
+
+	keys[key]++
+}
+
+// walk records the unknown keys of a decoded JSON value, using the Go value that the
+// JSON value is decoded into to determine its fields.
+func (d *StrictDecoder) walk(value interface{}, v reflect.Value, found *[][2]string) {
+	v = jsonfield.Indirect(v)
+	if !v.IsValid() {
+		return
+	}
+
+	switch x := value.(type) {
+	case map[string]interface{}:
+		switch v.Kind() {
+		case reflect.Struct:
+			fields := jsonfield.Of(v.Type())
+			for key, field := range x {
+				known, ok := fields.Keys[key]
+				if !ok {
+					d.record(fields.Name, key, found)
+					continue
+				}
+
+				if known != nil {
+					d.walk(field, known.Value(v), found)
+				}
+			}
+		case reflect.Map:
+			elements := make(map[string]reflect.Value, v.Len())
+			iterator := v.MapRange()
+			for iterator.Next() {
+				elements[fmt.Sprint(iterator.Key().Interface())] = iterator.Value()
+			}
+
+			for key, field := range x {
+				d.walk(field, elements[key], found)
+			}
+		}
+	case []interface{}:
+		if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+
+		for i := 0; i < len(x) && i < v.Len(); i++ {
+			d.walk(x[i], v.Index(i), found)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package dasgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStrictDecoder(t *testing.T) {
+	var calls [][2]string
+	d := NewStrictDecoder()
+	d.OnUnknown = func(typeName, key string) {
+		calls = append(calls, [2]string{typeName, key})
+	}
+
+	payloads := []struct {
+		data string
+		v    interface{}
+	}{
+		{data: `{"id":"1","username":"a","new_field":1}`, v: new(User)},
+		{data: `{"id":"2","username":"b","new_field":2,"other_field":true}`, v: new(User)},
+
+		// unknown keys of nested structs and slices are recorded for their type.
+		{data: `{"id":"3","author":{"id":"4","new_field":3},"mentions":[{"id":"5","new_field":4}],"new_message_field":1}`, v: new(Message)},
+
+		// unknown keys of a type that embeds another type are merged with the embedded type.
+		{data: `{"id":"6","type":0,"new_field":1}`, v: new(ChannelCreate)},
+		{data: `{"id":"7","type":0,"new_field":1}`, v: new(Channel)},
+	}
+
+	for _, payload := range payloads {
+		if err := d.Decode([]byte(payload.data), payload.v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if user := payloads[0].v.(*User); user.ID != 1 || user.Username != "a" {
+		t.Fatalf("expected the user to be decoded, got %+v", user)
+	}
+
+	want := []*UnknownKeys{
+		{Type: "Channel", Keys: map[string]int{"new_field": 2}},
+		{Type: "Message", Keys: map[string]int{"new_message_field": 1}},
+		{Type: "User", Keys: map[string]int{"new_field": 4, "other_field": 1}},
+	}
+
+	if unknown := d.Unknown(); !reflect.DeepEqual(unknown, want) {
+		t.Fatalf("expected %v, got %v", want, unknown)
+	}
+
+	// OnUnknown is called once for each unknown key of a type.
+	wantCalls := [][2]string{
+		{"User", "new_field"},
+		{"User", "other_field"},
+		{"Message", "new_message_field"},
+		{"Channel", "new_field"},
+	}
+
+	if len(calls) != len(wantCalls) {
+		t.Fatalf("expected %d calls, got %v", len(wantCalls), calls)
+	}
+
+	seen := make(map[[2]string]int)
+	for _, call := range calls {
+		seen[call]++
+	}
+
+	for _, call := range wantCalls {
+		if seen[call] != 1 {
+			t.Fatalf("expected OnUnknown to be called once for %v, got %v", call, calls)
+		}
+	}
+
+	if s := d.Unknown()[2].String(); s != "`User` received unknown keys: new_field, other_field" {
+		t.Fatalf("expected the keys in order of count, got %q", s)
+	}
+
+	d.Reset()
+	if unknown := d.Unknown(); len(unknown) != 0 {
+		t.Fatalf("expected no unknown keys after Reset, got %v", unknown)
+	}
+
+	// OnUnknown is called again for a key that is received after Reset.
+	calls = nil
+	if err := d.Decode([]byte(`{"id":"1","new_field":1}`), new(User)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 call after Reset, got %v", calls)
+	}
+}
+
+func TestStrictDecoderKnownKeys(t *testing.T) {
+	d := NewStrictDecoder()
+
+	// keys of maps, nil embedded pointers, and byte slices aren't reported.
+	tests := []struct {
+		data string
+		v    interface{}
+	}{
+		{data: `{"1":{"id":"1"},"2":{"id":"2"}}`, v: &map[string]*User{}},
+		{data: `{"id":"1","name":"guild","owner_id":"2"}`, v: new(GuildCreate)},
+		{data: `{"op":0,"d":{"new_field":1},"s":1,"t":"READY"}`, v: new(GatewayPayload)},
+	}
+
+	for _, test := range tests {
+		if err := d.Decode([]byte(test.data), test.v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if unknown := d.Unknown(); len(unknown) != 0 {
+		t.Fatalf("expected no unknown keys, got %v", unknown)
+	}
+}
+
+func TestStrictDecoderPayload(t *testing.T) {
+	d := NewStrictDecoder()
+
+	payload, event, err := d.DecodePayload([]byte(`{"op":0,"s":2,"t":"GUILD_MEMBER_ADD","d":{"guild_id":"1","user":{"id":"2","new_field":1},"new_member_field":1}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.SequenceNumber != 2 {
+		t.Fatalf("expected sequence 2, got %d", payload.SequenceNumber)
+	}
+
+	add, ok := event.(*GuildMemberAdd)
+	if !ok || add.GuildID != 1 || add.User == nil || add.User.ID != 2 {
+		t.Fatalf("expected a Guild Member Add event, got %#v", event)
+	}
+
+	want := []*UnknownKeys{
+		{Type: "GuildMember", Keys: map[string]int{"new_member_field": 1}},
+		{Type: "User", Keys: map[string]int{"new_field": 1}},
+	}
+
+	if unknown := d.Unknown(); !reflect.DeepEqual(unknown, want) {
+		t.Fatalf("expected %v, got %v", want, unknown)
+	}
+
+	// a payload with an unknown event name isn't decoded into an event.
+	if _, event, err := d.DecodePayload([]byte(`{"op":0,"s":3,"t":"NEW_EVENT","d":{}}`)); err != nil || event != nil {
+		t.Fatalf("expected no event, got %v (%v)", event, err)
+	}
+
+	if _, err := d.DecodeEvent("NEW_EVENT", []byte(`{}`)); err == nil {
+		t.Fatal("expected an error for an unknown event name")
+	}
+}
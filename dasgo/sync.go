@@ -0,0 +1,588 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ApplicationCommandClient represents a client that sends Application Command requests.
+type ApplicationCommandClient interface {
+	GetGlobalApplicationCommands(request *GetGlobalApplicationCommands) ([]*ApplicationCommand, error)
+	CreateGlobalApplicationCommand(request *CreateGlobalApplicationCommand) (*ApplicationCommand, error)
+	EditGlobalApplicationCommand(request *EditGlobalApplicationCommand) (*ApplicationCommand, error)
+	DeleteGlobalApplicationCommand(request *DeleteGlobalApplicationCommand) error
+	BulkOverwriteGlobalApplicationCommands(request *BulkOverwriteGlobalApplicationCommands) ([]*ApplicationCommand, error)
+	GetGuildApplicationCommands(request *GetGuildApplicationCommands) ([]*ApplicationCommand, error)
+	CreateGuildApplicationCommand(request *CreateGuildApplicationCommand) (*ApplicationCommand, error)
+	EditGuildApplicationCommand(request *EditGuildApplicationCommand) (*ApplicationCommand, error)
+	DeleteGuildApplicationCommand(request *DeleteGuildApplicationCommand) error
+	BulkOverwriteGuildApplicationCommands(request *BulkOverwriteGuildApplicationCommands) ([]*ApplicationCommand, error)
+}
+
+// CommandSync represents the parameters used to synchronize Application Commands.
+type CommandSync struct {
+	ApplicationID Snowflake
+
+	// GuildID is the guild to synchronize commands in (0 for global commands).
+	GuildID Snowflake
+
+	// Commands represents the desired set of commands.
+	Commands []*ApplicationCommand
+
+	// BulkOverwrite applies a non-empty plan with a single Bulk Overwrite request
+	// instead of individual create, edit, and delete requests.
+	BulkOverwrite bool
+
+	// DryRun writes the plan to Output without applying it.
+	DryRun bool
+	Output io.Writer
+}
+
+// CommandSyncPlan represents the changes required to synchronize Application Commands.
+type CommandSyncPlan struct {
+	ApplicationID Snowflake
+	GuildID       Snowflake
+	Create        []*ApplicationCommand
+	Edit          []*CommandSyncEdit
+	Delete        []*ApplicationCommand
+	Unchanged     []*ApplicationCommand
+}
+
+// CommandSyncEdit represents a registered command that differs from its desired definition.
+type CommandSyncEdit struct {
+	Current *ApplicationCommand
+	Desired *ApplicationCommand
+
+	// Changes contains the paths of the fields that differ (i.e options[0].description).
+	Changes []string
+}
+
+// SyncApplicationCommands fetches the registered commands of an application,
+// computes a plan against the desired commands, then applies the plan (unless DryRun is set).
+func SyncApplicationCommands(client ApplicationCommandClient, sync *CommandSync) (*CommandSyncPlan, error) {
+	var current []*ApplicationCommand
+	var err error
+	if sync.GuildID == 0 {
+		current, err = client.GetGlobalApplicationCommands(&GetGlobalApplicationCommands{
+			ApplicationID:     sync.ApplicationID,
+			WithLocalizations: true,
+		})
+	} else {
+		current, err = client.GetGuildApplicationCommands(&GetGuildApplicationCommands{
+			ApplicationID:     sync.ApplicationID,
+			GuildID:           sync.GuildID,
+			WithLocalizations: true,
+		})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting registered application commands: %w", err)
+	}
+
+	plan := PlanApplicationCommands(current, sync.Commands)
+	plan.ApplicationID = sync.ApplicationID
+	plan.GuildID = sync.GuildID
+
+	if sync.DryRun {
+		output := sync.Output
+		if output == nil {
+			output = os.Stdout
+		}
+
+		if _, err := io.WriteString(output, plan.String()); err != nil {
+			return plan, fmt.Errorf("error writing application command sync plan: %w", err)
+		}
+
+		return plan, nil
+	}
+
+	if plan.Empty() {
+		return plan, nil
+	}
+
+	if sync.BulkOverwrite {
+		return plan, plan.bulkOverwrite(client, sync.Commands)
+	}
+
+	return plan, plan.apply(client)
+}
+
+// PlanApplicationCommands computes the plan required to turn the current (registered)
+// commands into the desired commands.
+//
+// Commands are matched by type and name. Server-assigned fields (ID, ApplicationID, GuildID, Version)
+// are ignored, nil and empty localization maps are equivalent, and the order of
+// subcommands, subcommand groups, and channel types is irrelevant. The dm_permission
+// of a registered guild command (with a GuildID) is ignored, since it only applies to global commands.
+func PlanApplicationCommands(current, desired []*ApplicationCommand) *CommandSyncPlan {
+	plan := new(CommandSyncPlan)
+
+	registered := make(map[string]*ApplicationCommand, len(current))
+	for _, command := range current {
+		registered[commandSyncKey(command)] = command
+	}
+
+	for _, command := range desired {
+		key := commandSyncKey(command)
+		match, ok := registered[key]
+		if !ok {
+			plan.Create = append(plan.Create, command)
+			continue
+		}
+
+		delete(registered, key)
+		if changes := diffApplicationCommand(match, command); len(changes) != 0 {
+			plan.Edit = append(plan.Edit, &CommandSyncEdit{Current: match, Desired: command, Changes: changes})
+		} else {
+			plan.Unchanged = append(plan.Unchanged, match)
+		}
+	}
+
+	for _, command := range current {
+		if _, ok := registered[commandSyncKey(command)]; ok {
+			plan.Delete = append(plan.Delete, command)
+		}
+	}
+
+	return plan
+}
+
+// Empty determines whether the plan contains any changes.
+func (p *CommandSyncPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Edit) == 0 && len(p.Delete) == 0
+}
+
+// String returns a human readable representation of the plan.
+func (p *CommandSyncPlan) String() string {
+	var b strings.Builder
+
+	scope := "global"
+	if p.GuildID != 0 {
+		scope = fmt.Sprintf("guild %d", p.GuildID)
+	}
+
+	fmt.Fprintf(&b, "application command sync plan (application %d, %s):\n", p.ApplicationID, scope)
+	for _, command := range p.Create {
+		fmt.Fprintf(&b, "  + create %s %q\n", applicationCommandTypeName(command.Type), command.Name)
+	}
+
+	for _, edit := range p.Edit {
+		fmt.Fprintf(&b, "  ~ edit   %s %q (%s)\n",
+			applicationCommandTypeName(edit.Desired.Type), edit.Desired.Name, strings.Join(edit.Changes, ", "))
+	}
+
+	for _, command := range p.Delete {
+		fmt.Fprintf(&b, "  - delete %s %q\n", applicationCommandTypeName(command.Type), command.Name)
+	}
+
+	fmt.Fprintf(&b, "  %d to create, %d to edit, %d to delete, %d unchanged\n",
+		len(p.Create), len(p.Edit), len(p.Delete), len(p.Unchanged))
+
+	return b.String()
+}
+
+// apply applies the plan using individual requests.
+//
+// Commands are deleted before they are created to free command slots,
+// and dm_permission is only sent for global commands.
+func (p *CommandSyncPlan) apply(client ApplicationCommandClient) error {
+	for _, command := range p.Delete {
+		var err error
+		if p.GuildID == 0 {
+			err = client.DeleteGlobalApplicationCommand(&DeleteGlobalApplicationCommand{
+				ApplicationID: p.ApplicationID,
+				CommandID:     command.ID,
+			})
+		} else {
+			err = client.DeleteGuildApplicationCommand(&DeleteGuildApplicationCommand{
+				ApplicationID: p.ApplicationID,
+				GuildID:       p.GuildID,
+				CommandID:     command.ID,
+			})
+		}
+
+		if err != nil {
+			return fmt.Errorf("error deleting application command %q: %w", command.Name, err)
+		}
+	}
+
+	for _, edit := range p.Edit {
+		var err error
+		desired := edit.Desired
+
+		// options are sent when empty to remove the options of the current command.
+		options := desired.Options
+		if options == nil {
+			options = []*ApplicationCommandOption{}
+		}
+
+		if p.GuildID == 0 {
+			_, err = client.EditGlobalApplicationCommand(&EditGlobalApplicationCommand{
+				ApplicationID:            p.ApplicationID,
+				CommandID:                edit.Current.ID,
+				Name:                     NewOptional(desired.Name),
+				NameLocalizations:        NewNullable(desired.NameLocalizations),
+				Description:              NewOptional(desired.Description),
+				DescriptionLocalizations: NewNullable(desired.DescriptionLocalizations),
+				Options:                  NewOptional(options),
+				DefaultMemberPermissions: NullableFromPointer(desired.DefaultMemberPermissions),
+				DMPermission:             NewNullable(boolOrDefault(desired.DMPermission, true)),
+			})
+		} else {
+			_, err = client.EditGuildApplicationCommand(&EditGuildApplicationCommand{
+				ApplicationID:            p.ApplicationID,
+				GuildID:                  p.GuildID,
+				CommandID:                edit.Current.ID,
+				Name:                     NewOptional(desired.Name),
+				NameLocalizations:        NewNullable(desired.NameLocalizations),
+				Description:              NewOptional(desired.Description),
+				DescriptionLocalizations: NewNullable(desired.DescriptionLocalizations),
+				Options:                  NewOptional(options),
+				DefaultMemberPermissions: NullableFromPointer(desired.DefaultMemberPermissions),
+			})
+		}
+
+		if err != nil {
+			return fmt.Errorf("error editing application command %q: %w", desired.Name, err)
+		}
+	}
+
+	for _, command := range p.Create {
+		var err error
+		if p.GuildID == 0 {
+			_, err = client.CreateGlobalApplicationCommand(&CreateGlobalApplicationCommand{
+				ApplicationID:            p.ApplicationID,
+				Name:                     command.Name,
+				NameLocalizations:        command.NameLocalizations,
+				Description:              command.Description,
+				DescriptionLocalizations: command.DescriptionLocalizations,
+				Options:                  command.Options,
+				DefaultMemberPermissions: command.DefaultMemberPermissions,
+				DMPermission:             command.DMPermission,
+				Type:                     command.Type,
+			})
+		} else {
+			var commandType *Flag
+			if command.Type != 0 {
+				commandType = &command.Type
+			}
+
+			_, err = client.CreateGuildApplicationCommand(&CreateGuildApplicationCommand{
+				ApplicationID:            p.ApplicationID,
+				GuildID:                  p.GuildID,
+				Name:                     command.Name,
+				NameLocalizations:        command.NameLocalizations,
+				Description:              command.Description,
+				DescriptionLocalizations: command.DescriptionLocalizations,
+				Options:                  command.Options,
+				DefaultMemberPermissions: command.DefaultMemberPermissions,
+				Type:                     commandType,
+			})
+		}
+
+		if err != nil {
+			return fmt.Errorf("error creating application command %q: %w", command.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// bulkOverwrite applies the plan using a single Bulk Overwrite request.
+//
+// Matched commands keep their ID, so Discord updates them rather than recreating them.
+func (p *CommandSyncPlan) bulkOverwrite(client ApplicationCommandClient, desired []*ApplicationCommand) error {
+	ids := make(map[string]Snowflake, len(p.Edit)+len(p.Unchanged))
+	for _, edit := range p.Edit {
+		ids[commandSyncKey(edit.Current)] = edit.Current.ID
+	}
+
+	for _, command := range p.Unchanged {
+		ids[commandSyncKey(command)] = command.ID
+	}
+
+	commands := make([]*ApplicationCommand, len(desired))
+	for i, command := range desired {
+		overwrite := *command
+
+		// a command that isn't registered has no ID, which is omitted from the request.
+		overwrite.ID = ids[commandSyncKey(command)]
+		overwrite.ApplicationID = p.ApplicationID
+		overwrite.GuildID = p.GuildID
+		overwrite.Version = 0
+
+		// dm_permission only applies to global commands.
+		if p.GuildID != 0 {
+			overwrite.DMPermission = nil
+		}
+
+		commands[i] = &overwrite
+	}
+
+	var err error
+	if p.GuildID == 0 {
+		_, err = client.BulkOverwriteGlobalApplicationCommands(&BulkOverwriteGlobalApplicationCommands{
+			ApplicationID:       p.ApplicationID,
+			ApplicationCommands: commands,
+		})
+	} else {
+		_, err = client.BulkOverwriteGuildApplicationCommands(&BulkOverwriteGuildApplicationCommands{
+			ApplicationID:       p.ApplicationID,
+			GuildID:             p.GuildID,
+			ApplicationCommands: commands,
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("error overwriting application commands: %w", err)
+	}
+
+	return nil
+}
+
+// commandSyncKey returns the key used to match a desired command to a registered command.
+func commandSyncKey(command *ApplicationCommand) string {
+	commandType := command.Type
+	if commandType == 0 {
+		commandType = FlagApplicationCommandTypeCHAT_INPUT
+	}
+
+	return fmt.Sprintf("%d:%s", commandType, command.Name)
+}
+
+// applicationCommandTypeName returns the name of an Application Command Type.
+func applicationCommandTypeName(commandType Flag) string {
+	switch commandType {
+	case FlagApplicationCommandTypeUSER:
+		return "user"
+	case FlagApplicationCommandTypeMESSAGE:
+		return "message"
+	default:
+		return "chat_input"
+	}
+}
+
+// diffApplicationCommand returns the paths of the fields that differ between two commands.
+func diffApplicationCommand(current, desired *ApplicationCommand) []string {
+	var changes []string
+	if current.Description != desired.Description {
+		changes = append(changes, "description")
+	}
+
+	if !equalLocalizations(current.NameLocalizations, desired.NameLocalizations) {
+		changes = append(changes, "name_localizations")
+	}
+
+	if !equalLocalizations(current.DescriptionLocalizations, desired.DescriptionLocalizations) {
+		changes = append(changes, "description_localizations")
+	}
+
+	if stringOrEmpty(current.DefaultMemberPermissions) != stringOrEmpty(desired.DefaultMemberPermissions) {
+		changes = append(changes, "default_member_permissions")
+	}
+
+	// dm_permission only applies to global commands, so it's ignored for a registered guild command.
+	if current.GuildID == 0 && boolOrDefault(current.DMPermission, true) != boolOrDefault(desired.DMPermission, true) {
+		changes = append(changes, "dm_permission")
+	}
+
+	return append(changes, diffApplicationCommandOptions("options", current.Options, desired.Options)...)
+}
+
+// diffApplicationCommandOptions returns the paths of the fields that differ between two option lists.
+func diffApplicationCommandOptions(path string, current, desired []*ApplicationCommandOption) []string {
+	if len(current) != len(desired) {
+		return []string{path}
+	}
+
+	var changes []string
+
+	// the order of subcommands and subcommand groups is irrelevant.
+	if isSubcommandList(current) && isSubcommandList(desired) {
+		registered := make(map[string]*ApplicationCommandOption, len(current))
+		for _, option := range current {
+			registered[option.Name] = option
+		}
+
+		for i, option := range desired {
+			optionPath := fmt.Sprintf("%s[%d]", path, i)
+			match, ok := registered[option.Name]
+			if !ok {
+				changes = append(changes, optionPath)
+				continue
+			}
+
+			changes = append(changes, diffApplicationCommandOption(optionPath, match, option)...)
+		}
+
+		return changes
+	}
+
+	for i := range desired {
+		changes = append(changes, diffApplicationCommandOption(fmt.Sprintf("%s[%d]", path, i), current[i], desired[i])...)
+	}
+
+	return changes
+}
+
+// diffApplicationCommandOption returns the paths of the fields that differ between two options.
+func diffApplicationCommandOption(path string, current, desired *ApplicationCommandOption) []string {
+	var changes []string
+	if current.Type != desired.Type {
+		changes = append(changes, path+".type")
+	}
+
+	if current.Name != desired.Name {
+		changes = append(changes, path+".name")
+	}
+
+	if current.Description != desired.Description {
+		changes = append(changes, path+".description")
+	}
+
+	if !equalLocalizations(current.NameLocalizations, desired.NameLocalizations) {
+		changes = append(changes, path+".name_localizations")
+	}
+
+	if !equalLocalizations(current.DescriptionLocalizations, desired.DescriptionLocalizations) {
+		changes = append(changes, path+".description_localizations")
+	}
+
+	if boolOrDefault(current.Required, false) != boolOrDefault(desired.Required, false) {
+		changes = append(changes, path+".required")
+	}
+
+	if boolOrDefault(current.Autocomplete, false) != boolOrDefault(desired.Autocomplete, false) {
+		changes = append(changes, path+".autocomplete")
+	}
+
+	if !equalFloat(current.MinValue, desired.MinValue) {
+		changes = append(changes, path+".min_value")
+	}
+
+	if !equalFloat(current.MaxValue, desired.MaxValue) {
+		changes = append(changes, path+".max_value")
+	}
+
+	if !equalChannelTypes(current.ChannelTypes, desired.ChannelTypes) {
+		changes = append(changes, path+".channel_types")
+	}
+
+	if len(current.Choices) != len(desired.Choices) {
+		changes = append(changes, path+".choices")
+	} else {
+		for i := range desired.Choices {
+			if !equalChoice(current.Choices[i], desired.Choices[i]) {
+				changes = append(changes, fmt.Sprintf("%s.choices[%d]", path, i))
+			}
+		}
+	}
+
+	return append(changes, diffApplicationCommandOptions(path+".options", current.Options, desired.Options)...)
+}
+
+// isSubcommandList determines whether a list of options only contains subcommands or subcommand groups.
+func isSubcommandList(options []*ApplicationCommandOption) bool {
+	for _, option := range options {
+		if option.Type != FlagApplicationCommandOptionTypeSUB_COMMAND &&
+			option.Type != FlagApplicationCommandOptionTypeSUB_COMMAND_GROUP {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalLocalizations determines whether two localization maps are equal.
+//
+// A nil map is equal to an empty map.
+func equalLocalizations(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for locale, value := range a {
+		if other, ok := b[locale]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalChannelTypes determines whether two channel type lists contain the same channel types.
+func equalChannelTypes(a, b []*Flag) bool {
+	set := func(types []*Flag) []int {
+		values := make([]int, 0, len(types))
+		seen := make(map[Flag]bool, len(types))
+		for _, t := range types {
+			if t != nil && !seen[*t] {
+				seen[*t] = true
+				values = append(values, int(*t))
+			}
+		}
+
+		sort.Ints(values)
+		return values
+	}
+
+	x, y := set(a), set(b)
+	if len(x) != len(y) {
+		return false
+	}
+
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalChoice determines whether two choices are equal.
+//
+// Values are compared by their JSON representation, since a registered
+// numeric value is decoded as a float64.
+func equalChoice(a, b *ApplicationCommandOptionChoice) bool {
+	if a.Name != b.Name || !equalLocalizations(a.NameLocalizations, b.NameLocalizations) {
+		return false
+	}
+
+	x, errX := json.Marshal(a.Value)
+	y, errY := json.Marshal(b.Value)
+
+	return errX == nil && errY == nil && string(x) == string(y)
+}
+
+// equalFloat determines whether two optional floats are equal.
+func equalFloat(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// boolOrDefault returns the value of an optional bool or its default value.
+func boolOrDefault(b *bool, value bool) bool {
+	if b == nil {
+		return value
+	}
+
+	return *b
+}
+
+// stringOrEmpty returns the value of an optional string or an empty string.
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,323 @@
+package dasgo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// commandClient represents an ApplicationCommandClient that records the requests it receives.
+type commandClient struct {
+	ApplicationCommandClient
+
+	registered []*ApplicationCommand
+	requests   []interface{}
+}
+
+func (c *commandClient) GetGlobalApplicationCommands(request *GetGlobalApplicationCommands) ([]*ApplicationCommand, error) {
+	return c.registered, nil
+}
+
+func (c *commandClient) GetGuildApplicationCommands(request *GetGuildApplicationCommands) ([]*ApplicationCommand, error) {
+	return c.registered, nil
+}
+
+func (c *commandClient) CreateGlobalApplicationCommand(request *CreateGlobalApplicationCommand) (*ApplicationCommand, error) {
+	c.requests = append(c.requests, request)
+	return nil, nil
+}
+
+func (c *commandClient) CreateGuildApplicationCommand(request *CreateGuildApplicationCommand) (*ApplicationCommand, error) {
+	c.requests = append(c.requests, request)
+	return nil, nil
+}
+
+func (c *commandClient) EditGlobalApplicationCommand(request *EditGlobalApplicationCommand) (*ApplicationCommand, error) {
+	c.requests = append(c.requests, request)
+	return nil, nil
+}
+
+func (c *commandClient) EditGuildApplicationCommand(request *EditGuildApplicationCommand) (*ApplicationCommand, error) {
+	c.requests = append(c.requests, request)
+	return nil, nil
+}
+
+func (c *commandClient) DeleteGlobalApplicationCommand(request *DeleteGlobalApplicationCommand) error {
+	c.requests = append(c.requests, request)
+	return nil
+}
+
+func (c *commandClient) DeleteGuildApplicationCommand(request *DeleteGuildApplicationCommand) error {
+	c.requests = append(c.requests, request)
+	return nil
+}
+
+func (c *commandClient) BulkOverwriteGlobalApplicationCommands(request *BulkOverwriteGlobalApplicationCommands) ([]*ApplicationCommand, error) {
+	c.requests = append(c.requests, request)
+	return nil, nil
+}
+
+func (c *commandClient) BulkOverwriteGuildApplicationCommands(request *BulkOverwriteGuildApplicationCommands) ([]*ApplicationCommand, error) {
+	c.requests = append(c.requests, request)
+	return nil, nil
+}
+
+func commandNames(commands []*ApplicationCommand) []string {
+	names := make([]string, len(commands))
+	for i, command := range commands {
+		names[i] = command.Name
+	}
+
+	return names
+}
+
+func TestPlanApplicationCommands(t *testing.T) {
+	current := []*ApplicationCommand{
+		{ID: 1, Name: "ping", Description: "Replies with pong.", NameLocalizations: map[string]string{}},
+		{ID: 2, Name: "echo", Description: "Echoes a message."},
+		{ID: 3, Name: "old", Description: "Deleted."},
+		{ID: 4, Type: FlagApplicationCommandTypeUSER, Name: "info"},
+	}
+
+	desired := []*ApplicationCommand{
+		{Name: "ping", Description: "Replies with pong."},
+		{Name: "echo", Description: "Echoes a message!"},
+		{Name: "new", Description: "Created."},
+		{Type: FlagApplicationCommandTypeUSER, Name: "info"},
+		{Type: FlagApplicationCommandTypeMESSAGE, Name: "info"},
+	}
+
+	plan := PlanApplicationCommands(current, desired)
+	if names := commandNames(plan.Create); !reflect.DeepEqual(names, []string{"new", "info"}) {
+		t.Errorf("expected create %q, got %q", []string{"new", "info"}, names)
+	}
+
+	if len(plan.Edit) != 1 || plan.Edit[0].Current.ID != 2 || !reflect.DeepEqual(plan.Edit[0].Changes, []string{"description"}) {
+		t.Errorf("expected the description of echo to be edited, got %+v", plan.Edit)
+	}
+
+	if names := commandNames(plan.Delete); !reflect.DeepEqual(names, []string{"old"}) {
+		t.Errorf("expected delete %q, got %q", []string{"old"}, names)
+	}
+
+	if names := commandNames(plan.Unchanged); !reflect.DeepEqual(names, []string{"ping", "info"}) {
+		t.Errorf("expected unchanged %q, got %q", []string{"ping", "info"}, names)
+	}
+
+	if plan.Empty() {
+		t.Error("expected a non-empty plan")
+	}
+
+	if plan := PlanApplicationCommands(current[:2], desired[:1]); plan.Empty() {
+		t.Error("expected a plan that deletes echo")
+	}
+}
+
+func TestDiffApplicationCommand(t *testing.T) {
+	enabled, disabled := true, false
+	permissions := "8"
+
+	subcommand := func(name, description string) *ApplicationCommandOption {
+		return &ApplicationCommandOption{Type: FlagApplicationCommandOptionTypeSUB_COMMAND, Name: name, Description: description}
+	}
+
+	channelTypes := func(types ...Flag) []*Flag {
+		flags := make([]*Flag, len(types))
+		for i := range types {
+			flags[i] = &types[i]
+		}
+
+		return flags
+	}
+
+	tests := []struct {
+		name             string
+		current, desired *ApplicationCommand
+		changes          []string
+	}{
+		{
+			name:    "nil and empty localizations",
+			current: &ApplicationCommand{Name: "a", NameLocalizations: map[string]string{}},
+			desired: &ApplicationCommand{Name: "a"},
+		},
+		{
+			name:    "localizations",
+			current: &ApplicationCommand{Name: "a", DescriptionLocalizations: map[string]string{FlagLocalesGerman: "a"}},
+			desired: &ApplicationCommand{Name: "a", DescriptionLocalizations: map[string]string{FlagLocalesGerman: "b"}},
+			changes: []string{"description_localizations"},
+		},
+		{
+			name:    "default member permissions",
+			current: &ApplicationCommand{Name: "a"},
+			desired: &ApplicationCommand{Name: "a", DefaultMemberPermissions: &permissions},
+			changes: []string{"default_member_permissions"},
+		},
+		{
+			name:    "default dm permission",
+			current: &ApplicationCommand{Name: "a", DMPermission: &enabled},
+			desired: &ApplicationCommand{Name: "a"},
+		},
+		{
+			name:    "global dm permission",
+			current: &ApplicationCommand{Name: "a"},
+			desired: &ApplicationCommand{Name: "a", DMPermission: &disabled},
+			changes: []string{"dm_permission"},
+		},
+		{
+			name:    "guild dm permission",
+			current: &ApplicationCommand{Name: "a", GuildID: 1},
+			desired: &ApplicationCommand{Name: "a", DMPermission: &disabled},
+		},
+		{
+			name:    "subcommand order",
+			current: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{subcommand("x", "x"), subcommand("y", "y")}},
+			desired: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{subcommand("y", "y"), subcommand("x", "x")}},
+		},
+		{
+			name:    "subcommand description",
+			current: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{subcommand("x", "x"), subcommand("y", "y")}},
+			desired: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{subcommand("y", "z"), subcommand("x", "x")}},
+			changes: []string{"options[0].description"},
+		},
+		{
+			name:    "option order",
+			current: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{testOption("x", FlagApplicationCommandOptionTypeSTRING), testOption("y", FlagApplicationCommandOptionTypeSTRING)}},
+			desired: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{testOption("y", FlagApplicationCommandOptionTypeSTRING), testOption("x", FlagApplicationCommandOptionTypeSTRING)}},
+			changes: []string{"options[0].name", "options[1].name"},
+		},
+		{
+			name:    "option count",
+			current: &ApplicationCommand{Name: "a"},
+			desired: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{testOption("x", FlagApplicationCommandOptionTypeSTRING)}},
+			changes: []string{"options"},
+		},
+		{
+			name: "channel type order",
+			current: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{{
+				Type: FlagApplicationCommandOptionTypeCHANNEL, Name: "c", ChannelTypes: channelTypes(0, 2),
+			}}},
+			desired: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{{
+				Type: FlagApplicationCommandOptionTypeCHANNEL, Name: "c", ChannelTypes: channelTypes(2, 0),
+			}}},
+		},
+		{
+			name: "decoded choice value",
+			current: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{{
+				Type: FlagApplicationCommandOptionTypeINTEGER, Name: "n", Choices: []*ApplicationCommandOptionChoice{testChoice("one", float64(1))},
+			}}},
+			desired: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{{
+				Type: FlagApplicationCommandOptionTypeINTEGER, Name: "n", Choices: []*ApplicationCommandOptionChoice{testChoice("one", 1)},
+			}}},
+		},
+		{
+			name: "choice value",
+			current: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{{
+				Type: FlagApplicationCommandOptionTypeINTEGER, Name: "n", Choices: []*ApplicationCommandOptionChoice{testChoice("one", float64(1))},
+			}}},
+			desired: &ApplicationCommand{Name: "a", Options: []*ApplicationCommandOption{{
+				Type: FlagApplicationCommandOptionTypeINTEGER, Name: "n", Choices: []*ApplicationCommandOptionChoice{testChoice("one", 2)},
+			}}},
+			changes: []string{"options[0].choices[0]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if changes := diffApplicationCommand(test.current, test.desired); !reflect.DeepEqual(changes, test.changes) {
+				t.Fatalf("expected changes %q, got %q", test.changes, changes)
+			}
+		})
+	}
+}
+
+func TestSyncApplicationCommandsDMPermission(t *testing.T) {
+	disabled := false
+	desired := []*ApplicationCommand{
+		{Name: "ping", Description: "Replies with pong!", DMPermission: &disabled},
+		{Name: "new", Description: "Created.", DMPermission: &disabled},
+	}
+
+	for _, guildID := range []Snowflake{0, 100} {
+		client := &commandClient{registered: []*ApplicationCommand{
+			{ID: 1, GuildID: guildID, Name: "ping", Description: "Replies with pong."},
+		}}
+
+		if _, err := SyncApplicationCommands(client, &CommandSync{ApplicationID: 1, GuildID: guildID, Commands: desired}); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(client.requests) != 2 {
+			t.Fatalf("expected an edit and a create request, got %d requests", len(client.requests))
+		}
+
+		for _, request := range client.requests {
+			payload, err := jsonPayload(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(payload, &fields); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, ok := fields["dm_permission"]; ok != (guildID == 0) {
+				t.Errorf("expected dm_permission to be sent only for global commands (guild %d): %s", guildID, payload)
+			}
+		}
+	}
+}
+
+func TestSyncApplicationCommandsBulkOverwrite(t *testing.T) {
+	disabled := false
+	desired := []*ApplicationCommand{
+		{Name: "ping", Description: "Replies with pong!", DMPermission: &disabled},
+		{Name: "new", Description: "Created."},
+	}
+
+	for _, guildID := range []Snowflake{0, 100} {
+		client := &commandClient{registered: []*ApplicationCommand{
+			{ID: 1, GuildID: guildID, Name: "ping", Description: "Replies with pong.", Version: 5},
+			{ID: 2, GuildID: guildID, Name: "old", Description: "Deleted."},
+		}}
+
+		sync := &CommandSync{ApplicationID: 10, GuildID: guildID, Commands: desired, BulkOverwrite: true}
+		if _, err := SyncApplicationCommands(client, sync); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(client.requests) != 1 {
+			t.Fatalf("expected a single bulk overwrite request, got %d requests", len(client.requests))
+		}
+
+		payload, err := jsonPayload(client.requests[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var commands []map[string]json.RawMessage
+		if err := json.Unmarshal(payload, &commands); err != nil {
+			t.Fatalf("expected a bare array of commands, got %s", payload)
+		}
+
+		if len(commands) != 2 {
+			t.Fatalf("expected 2 commands, got %s", payload)
+		}
+
+		if id := string(commands[0]["id"]); id != `"1"` {
+			t.Errorf("expected the registered command to keep its ID, got %s", id)
+		}
+
+		if _, ok := commands[1]["id"]; ok {
+			t.Errorf("expected the ID of a new command to be omitted, got %s", payload)
+		}
+
+		if _, ok := commands[0]["version"]; ok {
+			t.Errorf("expected the version to be omitted, got %s", payload)
+		}
+
+		if _, ok := commands[0]["dm_permission"]; ok != (guildID == 0) {
+			t.Errorf("expected dm_permission to be sent only for global commands (guild %d): %s", guildID, payload)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// timestampLayout represents the layout that Discord uses to encode an ISO8601 timestamp.
+const timestampLayout = "2006-01-02T15:04:05.000000+00:00"
+
+// timestampLayouts represents the layouts of the ISO8601 timestamps that Discord sends.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+}
+
+// Timestamp represents a nullable ISO8601 timestamp, which is null when it is zero.
+//
+// A Timestamp embeds a time.Time, so it can be used as one (i.e t.Before(time.Now())).
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns a Timestamp of t.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// Valid returns whether a Timestamp is set (non-null).
+func (t Timestamp) Valid() bool {
+	return !t.IsZero()
+}
+
+// Markup returns the message formatting of a Timestamp with a Timestamp Style (i.e <t:1618953630:R>).
+//
+// An empty style uses the default style (FlagTimestampStyleShortDateTime),
+// and a null Timestamp returns an empty string.
+func (t Timestamp) Markup(style string) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return FormatTimestamp(t.Time, style)
+}
+
+// MarshalJSON marshals a Timestamp into an ISO8601 timestamp, or null when it is zero.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.Quote(t.UTC().Format(timestampLayout))), nil
+}
+
+// UnmarshalJSON unmarshals an ISO8601 timestamp into a Timestamp, which is zero when
+// the timestamp is null or empty.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("error decoding timestamp %s: %w", data, err)
+	}
+
+	if s == "" {
+		*t = Timestamp{}
+
+		return nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			*t = Timestamp{Time: parsed}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("error decoding timestamp %q: unknown ISO8601 format", s)
+}
+
+// Milliseconds represents a duration which is encoded as an integer number of milliseconds.
+type Milliseconds time.Duration
+
+// Duration returns the time.Duration of a Milliseconds.
+func (d Milliseconds) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// MarshalJSON marshals a Milliseconds into an integer number of milliseconds.
+func (d Milliseconds) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(time.Duration(d).Milliseconds(), 10)), nil
+}
+
+// UnmarshalJSON unmarshals a number of milliseconds into a Milliseconds.
+func (d *Milliseconds) UnmarshalJSON(data []byte) error {
+	duration, err := unmarshalDuration(data, time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	*d = Milliseconds(duration)
+
+	return nil
+}
+
+// Seconds represents a duration which is encoded as an integer number of seconds.
+type Seconds time.Duration
+
+// Duration returns the time.Duration of a Seconds.
+func (d Seconds) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// MarshalJSON marshals a Seconds into an integer number of seconds.
+func (d Seconds) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(time.Duration(d)/time.Second), 10)), nil
+}
+
+// UnmarshalJSON unmarshals a number of seconds into a Seconds.
+func (d *Seconds) UnmarshalJSON(data []byte) error {
+	duration, err := unmarshalDuration(data, time.Second)
+	if err != nil {
+		return err
+	}
+
+	*d = Seconds(duration)
+
+	return nil
+}
+
+// unmarshalDuration unmarshals a JSON number of units into a time.Duration,
+// which is zero when the number is null.
+func unmarshalDuration(data []byte, unit time.Duration) (time.Duration, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return 0, nil
+	}
+
+	number, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return 0, fmt.Errorf("error decoding duration %s: %w", data, err)
+	}
+
+	return time.Duration(number * float64(unit)), nil
+}
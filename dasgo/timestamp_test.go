@@ -0,0 +1,164 @@
+package dasgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	want := time.Date(2021, 4, 20, 21, 20, 30, 123456000, time.UTC)
+	tests := []struct {
+		name string
+		json string
+		want time.Time
+	}{
+		{name: "discord", json: `"2021-04-20T21:20:30.123456+00:00"`, want: want},
+		{name: "rfc3339", json: `"2021-04-20T21:20:30.123456Z"`, want: want},
+		{name: "offset", json: `"2021-04-20T23:20:30.123456+02:00"`, want: want},
+		{name: "without fraction", json: `"2021-04-20T21:20:30+00:00"`, want: want.Truncate(time.Second)},
+		{name: "without zone", json: `"2021-04-20T21:20:30.123456"`, want: want},
+		{name: "space", json: `"2021-04-20 21:20:30.123456+00:00"`, want: want},
+		{name: "null", json: `null`},
+		{name: "empty", json: `""`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var timestamp Timestamp
+			if err := json.Unmarshal([]byte(test.json), &timestamp); err != nil {
+				t.Fatal(err)
+			}
+
+			if !timestamp.Equal(test.want) || timestamp.Valid() == test.want.IsZero() {
+				t.Fatalf("expected %v, got %v", test.want, timestamp.Time)
+			}
+		})
+	}
+
+	for _, data := range []string{`"20 April 2021"`, `1618953630`} {
+		var timestamp Timestamp
+		if err := json.Unmarshal([]byte(data), &timestamp); err == nil {
+			t.Errorf("expected an error for %s, got %v", data, timestamp.Time)
+		}
+	}
+}
+
+func TestTimestampMarshal(t *testing.T) {
+	zone := time.FixedZone("", 2*60*60)
+	timestamp := NewTimestamp(time.Date(2021, 4, 20, 23, 20, 30, 123456789, zone))
+
+	data, err := json.Marshal(timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `"2021-04-20T21:20:30.123456+00:00"`; string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+
+	if data, err := json.Marshal(Timestamp{}); err != nil || string(data) != "null" {
+		t.Fatalf("expected null, got %s (%v)", data, err)
+	}
+}
+
+func TestTimestampNull(t *testing.T) {
+	// a null timestamp is encoded as null, rather than omitted.
+	data, err := json.Marshal(&ChannelPinsUpdate{ChannelID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"channel_id":"1","last_pin_timestamp":null}`; string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+
+	var update ChannelPinsUpdate
+	if err := json.Unmarshal(data, &update); err != nil || update.LastPinTimestamp.Valid() {
+		t.Fatalf("expected a null timestamp, got %v (%v)", update.LastPinTimestamp.Time, err)
+	}
+
+	update.LastPinTimestamp = NewTimestamp(time.Unix(1618953630, 0))
+	if data, err = json.Marshal(&update); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded ChannelPinsUpdate
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.LastPinTimestamp.Equal(update.LastPinTimestamp.Time) {
+		t.Fatalf("expected %v, got %v (%v)", update.LastPinTimestamp.Time, decoded.LastPinTimestamp.Time, err)
+	}
+}
+
+func TestTimestampMarkup(t *testing.T) {
+	timestamp := NewTimestamp(time.Unix(1618953630, 0))
+
+	if markup := timestamp.Markup(""); markup != "<t:1618953630>" {
+		t.Errorf("expected <t:1618953630>, got %q", markup)
+	}
+
+	if markup := timestamp.Markup(FlagTimestampStyleRelativeTime); markup != "<t:1618953630:R>" {
+		t.Errorf("expected <t:1618953630:R>, got %q", markup)
+	}
+
+	if markup := (Timestamp{}).Markup(FlagTimestampStyleRelativeTime); markup != "" {
+		t.Errorf("expected a null timestamp to have no markup, got %q", markup)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		json     string
+		decoded  interface{}
+		duration time.Duration
+	}{
+		{name: "milliseconds", value: Milliseconds(41250 * time.Millisecond), json: `41250`, decoded: new(Milliseconds), duration: 41250 * time.Millisecond},
+		{name: "truncated milliseconds", value: Milliseconds(1500 * time.Microsecond), json: `1`, decoded: new(Milliseconds), duration: time.Millisecond},
+		{name: "seconds", value: Seconds(90 * time.Second), json: `90`, decoded: new(Seconds), duration: 90 * time.Second},
+		{name: "truncated seconds", value: Seconds(1500 * time.Millisecond), json: `1`, decoded: new(Seconds), duration: time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != test.json {
+				t.Fatalf("expected %s, got %s", test.json, data)
+			}
+
+			if err := json.Unmarshal(data, test.decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			var duration time.Duration
+			switch decoded := test.decoded.(type) {
+			case *Milliseconds:
+				duration = decoded.Duration()
+			case *Seconds:
+				duration = decoded.Duration()
+			}
+
+			if duration != test.duration {
+				t.Fatalf("expected %v, got %v", test.duration, duration)
+			}
+		})
+	}
+
+	// fractional and null numbers are decoded.
+	var retryAfter Seconds
+	if err := json.Unmarshal([]byte(`1.5`), &retryAfter); err != nil || retryAfter.Duration() != 1500*time.Millisecond {
+		t.Fatalf("expected 1.5s, got %v (%v)", retryAfter.Duration(), err)
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &retryAfter); err != nil || retryAfter != 0 {
+		t.Fatalf("expected 0, got %v (%v)", retryAfter.Duration(), err)
+	}
+
+	if err := json.Unmarshal([]byte(`"1"`), &retryAfter); err == nil {
+		t.Fatal("expected an error for a string")
+	}
+}
@@ -0,0 +1,324 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTokenNotFound represents an error returned when a user does not have a stored token.
+var ErrTokenNotFound = errors.New("token not found")
+
+// defaultRefreshBefore represents the default duration before expiry that a token is refreshed.
+const defaultRefreshBefore = time.Minute
+
+// bearerEndpoints represents the endpoints that a TokenTransport authorizes with a Bearer token.
+var bearerEndpoints = []string{
+	EndpointGetCurrentUserGuilds,
+	EndpointGetUserConnections,
+	EndpointGetCurrentAuthorizationInformation,
+}
+
+// Token represents the OAuth2 access token of a user, which tracks its absolute expiry.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+
+	// Expiry represents the time the access token expires, which is zero when it does not expire.
+	Expiry time.Time `json:"expiry"`
+}
+
+// NewToken returns the Token of an access token response received at a time.
+func NewToken(response *AccessTokenResponse, received time.Time) *Token {
+	token := &Token{
+		AccessToken:  response.AccessToken,
+		TokenType:    response.TokenType,
+		RefreshToken: response.RefreshToken,
+		Scope:        response.Scope,
+	}
+
+	if response.ExpiresIn != 0 {
+		token.Expiry = received.Add(response.ExpiresIn.Duration())
+	}
+
+	return token
+}
+
+// Expired returns whether a token is expired at a time.
+func (t *Token) Expired(now time.Time) bool {
+	return !t.Expiry.IsZero() && !now.Before(t.Expiry)
+}
+
+// Authorization returns the value of the Authorization header of a token (i.e Bearer TOKEN).
+func (t *Token) Authorization() string {
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	return tokenType + " " + t.AccessToken
+}
+
+// TokenManager represents a manager of user OAuth2 tokens, which refreshes tokens before they expire.
+//
+// Tokens are stored by user ID (under guild ID 0) in a Store.
+type TokenManager struct {
+	Client *OAuth2Client
+	Store  Store[Token]
+
+	// RefreshBefore represents the duration before expiry that a token is refreshed
+	// (one minute when zero).
+	RefreshBefore time.Duration
+
+	// OnDrop is called (when set) with the ID of a user whose token is dropped because
+	// its refresh token is invalid (i.e the user deauthorized the application).
+	OnDrop func(userID Snowflake)
+
+	mu        sync.Mutex
+	refreshes map[Snowflake]*tokenRefresh
+}
+
+// tokenRefresh represents an in-flight refresh of a user's token, which is shared
+// by every caller that requests the token during the refresh.
+type tokenRefresh struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// NewTokenManager returns a new TokenManager, which stores tokens in memory when store is nil.
+func NewTokenManager(client *OAuth2Client, store Store[Token]) *TokenManager {
+	if store == nil {
+		store = NewMemoryStore[Token]()
+	}
+
+	return &TokenManager{Client: client, Store: store}
+}
+
+// Put stores the token of an access token response for a user, and returns it.
+func (m *TokenManager) Put(userID Snowflake, response *AccessTokenResponse) (*Token, error) {
+	token := NewToken(response, time.Now())
+	if err := m.Store.Put(0, userID, token); err != nil {
+		return nil, fmt.Errorf("error storing token of user %d: %w", userID, err)
+	}
+
+	return token, nil
+}
+
+// Token returns the token of a user, which is refreshed when it expires within RefreshBefore.
+//
+// Concurrent calls for the same user share a single refresh. A token that fails to refresh
+// with an invalid_grant error is deleted, while a token that fails to refresh otherwise is
+// returned until it expires.
+func (m *TokenManager) Token(ctx context.Context, userID Snowflake) (*Token, error) {
+	refreshBefore := m.RefreshBefore
+	if refreshBefore == 0 {
+		refreshBefore = defaultRefreshBefore
+	}
+
+	token, due, err := m.token(userID, refreshBefore)
+	if err != nil || !due {
+		return token, err
+	}
+
+	m.mu.Lock()
+	if m.refreshes == nil {
+		m.refreshes = make(map[Snowflake]*tokenRefresh)
+	}
+
+	refresh, ok := m.refreshes[userID]
+	if !ok {
+		refresh = &tokenRefresh{done: make(chan struct{})}
+		m.refreshes[userID] = refresh
+
+		go m.refresh(userID, refreshBefore, refresh)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-refresh.done:
+		return refresh.token, refresh.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// token returns the stored token of a user, and whether it's due to be refreshed
+// (i.e it expires within refreshBefore).
+func (m *TokenManager) token(userID Snowflake, refreshBefore time.Duration) (*Token, bool, error) {
+	token, err := m.Store.Get(0, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting token of user %d: %w", userID, err)
+	}
+
+	if token == nil {
+		return nil, false, fmt.Errorf("error getting token of user %d: %w", userID, ErrTokenNotFound)
+	}
+
+	if token.Expired(time.Now().Add(refreshBefore)) && token.RefreshToken != "" {
+		return token, true, nil
+	}
+
+	if token.Expired(time.Now()) {
+		return nil, false, fmt.Errorf("error getting token of user %d: token is expired and can't be refreshed", userID)
+	}
+
+	return token, false, nil
+}
+
+// refresh refreshes the token of a user (when it's due) and completes the refresh.
+//
+// The refresh is not canceled with the context of a caller, since it's shared by every caller.
+func (m *TokenManager) refresh(userID Snowflake, refreshBefore time.Duration, refresh *tokenRefresh) {
+	defer func() {
+		m.mu.Lock()
+		delete(m.refreshes, userID)
+		m.mu.Unlock()
+
+		close(refresh.done)
+	}()
+
+	// the token is read again once the refresh is in flight (without holding the lock),
+	// since a refresh that completes after a caller reads the token rotates its refresh token.
+	token, due, err := m.token(userID, refreshBefore)
+	if err != nil || !due {
+		refresh.token, refresh.err = token, err
+
+		return
+	}
+
+	response, err := m.Client.Refresh(context.Background(), token.RefreshToken)
+	if err != nil {
+		var oauth2Error *OAuth2Error
+		if errors.As(err, &oauth2Error) && oauth2Error.Code == "invalid_grant" {
+			if deleteErr := m.Store.Delete(0, userID); deleteErr != nil {
+				err = fmt.Errorf("%w (error deleting token: %v)", err, deleteErr)
+			}
+
+			if m.OnDrop != nil {
+				m.OnDrop(userID)
+			}
+
+			refresh.err = fmt.Errorf("error refreshing token of user %d: %w", userID, err)
+
+			return
+		}
+
+		if !token.Expired(time.Now()) {
+			refresh.token = token
+
+			return
+		}
+
+		refresh.err = fmt.Errorf("error refreshing token of user %d: %w", userID, err)
+
+		return
+	}
+
+	refreshed := NewToken(response, time.Now())
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	if err := m.Store.Put(0, userID, refreshed); err != nil {
+		refresh.err = fmt.Errorf("error storing token of user %d: %w", userID, err)
+
+		return
+	}
+
+	refresh.token = refreshed
+}
+
+// Revoke revokes the token of a user and deletes it.
+func (m *TokenManager) Revoke(ctx context.Context, userID Snowflake) error {
+	token, err := m.Store.Get(0, userID)
+	if err != nil {
+		return fmt.Errorf("error getting token of user %d: %w", userID, err)
+	}
+
+	if token == nil {
+		return nil
+	}
+
+	revoke, hint := token.AccessToken, FlagOAuth2TokenTypeHintAccessToken
+	if token.RefreshToken != "" {
+		revoke, hint = token.RefreshToken, FlagOAuth2TokenTypeHintRefreshToken
+	}
+
+	if err := m.Client.Revoke(ctx, revoke, hint); err != nil {
+		return err
+	}
+
+	if err := m.Store.Delete(0, userID); err != nil {
+		return fmt.Errorf("error deleting token of user %d: %w", userID, err)
+	}
+
+	return nil
+}
+
+// tokenUserKey represents the context key of the user whose token authorizes a request.
+type tokenUserKey struct{}
+
+// WithTokenUser returns a context that authorizes the requests of a TokenTransport
+// with the token of a user.
+func WithTokenUser(ctx context.Context, userID Snowflake) context.Context {
+	return context.WithValue(ctx, tokenUserKey{}, userID)
+}
+
+// TokenTransport represents an http.RoundTripper that authorizes requests to the OAuth2
+// user endpoints (Get Current User Guilds, Get User Connections, and Get Current Authorization
+// Information) with the Bearer token of the user in the request's context (WithTokenUser).
+//
+// Other requests and requests without a user are sent unmodified.
+type TokenTransport struct {
+	Manager *TokenManager
+
+	// Base represents the RoundTripper used to send requests (http.DefaultTransport when nil).
+	Base http.RoundTripper
+}
+
+// RoundTrip sends a request with the Bearer token of the user in the request's context.
+func (t *TokenTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	userID, ok := request.Context().Value(tokenUserKey{}).(Snowflake)
+	if !ok || !isBearerEndpoint(request.URL.Path) {
+		return base.RoundTrip(request)
+	}
+
+	token, err := t.Manager.Token(request.Context(), userID)
+	if err != nil {
+		if request.Body != nil {
+			request.Body.Close()
+		}
+
+		return nil, err
+	}
+
+	authorized := request.Clone(request.Context())
+	authorized.Header.Set("Authorization", token.Authorization())
+
+	return base.RoundTrip(authorized)
+}
+
+// isBearerEndpoint returns whether a URL path refers to an endpoint that is authorized with a Bearer token.
+func isBearerEndpoint(path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	for _, endpoint := range bearerEndpoints {
+		if strings.HasSuffix(path, "/"+endpoint) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,269 @@
+package dasgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+	"github.com/switchupcb/dasgo/dasgo/dasgotest"
+)
+
+// refreshTransport represents an http.RoundTripper that counts (and delays or fails)
+// the requests sent to the OAuth2 token endpoint.
+type refreshTransport struct {
+	refreshes int32
+	delay     time.Duration
+	err       error
+}
+
+// RoundTrip sends a request with http.DefaultTransport.
+func (t *refreshTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(request.URL.Path, "/"+dasgo.EndpointTokenURL) {
+		atomic.AddInt32(&t.refreshes, 1)
+		time.Sleep(t.delay)
+
+		if t.err != nil {
+			return nil, t.err
+		}
+	}
+
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+// readStore represents a Store that calls a hook after it reads a token.
+type readStore struct {
+	dasgo.Store[dasgo.Token]
+	read func()
+}
+
+// Get returns a token, and calls the hook after the token is read.
+func (s *readStore) Get(guildID, id dasgo.Snowflake) (*dasgo.Token, error) {
+	token, err := s.Store.Get(guildID, id)
+	if s.read != nil {
+		s.read()
+	}
+
+	return token, err
+}
+
+// tokenManager returns a TokenManager for the OAuth2 client of a fake server, and stores
+// a token that is due to be refreshed for user 1.
+func tokenManager(t *testing.T, store dasgo.Store[dasgo.Token]) (*dasgo.TokenManager, *refreshTransport, *dasgo.Token) {
+	t.Helper()
+
+	s, client := oauth2Client(t)
+	transport := new(refreshTransport)
+	client.HTTPClient = &http.Client{Transport: transport}
+
+	response, err := client.Exchange(context.Background(), s.Authorize(&dasgotest.Grant{RedirectURI: client.RedirectURI}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	atomic.StoreInt32(&transport.refreshes, 0)
+
+	// the token expires within the default RefreshBefore.
+	response.ExpiresIn = dasgo.Seconds(30 * time.Second)
+
+	m := dasgo.NewTokenManager(client, store)
+	token, err := m.Put(1, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m, transport, token
+}
+
+func TestTokenManagerToken(t *testing.T) {
+	m, transport, _ := tokenManager(t, nil)
+	m.RefreshBefore = time.Second
+
+	// a token that doesn't expire within RefreshBefore isn't refreshed.
+	token, err := m.Token(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transport.refreshes != 0 || token.Authorization() != "Bearer "+token.AccessToken {
+		t.Fatalf("expected the stored token without a refresh, got %d refreshes", transport.refreshes)
+	}
+
+	if _, err := m.Token(context.Background(), 2); !errors.Is(err, dasgo.ErrTokenNotFound) {
+		t.Fatalf("expected ErrTokenNotFound, got %v", err)
+	}
+}
+
+func TestTokenManagerRefresh(t *testing.T) {
+	m, transport, stored := tokenManager(t, nil)
+	transport.delay = 50 * time.Millisecond
+
+	// concurrent callers share a single refresh.
+	const callers = 10
+	tokens := make([]*dasgo.Token, callers)
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], errs[i] = m.Token(context.Background(), 1)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if refreshes := atomic.LoadInt32(&transport.refreshes); refreshes != 1 {
+		t.Fatalf("expected 1 refresh, got %d", refreshes)
+	}
+
+	for i := range tokens {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+
+		if tokens[i].AccessToken == stored.AccessToken || tokens[i].AccessToken != tokens[0].AccessToken {
+			t.Fatalf("expected every caller to receive the refreshed token, got %q", tokens[i].AccessToken)
+		}
+	}
+
+	if refreshed, err := m.Store.Get(0, 1); err != nil || refreshed.AccessToken != tokens[0].AccessToken {
+		t.Fatalf("expected the refreshed token to be stored, got %v (%v)", refreshed, err)
+	}
+}
+
+func TestTokenManagerRefreshReread(t *testing.T) {
+	store := &readStore{Store: dasgo.NewMemoryStore[dasgo.Token]()}
+	m, transport, stored := tokenManager(t, store)
+
+	// another caller refreshes the token after the first caller reads it, which rotates
+	// the refresh token that the first caller read.
+	var read int32
+	var refreshed *dasgo.Token
+	store.read = func() {
+		if atomic.AddInt32(&read, 1) != 1 {
+			return
+		}
+
+		var err error
+		if refreshed, err = m.Token(context.Background(), 1); err != nil {
+			t.Error(err)
+			return
+		}
+
+		// the rotated token is due to be refreshed again.
+		rotated := *refreshed
+		rotated.Expiry = time.Now().Add(30 * time.Second)
+		if err := store.Put(0, 1, &rotated); err != nil {
+			t.Error(err)
+		}
+	}
+
+	token, err := m.Token(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected the rotated refresh token to be used, got %v", err)
+	}
+
+	if token.AccessToken == stored.AccessToken || refreshed == nil || token.RefreshToken == stored.RefreshToken {
+		t.Fatalf("expected a token refreshed from the rotated refresh token, got %+v", token)
+	}
+
+	if refreshes := atomic.LoadInt32(&transport.refreshes); refreshes != 2 {
+		t.Fatalf("expected 2 refreshes, got %d", refreshes)
+	}
+}
+
+func TestTokenManagerDrop(t *testing.T) {
+	m, _, stored := tokenManager(t, nil)
+
+	var dropped []dasgo.Snowflake
+	m.OnDrop = func(userID dasgo.Snowflake) { dropped = append(dropped, userID) }
+
+	// the user deauthorizes the application, which revokes the refresh token.
+	if err := m.Client.Revoke(context.Background(), stored.RefreshToken, dasgo.FlagOAuth2TokenTypeHintRefreshToken); err != nil {
+		t.Fatal(err)
+	}
+
+	var oauth2Error *dasgo.OAuth2Error
+	if _, err := m.Token(context.Background(), 1); !errors.As(err, &oauth2Error) || oauth2Error.Code != "invalid_grant" {
+		t.Fatalf("expected invalid_grant, got %v", err)
+	}
+
+	if len(dropped) != 1 || dropped[0] != 1 {
+		t.Fatalf("expected user 1 to be dropped, got %v", dropped)
+	}
+
+	if _, err := m.Token(context.Background(), 1); !errors.Is(err, dasgo.ErrTokenNotFound) {
+		t.Fatalf("expected the token to be deleted, got %v", err)
+	}
+}
+
+func TestTokenManagerRefreshError(t *testing.T) {
+	m, transport, stored := tokenManager(t, nil)
+	transport.err = errors.New("connection refused")
+
+	var dropped int
+	m.OnDrop = func(dasgo.Snowflake) { dropped++ }
+
+	// a token that fails to refresh is returned until it expires.
+	token, err := m.Token(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.AccessToken != stored.AccessToken || dropped != 0 {
+		t.Fatalf("expected the stored token, got %q", token.AccessToken)
+	}
+
+	expired := *stored
+	expired.Expiry = time.Now().Add(-time.Second)
+	if err := m.Store.Put(0, 1, &expired); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Token(context.Background(), 1); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("expected the refresh error, got %v", err)
+	}
+
+	if token, err := m.Store.Get(0, 1); err != nil || token == nil || dropped != 0 {
+		t.Fatalf("expected the token to be kept, got %v (%v)", token, err)
+	}
+
+	// an expired token without a refresh token can't be refreshed.
+	expired.RefreshToken = ""
+	if err := m.Store.Put(0, 1, &expired); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Token(context.Background(), 1); err == nil || !strings.Contains(err.Error(), "can't be refreshed") {
+		t.Fatalf("expected an expired token error, got %v", err)
+	}
+}
+
+func TestTokenManagerContext(t *testing.T) {
+	m, transport, _ := tokenManager(t, nil)
+	transport.delay = 200 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := m.Token(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// the refresh isn't canceled with the context of a caller.
+	token, err := m.Token(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshes := atomic.LoadInt32(&transport.refreshes); refreshes != 1 || token.Expired(time.Now().Add(time.Hour)) {
+		t.Fatalf("expected the refresh to be shared after a canceled caller, got %d refreshes", refreshes)
+	}
+}
@@ -0,0 +1,527 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError represents a value that violates a Discord API constraint.
+type ValidationError struct {
+	// Path represents the location of the value (i.e options[2].choices[5].name).
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors represents every violation found during validation.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// validator collects violations while validating nested values.
+type validator struct {
+	errors ValidationErrors
+}
+
+// add adds a violation to the validator.
+func (v *validator) add(path, format string, a ...interface{}) {
+	v.errors = append(v.errors, &ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+// err returns the violations as an error or nil when there are no violations.
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	return v.errors
+}
+
+// joinPath joins a parent path and a field.
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
+
+// Application Command Limits
+// https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-naming
+const (
+	FlagApplicationCommandLimitName             = 32
+	FlagApplicationCommandLimitDescription      = 100
+	FlagApplicationCommandLimitOptions          = 25
+	FlagApplicationCommandLimitChoices          = 25
+	FlagApplicationCommandLimitChoiceName       = 100
+	FlagApplicationCommandLimitChoiceValue      = 100
+	FlagApplicationCommandLimitCombinedChars    = 4000
+	FlagApplicationCommandLimitSubcommandDepth  = 2
+	FlagApplicationCommandLimitOptionValueRange = 1 << 53
+)
+
+// applicationCommandNameRegex represents the CHAT_INPUT command and option name regex.
+var applicationCommandNameRegex = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+// locales represents the set of valid Discord locales.
+var locales = map[string]bool{
+	FlagLocalesDanish:              true,
+	FlagLocalesGerman:              true,
+	FlagLocalesEnglishUK:           true,
+	FlagLocalesEnglishUS:           true,
+	FlagLocalesSpanish:             true,
+	FlagLocalesFrench:              true,
+	FlagLocalesCroatian:            true,
+	FlagLocalesItalian:             true,
+	FlagLocalesLithuanian:          true,
+	FlagLocalesHungarian:           true,
+	FlagLocalesDutch:               true,
+	FlagLocalesNorwegian:           true,
+	FlagLocalesPolish:              true,
+	FlagLocalesPortugueseBrazilian: true,
+	FlagLocalesRomanian:            true,
+	FlagLocalesFinnish:             true,
+	FlagLocalesSwedish:             true,
+	FlagLocalesVietnamese:          true,
+	FlagLocalesTurkish:             true,
+	FlagLocalesCzech:               true,
+	FlagLocalesGreek:               true,
+	FlagLocalesBulgarian:           true,
+	FlagLocalesRussian:             true,
+	FlagLocalesUkrainian:           true,
+	FlagLocalesHindi:               true,
+	FlagLocalesThai:                true,
+	FlagLocalesChineseChina:        true,
+	FlagLocalesJapanese:            true,
+	FlagLocalesChineseTaiwan:       true,
+	FlagLocalesKorean:              true,
+}
+
+// Validate reports every violation of Discord's Application Command constraints.
+//
+// The returned error is nil or ValidationErrors.
+func (c *ApplicationCommand) Validate() error {
+	v := new(validator)
+
+	commandType := c.Type
+	if commandType == 0 {
+		commandType = FlagApplicationCommandTypeCHAT_INPUT
+	}
+
+	switch commandType {
+	case FlagApplicationCommandTypeCHAT_INPUT:
+		v.validateChatInputName("name", c.Name)
+		v.validateLocalizations("name_localizations", c.NameLocalizations, v.validateChatInputName)
+
+		v.validateDescription("description", c.Description)
+		v.validateLocalizations("description_localizations", c.DescriptionLocalizations, v.validateDescription)
+
+		v.validateOptions("options", c.Options, 0)
+
+		if n := c.combinedLength(); n > FlagApplicationCommandLimitCombinedChars {
+			v.add("", "combined name, description, and option characters must be at most %d characters (found %d)",
+				FlagApplicationCommandLimitCombinedChars, n)
+		}
+
+	case FlagApplicationCommandTypeUSER, FlagApplicationCommandTypeMESSAGE:
+		v.validateContextMenuName("name", c.Name)
+		v.validateLocalizations("name_localizations", c.NameLocalizations, v.validateContextMenuName)
+
+		if c.Description != "" {
+			v.add("description", "must be empty for %s commands", applicationCommandTypeName(commandType))
+		}
+
+		if len(c.DescriptionLocalizations) != 0 {
+			v.add("description_localizations", "must be empty for %s commands", applicationCommandTypeName(commandType))
+		}
+
+		if len(c.Options) != 0 {
+			v.add("options", "must be empty for %s commands", applicationCommandTypeName(commandType))
+		}
+
+	default:
+		v.add("type", "unknown application command type %d", c.Type)
+	}
+
+	return v.err()
+}
+
+// Validate reports every violation of Discord's Application Command Option constraints.
+//
+// The option is validated as a top-level option of a CHAT_INPUT command.
+// The returned error is nil or ValidationErrors.
+func (o *ApplicationCommandOption) Validate() error {
+	v := new(validator)
+	v.validateOption("", o, 0)
+
+	return v.err()
+}
+
+// combinedLength returns the combined length of the name, description, and options of a command.
+func (c *ApplicationCommand) combinedLength() int {
+	n := utf8.RuneCountInString(c.Name) + utf8.RuneCountInString(c.Description)
+
+	var count func(options []*ApplicationCommandOption)
+	count = func(options []*ApplicationCommandOption) {
+		for _, option := range options {
+			if option == nil {
+				continue
+			}
+
+			n += utf8.RuneCountInString(option.Name) + utf8.RuneCountInString(option.Description)
+			for _, choice := range option.Choices {
+				if choice == nil {
+					continue
+				}
+
+				n += utf8.RuneCountInString(choice.Name)
+				if value, ok := choice.Value.(string); ok {
+					n += utf8.RuneCountInString(value)
+				}
+			}
+
+			count(option.Options)
+		}
+	}
+
+	count(c.Options)
+
+	return n
+}
+
+// validateChatInputName validates the name of a CHAT_INPUT command or option.
+func (v *validator) validateChatInputName(path, name string) {
+	if !applicationCommandNameRegex.MatchString(name) {
+		v.add(path, "%q must be 1-%d characters and only contain letters, numbers, '-' and '_'",
+			name, FlagApplicationCommandLimitName)
+		return
+	}
+
+	if strings.ToLower(name) != name {
+		v.add(path, "%q must be lowercase", name)
+	}
+}
+
+// validateContextMenuName validates the name of a USER or MESSAGE command.
+func (v *validator) validateContextMenuName(path, name string) {
+	if n := utf8.RuneCountInString(name); n < 1 || n > FlagApplicationCommandLimitName {
+		v.add(path, "must be 1-%d characters (found %d)", FlagApplicationCommandLimitName, n)
+	}
+}
+
+// validateDescription validates the description of a CHAT_INPUT command or option.
+func (v *validator) validateDescription(path, description string) {
+	if n := utf8.RuneCountInString(description); n < 1 || n > FlagApplicationCommandLimitDescription {
+		v.add(path, "must be 1-%d characters (found %d)", FlagApplicationCommandLimitDescription, n)
+	}
+}
+
+// validateLocale validates a localization key.
+func (v *validator) validateLocale(path, locale string) {
+	if !locales[locale] {
+		v.add(path, "%q is not a valid locale", locale)
+	}
+}
+
+// validateLocalizations validates the locales and values of a localization map in locale order,
+// so that the violations are reported in a stable order.
+func (v *validator) validateLocalizations(path string, localizations map[string]string, validate func(path, value string)) {
+	keys := make([]string, 0, len(localizations))
+	for locale := range localizations {
+		keys = append(keys, locale)
+	}
+
+	sort.Strings(keys)
+
+	for _, locale := range keys {
+		v.validateLocale(path, locale)
+		validate(fmt.Sprintf("%s[%s]", path, locale), localizations[locale])
+	}
+}
+
+// validateOptions validates a list of options at the given subcommand depth.
+func (v *validator) validateOptions(path string, options []*ApplicationCommandOption, depth int) {
+	if len(options) > FlagApplicationCommandLimitOptions {
+		v.add(path, "must contain at most %d options (found %d)", FlagApplicationCommandLimitOptions, len(options))
+	}
+
+	names := make(map[string]bool, len(options))
+	optional := false
+	subcommands, parameters := 0, 0
+	for i, option := range options {
+		optionPath := fmt.Sprintf("%s[%d]", path, i)
+		if option == nil {
+			v.add(optionPath, "must not be null")
+			continue
+		}
+
+		if names[option.Name] {
+			v.add(joinPath(optionPath, "name"), "%q is used by another option", option.Name)
+		}
+
+		names[option.Name] = true
+
+		switch option.Type {
+		case FlagApplicationCommandOptionTypeSUB_COMMAND, FlagApplicationCommandOptionTypeSUB_COMMAND_GROUP:
+			subcommands++
+		default:
+			parameters++
+			if boolOrDefault(option.Required, false) {
+				if optional {
+					v.add(joinPath(optionPath, "required"), "required options must be placed before optional options")
+				}
+			} else {
+				optional = true
+			}
+		}
+
+		v.validateOption(optionPath, option, depth)
+	}
+
+	if subcommands != 0 && parameters != 0 {
+		v.add(path, "must not mix subcommands or subcommand groups with other option types")
+	}
+}
+
+// validateOption validates an option at the given subcommand depth.
+func (v *validator) validateOption(path string, option *ApplicationCommandOption, depth int) {
+	v.validateChatInputName(joinPath(path, "name"), option.Name)
+	v.validateLocalizations(joinPath(path, "name_localizations"), option.NameLocalizations, v.validateChatInputName)
+
+	v.validateDescription(joinPath(path, "description"), option.Description)
+	v.validateLocalizations(joinPath(path, "description_localizations"), option.DescriptionLocalizations, v.validateDescription)
+
+	numeric := option.Type == FlagApplicationCommandOptionTypeINTEGER || option.Type == FlagApplicationCommandOptionTypeNUMBER
+	choosable := numeric || option.Type == FlagApplicationCommandOptionTypeSTRING
+
+	switch option.Type {
+	case FlagApplicationCommandOptionTypeSUB_COMMAND_GROUP:
+		if depth != 0 {
+			v.add(joinPath(path, "type"), "subcommand groups must be top-level options")
+		}
+
+		if len(option.Options) == 0 {
+			v.add(joinPath(path, "options"), "subcommand groups must contain at least one subcommand")
+		}
+
+		for i, sub := range option.Options {
+			if sub != nil && sub.Type != FlagApplicationCommandOptionTypeSUB_COMMAND {
+				v.add(joinPath(path, fmt.Sprintf("options[%d].type", i)), "subcommand groups must only contain subcommands")
+			}
+		}
+
+		v.validateOptions(joinPath(path, "options"), option.Options, depth+1)
+
+	case FlagApplicationCommandOptionTypeSUB_COMMAND:
+		if depth >= FlagApplicationCommandLimitSubcommandDepth {
+			v.add(joinPath(path, "type"), "subcommands must be nested at most %d levels deep", FlagApplicationCommandLimitSubcommandDepth)
+		}
+
+		for i, sub := range option.Options {
+			if sub != nil && (sub.Type == FlagApplicationCommandOptionTypeSUB_COMMAND ||
+				sub.Type == FlagApplicationCommandOptionTypeSUB_COMMAND_GROUP) {
+				v.add(joinPath(path, fmt.Sprintf("options[%d].type", i)), "subcommands must not contain subcommands or subcommand groups")
+			}
+		}
+
+		v.validateOptions(joinPath(path, "options"), option.Options, depth+1)
+
+	case FlagApplicationCommandOptionTypeSTRING,
+		FlagApplicationCommandOptionTypeINTEGER,
+		FlagApplicationCommandOptionTypeBOOLEAN,
+		FlagApplicationCommandOptionTypeUSER,
+		FlagApplicationCommandOptionTypeCHANNEL,
+		FlagApplicationCommandOptionTypeROLE,
+		FlagApplicationCommandOptionTypeMENTIONABLE,
+		FlagApplicationCommandOptionTypeNUMBER,
+		FlagApplicationCommandOptionTypeATTACHMENT:
+		if len(option.Options) != 0 {
+			v.add(joinPath(path, "options"), "only subcommands and subcommand groups may contain options")
+		}
+
+	default:
+		v.add(joinPath(path, "type"), "unknown application command option type %d", option.Type)
+	}
+
+	if !numeric {
+		if option.MinValue != nil {
+			v.add(joinPath(path, "min_value"), "only INTEGER and NUMBER options may have a minimum value")
+		}
+
+		if option.MaxValue != nil {
+			v.add(joinPath(path, "max_value"), "only INTEGER and NUMBER options may have a maximum value")
+		}
+	} else {
+		v.validateOptionRange(path, option)
+	}
+
+	if len(option.ChannelTypes) != 0 && option.Type != FlagApplicationCommandOptionTypeCHANNEL {
+		v.add(joinPath(path, "channel_types"), "only CHANNEL options may have channel types")
+	}
+
+	if boolOrDefault(option.Autocomplete, false) {
+		if !choosable {
+			v.add(joinPath(path, "autocomplete"), "only STRING, INTEGER, and NUMBER options may use autocomplete")
+		}
+
+		if len(option.Choices) != 0 {
+			v.add(joinPath(path, "autocomplete"), "must not be enabled when choices are present")
+		}
+	}
+
+	if len(option.Choices) != 0 && !choosable {
+		v.add(joinPath(path, "choices"), "only STRING, INTEGER, and NUMBER options may have choices")
+	}
+
+	if len(option.Choices) > FlagApplicationCommandLimitChoices {
+		v.add(joinPath(path, "choices"), "must contain at most %d choices (found %d)",
+			FlagApplicationCommandLimitChoices, len(option.Choices))
+	}
+
+	for i, choice := range option.Choices {
+		v.validateChoice(joinPath(path, fmt.Sprintf("choices[%d]", i)), option.Type, choice)
+	}
+}
+
+// validateOptionRange validates the minimum and maximum value of a numeric option.
+func (v *validator) validateOptionRange(path string, option *ApplicationCommandOption) {
+	check := func(field string, value *float64) {
+		if value == nil {
+			return
+		}
+
+		if math.Abs(*value) > FlagApplicationCommandLimitOptionValueRange {
+			v.add(joinPath(path, field), "must be between -2^53 and 2^53")
+		}
+
+		if option.Type == FlagApplicationCommandOptionTypeINTEGER && *value != math.Trunc(*value) {
+			v.add(joinPath(path, field), "must be an integer for INTEGER options")
+		}
+	}
+
+	check("min_value", option.MinValue)
+	check("max_value", option.MaxValue)
+
+	if option.MinValue != nil && option.MaxValue != nil && *option.MinValue > *option.MaxValue {
+		v.add(joinPath(path, "min_value"), "must be less than or equal to max_value")
+	}
+}
+
+// validateChoice validates a choice of an option with the given type.
+func (v *validator) validateChoice(path string, optionType Flag, choice *ApplicationCommandOptionChoice) {
+	if choice == nil {
+		v.add(path, "must not be null")
+		return
+	}
+
+	if n := utf8.RuneCountInString(choice.Name); n < 1 || n > FlagApplicationCommandLimitChoiceName {
+		v.add(joinPath(path, "name"), "must be 1-%d characters (found %d)", FlagApplicationCommandLimitChoiceName, n)
+	}
+
+	v.validateLocalizations(joinPath(path, "name_localizations"), choice.NameLocalizations, func(path, name string) {
+		if n := utf8.RuneCountInString(name); n < 1 || n > FlagApplicationCommandLimitChoiceName {
+			v.add(path, "must be 1-%d characters (found %d)", FlagApplicationCommandLimitChoiceName, n)
+		}
+	})
+
+	valuePath := joinPath(path, "value")
+	switch optionType {
+	case FlagApplicationCommandOptionTypeSTRING:
+		value, ok := choice.Value.(string)
+		if !ok {
+			v.add(valuePath, "must be a string for STRING options")
+		} else if n := utf8.RuneCountInString(value); n < 1 || n > FlagApplicationCommandLimitChoiceValue {
+			v.add(valuePath, "must be 1-%d characters (found %d)", FlagApplicationCommandLimitChoiceValue, n)
+		}
+
+	case FlagApplicationCommandOptionTypeINTEGER:
+		value, ok := choiceNumber(choice.Value)
+		if !ok || value != math.Trunc(value) {
+			v.add(valuePath, "must be an integer for INTEGER options")
+		}
+
+	case FlagApplicationCommandOptionTypeNUMBER:
+		if _, ok := choiceNumber(choice.Value); !ok {
+			v.add(valuePath, "must be a number for NUMBER options")
+		}
+	}
+}
+
+// choiceNumber converts a numeric choice value to a float64.
+func choiceNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+
+	return 0, false
+}
+
+// Validate reports every violation of Discord's Create Guild Sticker constraints.
+//
+// The returned error is nil or ValidationErrors.
+func (r *CreateGuildSticker) Validate() error {
+	v := new(validator)
+
+	if n := utf8.RuneCountInString(r.Name); n < FlagStickerLimitNameMIN || n > FlagStickerLimitNameMAX {
+		v.add("name", "must be %d-%d characters (found %d)", FlagStickerLimitNameMIN, FlagStickerLimitNameMAX, n)
+	}
+
+	if n := utf8.RuneCountInString(r.Description); n != 0 && (n < FlagStickerLimitDescriptionMIN || n > FlagStickerLimitDescriptionMAX) {
+		v.add("description", "must be empty or %d-%d characters (found %d)",
+			FlagStickerLimitDescriptionMIN, FlagStickerLimitDescriptionMAX, n)
+	}
+
+	if r.Tags == nil || *r.Tags == "" {
+		v.add("tags", "must be set")
+	} else if n := utf8.RuneCountInString(*r.Tags); n > FlagStickerLimitTagsMAX {
+		v.add("tags", "must be at most %d characters (found %d)", FlagStickerLimitTagsMAX, n)
+	}
+
+	if _, err := validateStickerFile(r.File); err != nil {
+		v.add("file", "%v", err)
+	}
+
+	return v.err()
+}
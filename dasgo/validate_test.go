@@ -0,0 +1,246 @@
+package dasgo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// validationPaths returns the paths of the violations reported by a validation error.
+func validationPaths(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	paths := make([]string, len(errs))
+	for i, e := range errs {
+		paths[i] = e.Path
+	}
+
+	return paths
+}
+
+func testOption(name string, optionType Flag) *ApplicationCommandOption {
+	return &ApplicationCommandOption{Type: optionType, Name: name, Description: "description"}
+}
+
+func testChoice(name string, value interface{}) *ApplicationCommandOptionChoice {
+	return &ApplicationCommandOptionChoice{Name: name, Value: value}
+}
+
+func TestApplicationCommandValidate(t *testing.T) {
+	required, enabled := true, true
+	min, max := 1.5, 2.0
+
+	many := func(n int, option func(i int) *ApplicationCommandOption) []*ApplicationCommandOption {
+		options := make([]*ApplicationCommandOption, n)
+		for i := range options {
+			options[i] = option(i)
+		}
+
+		return options
+	}
+
+	tests := []struct {
+		name    string
+		command *ApplicationCommand
+		paths   []string
+	}{
+		{
+			name:    "valid",
+			command: &ApplicationCommand{Name: "ping", Description: "Replies with pong."},
+		},
+		{
+			name:    "name regex",
+			command: &ApplicationCommand{Name: "bad name!", Description: "description"},
+			paths:   []string{"name"},
+		},
+		{
+			name:    "name lowercase",
+			command: &ApplicationCommand{Name: "Ping", Description: "description"},
+			paths:   []string{"name"},
+		},
+		{
+			name:    "description length",
+			command: &ApplicationCommand{Name: "ping", Description: strings.Repeat("a", FlagApplicationCommandLimitDescription+1)},
+			paths:   []string{"description"},
+		},
+		{
+			name:    "empty description",
+			command: &ApplicationCommand{Name: "ping"},
+			paths:   []string{"description"},
+		},
+		{
+			name: "option limit",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: many(FlagApplicationCommandLimitOptions+1, func(i int) *ApplicationCommandOption {
+					return testOption(string(rune('a'+i)), FlagApplicationCommandOptionTypeSTRING)
+				}),
+			},
+			paths: []string{"options"},
+		},
+		{
+			name: "choice limit",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{{
+					Type: FlagApplicationCommandOptionTypeINTEGER, Name: "n", Description: "description",
+					Choices: func() []*ApplicationCommandOptionChoice {
+						choices := make([]*ApplicationCommandOptionChoice, FlagApplicationCommandLimitChoices+1)
+						for i := range choices {
+							choices[i] = testChoice("choice", i)
+						}
+
+						return choices
+					}(),
+				}},
+			},
+			paths: []string{"options[0].choices"},
+		},
+		{
+			name: "required after optional",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{
+					testOption("a", FlagApplicationCommandOptionTypeSTRING),
+					{Type: FlagApplicationCommandOptionTypeSTRING, Name: "b", Description: "description", Required: &required},
+				},
+			},
+			paths: []string{"options[1].required"},
+		},
+		{
+			name: "subcommand depth",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{{
+					Type: FlagApplicationCommandOptionTypeSUB_COMMAND_GROUP, Name: "group", Description: "description",
+					Options: []*ApplicationCommandOption{{
+						Type: FlagApplicationCommandOptionTypeSUB_COMMAND, Name: "sub", Description: "description",
+						Options: []*ApplicationCommandOption{
+							testOption("nested", FlagApplicationCommandOptionTypeSUB_COMMAND),
+						},
+					}},
+				}},
+			},
+			paths: []string{
+				"options[0].options[0].options[0].type",
+				"options[0].options[0].options[0].type",
+			},
+		},
+		{
+			name: "min and max value on non-numeric option",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{{
+					Type: FlagApplicationCommandOptionTypeSTRING, Name: "s", Description: "description",
+					MinValue: &min, MaxValue: &max,
+				}},
+			},
+			paths: []string{"options[0].min_value", "options[0].max_value"},
+		},
+		{
+			name: "integer range",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{{
+					Type: FlagApplicationCommandOptionTypeINTEGER, Name: "n", Description: "description",
+					MinValue: &min, MaxValue: &max,
+				}},
+			},
+			paths: []string{"options[0].min_value"},
+		},
+		{
+			name: "channel types on non-channel option",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{{
+					Type: FlagApplicationCommandOptionTypeSTRING, Name: "s", Description: "description",
+					ChannelTypes: []*Flag{new(Flag)},
+				}},
+			},
+			paths: []string{"options[0].channel_types"},
+		},
+		{
+			name: "autocomplete with choices",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{{
+					Type: FlagApplicationCommandOptionTypeSTRING, Name: "s", Description: "description",
+					Autocomplete: &enabled, Choices: []*ApplicationCommandOptionChoice{testChoice("a", "a")},
+				}},
+			},
+			paths: []string{"options[0].autocomplete"},
+		},
+		{
+			name: "choice path",
+			command: &ApplicationCommand{Name: "ping", Description: "description",
+				Options: []*ApplicationCommandOption{
+					testOption("a", FlagApplicationCommandOptionTypeSTRING),
+					testOption("b", FlagApplicationCommandOptionTypeSTRING),
+					{
+						Type: FlagApplicationCommandOptionTypeSTRING, Name: "c", Description: "description",
+						Choices: []*ApplicationCommandOptionChoice{
+							testChoice("a", "a"), testChoice("b", "b"), testChoice("c", "c"),
+							testChoice("d", "d"), testChoice("e", "e"), testChoice("", "f"),
+						},
+					},
+				},
+			},
+			paths: []string{"options[2].choices[5].name"},
+		},
+		{
+			name: "context menu command",
+			command: &ApplicationCommand{Type: FlagApplicationCommandTypeUSER, Name: "User Info", Description: "description",
+				Options: []*ApplicationCommandOption{testOption("a", FlagApplicationCommandOptionTypeSTRING)},
+			},
+			paths: []string{"description", "options"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if paths := validationPaths(t, test.command.Validate()); !reflect.DeepEqual(paths, test.paths) {
+				t.Fatalf("expected violations at %q, got %q", test.paths, paths)
+			}
+		})
+	}
+}
+
+func TestApplicationCommandValidateLocalizationOrder(t *testing.T) {
+	command := &ApplicationCommand{
+		Name:        "ping",
+		Description: "description",
+		NameLocalizations: map[string]string{
+			FlagLocalesGerman:  "Ping",
+			FlagLocalesFrench:  "bad name!",
+			"xx":               "ping",
+			FlagLocalesSpanish: "PING",
+		},
+	}
+
+	want := []string{
+		"name_localizations[de]",
+		"name_localizations[es-ES]",
+		"name_localizations[fr]",
+		"name_localizations",
+	}
+
+	for i := 0; i < 20; i++ {
+		if paths := validationPaths(t, command.Validate()); !reflect.DeepEqual(paths, want) {
+			t.Fatalf("expected violations at %q, got %q", want, paths)
+		}
+	}
+}
+
+func TestApplicationCommandOptionValidate(t *testing.T) {
+	option := &ApplicationCommandOption{
+		Type: FlagApplicationCommandOptionTypeSTRING, Name: "option", Description: "description",
+		Choices: []*ApplicationCommandOptionChoice{testChoice("a", 1)},
+	}
+
+	want := []string{"choices[0].value"}
+	if paths := validationPaths(t, option.Validate()); !reflect.DeepEqual(paths, want) {
+		t.Fatalf("expected violations at %q, got %q", want, paths)
+	}
+}
@@ -0,0 +1,371 @@
+// Package dasgo provides Type Definitions for the Discord API.
+package dasgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// webhookHosts represents the hosts of a webhook URL.
+var webhookHosts = []string{
+	"discord.com", "ptb.discord.com", "canary.discord.com",
+	"discordapp.com", "ptb.discordapp.com", "canary.discordapp.com",
+}
+
+// maxRateLimitRetries represents the number of times a rate limited request is retried.
+const maxRateLimitRetries = 3
+
+// WebhookClient represents a client that executes a webhook with its token (without a bot token).
+type WebhookClient struct {
+	WebhookID    Snowflake
+	WebhookToken string
+
+	// Username and AvatarURL represent the default username and avatar overrides
+	// of executed messages (when non-empty).
+	Username  string
+	AvatarURL string
+
+	// BaseURL represents the URL that webhook endpoints are relative to
+	// (EndpointBaseURL when empty).
+	BaseURL string
+
+	// HTTPClient represents the client used to send requests (http.DefaultClient when nil).
+	HTTPClient *http.Client
+
+	mu      sync.Mutex
+	buckets map[string]*webhookBucket
+}
+
+// webhookBucket represents the rate limit bucket of a webhook route.
+type webhookBucket struct {
+	limit     int
+	remaining int
+	reset     time.Time
+	window    time.Duration
+}
+
+// ParseWebhookURL parses a webhook URL (i.e https://discord.com/api/webhooks/{webhook.id}/{webhook.token})
+// into its webhook ID and token.
+func ParseWebhookURL(webhookURL string) (Snowflake, string, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return 0, "", fmt.Errorf("error parsing webhook URL: %w", err)
+	}
+
+	if u.Scheme != "https" {
+		return 0, "", fmt.Errorf("error parsing webhook URL: scheme %q is not https", u.Scheme)
+	}
+
+	if !contains(webhookHosts, strings.ToLower(u.Hostname())) {
+		return 0, "", fmt.Errorf("error parsing webhook URL: host %q is not a Discord host", u.Hostname())
+	}
+
+	// the path of a webhook URL is /api/webhooks/{webhook.id}/{webhook.token}
+	// with an optional API version (i.e /api/v10/webhooks).
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) > 1 && segments[0] == "api" {
+		segments = segments[1:]
+		if version := strings.TrimPrefix(segments[0], "v"); version != segments[0] {
+			if _, err := strconv.Atoi(version); err == nil {
+				segments = segments[1:]
+			}
+		}
+	}
+
+	if len(segments) != 3 || segments[0] != "webhooks" {
+		return 0, "", fmt.Errorf("error parsing webhook URL: path %q is not /api/webhooks/{webhook.id}/{webhook.token}", u.Path)
+	}
+
+	id, err := strconv.ParseUint(segments[1], 10, 64)
+	if err != nil || id == 0 {
+		return 0, "", fmt.Errorf("error parsing webhook URL: webhook ID %q is not a snowflake", segments[1])
+	}
+
+	if segments[2] == "" {
+		return 0, "", errors.New("error parsing webhook URL: webhook token is missing")
+	}
+
+	return Snowflake(id), segments[2], nil
+}
+
+// NewWebhookClient returns a new WebhookClient for a webhook URL.
+func NewWebhookClient(webhookURL string) (*WebhookClient, error) {
+	id, token, err := ParseWebhookURL(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookClient{WebhookID: id, WebhookToken: token}, nil
+}
+
+// ExecuteWebhook executes the webhook with files, and returns the created message
+// when the request waits for the message (Wait); otherwise, the returned message is nil.
+//
+// The request's Username and AvatarURL default to the client's Username and AvatarURL.
+func (c *WebhookClient) ExecuteWebhook(ctx context.Context, request *ExecuteWebhook, files ...*File) (*Message, error) {
+	execute := *request
+	execute.WebhookID, execute.WebhookToken = c.WebhookID, c.WebhookToken
+
+	if execute.Username == "" {
+		execute.Username = c.Username
+	}
+
+	if execute.AvatarURL == "" {
+		execute.AvatarURL = c.AvatarURL
+	}
+
+	var message *Message
+	if execute.Wait {
+		message = new(Message)
+	}
+
+	if err := c.send(ctx, http.MethodPost, EndpointExecuteWebhook, nil, &execute, files, message); err != nil {
+		return nil, fmt.Errorf("error executing webhook: %w", err)
+	}
+
+	return message, nil
+}
+
+// ExecuteSlackCompatibleWebhook executes the webhook with a Slack webhook payload.
+func (c *WebhookClient) ExecuteSlackCompatibleWebhook(ctx context.Context, request *ExecuteSlackCompatibleWebhook) error {
+	if err := c.send(ctx, http.MethodPost, EndpointExecuteSlackCompatibleWebhook, nil, request, nil, nil); err != nil {
+		return fmt.Errorf("error executing Slack-compatible webhook: %w", err)
+	}
+
+	return nil
+}
+
+// ExecuteGitHubCompatibleWebhook executes the webhook with a GitHub webhook payload of a GitHub Webhook Event.
+func (c *WebhookClient) ExecuteGitHubCompatibleWebhook(ctx context.Context, request *ExecuteGitHubCompatibleWebhook) error {
+	if request.Event == "" {
+		return errors.New("error executing GitHub-compatible webhook: event is missing")
+	}
+
+	if err := c.send(ctx, http.MethodPost, EndpointExecuteGitHubCompatibleWebhook, nil, request, nil, nil); err != nil {
+		return fmt.Errorf("error executing GitHub-compatible webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookMessage returns a message that is executed by the webhook.
+func (c *WebhookClient) GetWebhookMessage(ctx context.Context, request *GetWebhookMessage) (*Message, error) {
+	message := new(Message)
+	if err := c.send(ctx, http.MethodGet, EndpointGetWebhookMessage, []string{"{message.id}", snowflakeString(request.MessageID)},
+		request, nil, message); err != nil {
+		return nil, fmt.Errorf("error getting webhook message: %w", err)
+	}
+
+	return message, nil
+}
+
+// EditWebhookMessage edits a message that is executed by the webhook with files, and returns the edited message.
+func (c *WebhookClient) EditWebhookMessage(ctx context.Context, request *EditWebhookMessage, files ...*File) (*Message, error) {
+	message := new(Message)
+	if err := c.send(ctx, http.MethodPatch, EndpointEditWebhookMessage, []string{"{message.id}", snowflakeString(request.MessageID)},
+		request, files, message); err != nil {
+		return nil, fmt.Errorf("error editing webhook message: %w", err)
+	}
+
+	return message, nil
+}
+
+// DeleteWebhookMessage deletes a message that is executed by the webhook.
+func (c *WebhookClient) DeleteWebhookMessage(ctx context.Context, request *DeleteWebhookMessage) error {
+	if err := c.send(ctx, http.MethodDelete, EndpointDeleteWebhookMessage, []string{"{message.id}", snowflakeString(request.MessageID)},
+		request, nil, nil); err != nil {
+		return fmt.Errorf("error deleting webhook message: %w", err)
+	}
+
+	return nil
+}
+
+// send sends a request to a webhook endpoint with the given parameters (i.e "{message.id}", "1"),
+// and decodes its JSON response into response (when non-nil).
+//
+// The URL Query String Parameters, headers, and JSON body of the request are encoded from its
+// `url`, `http`, and `json` tags.
+// A request that is rate limited is retried after the rate limit resets.
+func (c *WebhookClient) send(ctx context.Context, method, endpoint string, params []string, request interface{}, files []*File, response interface{}) error {
+	params = append(params, "{webhook.id}", snowflakeString(c.WebhookID), "{webhook.token}", url.PathEscape(c.WebhookToken))
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = EndpointBaseURL
+	}
+
+	requestURL := baseURL + strings.NewReplacer(params...).Replace(endpoint)
+	if query := queryValues(request).Encode(); query != "" {
+		requestURL += "?" + query
+	}
+
+	var body []byte
+	var contentType string
+	if method != http.MethodGet && method != http.MethodDelete {
+		var err error
+		if body, contentType, err = requestBody(request, files); err != nil {
+			return err
+		}
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	bucket := method + " " + endpoint
+	for retries := 0; ; retries++ {
+		if err := c.wait(ctx, bucket); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+
+		for key, values := range headerValues(request) {
+			req.Header[key] = values
+		}
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if err != nil {
+			return err
+		}
+
+		c.update(bucket, resp, data)
+
+		if resp.StatusCode == http.StatusTooManyRequests && retries < maxRateLimitRetries {
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			errorResponse := new(ErrorResponse)
+			if json.Unmarshal(data, errorResponse) != nil || errorResponse.Message == "" {
+				errorResponse.Message = http.StatusText(resp.StatusCode)
+			}
+
+			return errorResponse
+		}
+
+		if response == nil || resp.StatusCode == http.StatusNoContent {
+			return nil
+		}
+
+		return json.Unmarshal(data, response)
+	}
+}
+
+// wait waits until a rate limit bucket of the webhook has a remaining request, and reserves it.
+//
+// A bucket that resets before a response updates it is replenished to its limit.
+func (c *WebhookClient) wait(ctx context.Context, bucket string) error {
+	for {
+		c.mu.Lock()
+		var delay time.Duration
+		if b, ok := c.buckets[bucket]; ok {
+			now := time.Now()
+			if b.remaining <= 0 && !now.Before(b.reset) {
+				b.remaining, b.reset = b.limit, now.Add(b.window)
+			}
+
+			if b.remaining > 0 {
+				b.remaining--
+			} else {
+				delay = b.reset.Sub(now)
+			}
+		}
+		c.mu.Unlock()
+
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// update updates a rate limit bucket of the webhook from the Rate Limit Headers
+// and Rate Limit Response of a response.
+//
+// The remaining requests of a response don't restore the requests that are reserved
+// by requests in flight.
+func (c *WebhookClient) update(bucket string, resp *http.Response, data []byte) {
+	var limit, remaining int
+	var resetAfter float64
+	var err error
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		var rateLimit RateLimitResponse
+		if json.Unmarshal(data, &rateLimit) == nil && rateLimit.RetryAfter > 0 {
+			resetAfter = rateLimit.RetryAfter
+		} else if resetAfter, err = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err != nil {
+			resetAfter = 1
+		}
+	} else {
+		if resp.Header.Get(FlagRateLimitHeaderRemaining) == "" {
+			return
+		}
+
+		if remaining, err = strconv.Atoi(resp.Header.Get(FlagRateLimitHeaderRemaining)); err != nil {
+			return
+		}
+
+		if resetAfter, err = strconv.ParseFloat(resp.Header.Get(FlagRateLimitHeaderResetAfter), 64); err != nil {
+			return
+		}
+	}
+
+	limit, _ = strconv.Atoi(resp.Header.Get(FlagRateLimitHeaderLimit))
+	window := time.Duration(math.Ceil(resetAfter * float64(time.Second)))
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.buckets == nil {
+		c.buckets = make(map[string]*webhookBucket)
+	}
+
+	b, ok := c.buckets[bucket]
+	if !ok {
+		b = &webhookBucket{limit: 1}
+		c.buckets[bucket] = b
+	} else if now.Before(b.reset) && b.remaining < remaining {
+		remaining = b.remaining
+	}
+
+	if limit > 0 {
+		b.limit = limit
+	}
+
+	b.remaining, b.reset, b.window = remaining, now.Add(window), window
+}
@@ -0,0 +1,259 @@
+package dasgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/switchupcb/dasgo/dasgo"
+	"github.com/switchupcb/dasgo/dasgo/dasgotest"
+)
+
+// webhookClient returns a WebhookClient of a webhook in a channel of a fake server.
+func webhookClient(t *testing.T) (*dasgotest.Server, *dasgo.WebhookClient, *dasgo.Channel) {
+	t.Helper()
+
+	s := dasgotest.NewServer(nil, "token")
+	t.Cleanup(s.Close)
+
+	guild := s.World.AddGuild(&dasgo.Guild{Name: "guild"})
+	channel := s.World.AddChannel(&dasgo.Channel{Name: "general", GuildID: guild.ID})
+
+	name := "webhook"
+	webhook := s.World.AddWebhook(&dasgo.Webhook{Name: &name, GuildID: &guild.ID, ChannelID: &channel.ID})
+
+	client := &dasgo.WebhookClient{
+		WebhookID:    webhook.ID,
+		WebhookToken: webhook.Token,
+		Username:     "dasgo",
+		BaseURL:      s.BaseURL(),
+	}
+
+	return s, client, channel
+}
+
+func TestParseWebhookURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		id    dasgo.Snowflake
+		token string
+		err   string
+	}{
+		{name: "webhook", url: "https://discord.com/api/webhooks/123/token", id: 123, token: "token"},
+		{name: "version", url: "https://discord.com/api/v10/webhooks/123/token", id: 123, token: "token"},
+		{name: "legacy host", url: "https://canary.discordapp.com/api/webhooks/123/token/", id: 123, token: "token"},
+		{name: "http", url: "http://discord.com/api/webhooks/123/token", err: `scheme "http" is not https`},
+		{name: "host", url: "https://example.com/api/webhooks/123/token", err: `host "example.com" is not a Discord host`},
+		{name: "path", url: "https://discord.com/api/channels/123/token", err: "is not /api/webhooks/{webhook.id}/{webhook.token}"},
+		{name: "missing token", url: "https://discord.com/api/webhooks/123", err: "is not /api/webhooks/{webhook.id}/{webhook.token}"},
+		{name: "id", url: "https://discord.com/api/webhooks/webhook/token", err: `webhook ID "webhook" is not a snowflake`},
+		{name: "zero id", url: "https://discord.com/api/webhooks/0/token", err: `webhook ID "0" is not a snowflake`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, token, err := dasgo.ParseWebhookURL(test.url)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error containing %q, got %v", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if id != test.id || token != test.token {
+				t.Fatalf("expected %d and %q, got %d and %q", test.id, test.token, id, token)
+			}
+		})
+	}
+}
+
+func TestWebhookClientExecute(t *testing.T) {
+	s, client, channel := webhookClient(t)
+
+	// a request that doesn't wait doesn't return the message.
+	message, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Content: "hello"})
+	if err != nil || message != nil {
+		t.Fatalf("expected no message, got %+v (%v)", message, err)
+	}
+
+	message, err = client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Wait: true, Content: "wait"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Content != "wait" || message.ChannelID == nil || *message.ChannelID != channel.ID ||
+		message.Author == nil || message.Author.Username != client.Username {
+		t.Fatalf("expected a message from %q in channel %d, got %+v", client.Username, channel.ID, message)
+	}
+
+	if messages := s.World.Messages(channel.ID); len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+
+	// a request's username overrides the client's username.
+	message, err = client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Wait: true, Content: "override", Username: "user"})
+	if err != nil || message.Author.Username != "user" {
+		t.Fatalf("expected a message from user, got %+v (%v)", message, err)
+	}
+
+	if _, err := client.GetWebhookMessage(context.Background(), &dasgo.GetWebhookMessage{MessageID: message.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteWebhookMessage(context.Background(), &dasgo.DeleteWebhookMessage{MessageID: message.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a client with an invalid token is unauthorized.
+	client.WebhookToken = "invalid"
+
+	var errorResponse *dasgo.ErrorResponse
+	if _, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Content: "hello"}); !errors.As(err, &errorResponse) ||
+		errorResponse.Code != 50027 {
+		t.Fatalf("expected an invalid webhook token error, got %v", err)
+	}
+}
+
+func TestWebhookClientThread(t *testing.T) {
+	s, client, channel := webhookClient(t)
+
+	threadType := dasgo.Flag(dasgo.FlagChannelTypeGUILD_PUBLIC_THREAD)
+	thread := s.World.AddChannel(&dasgo.Channel{Name: "thread", Type: &threadType, GuildID: channel.GuildID, ParentID: &channel.ID})
+
+	message, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Wait: true, ThreadID: thread.ID, Content: "thread"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.ChannelID == nil || *message.ChannelID != thread.ID {
+		t.Fatalf("expected a message in thread %d, got %+v", thread.ID, message)
+	}
+
+	requests := s.Requests()
+	if query := requests[len(requests)-1].Query; query["thread_id"][0] != strconv.FormatUint(uint64(thread.ID), 10) || query["wait"][0] != "true" {
+		t.Fatalf("expected the thread_id and wait query parameters, got %v", query)
+	}
+}
+
+func TestWebhookClientFiles(t *testing.T) {
+	s, client, _ := webhookClient(t)
+
+	message, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Wait: true, Content: "files"},
+		&dasgo.File{Name: "a.txt", ContentType: "text/plain", Data: []byte("a")},
+		&dasgo.File{Name: "b.png", Data: []byte("b")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Content != "files" || len(message.Attachments) != 2 ||
+		message.Attachments[0].Filename != "a.txt" || message.Attachments[1].Filename != "b.png" {
+		t.Fatalf("expected a message with 2 attachments, got %+v", message)
+	}
+
+	requests := s.Requests()
+	if contentType := requests[len(requests)-1].Header.Get("Content-Type"); !strings.HasPrefix(contentType, "multipart/form-data") {
+		t.Fatalf("expected a multipart/form-data request, got %q", contentType)
+	}
+}
+
+func TestWebhookClientRateLimit(t *testing.T) {
+	s, client, _ := webhookClient(t)
+
+	// a rate limited request is retried after the rate limit resets.
+	s.QueueRateLimit(http.MethodPost, dasgo.EndpointExecuteWebhook, 100*time.Millisecond, false)
+
+	start := time.Now()
+	if _, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Content: "retry"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || len(s.Requests()) != 2 {
+		t.Fatalf("expected a retry after 100ms, got %d requests in %v", len(s.Requests()), elapsed)
+	}
+
+	// a request is retried a limited number of times.
+	for i := 0; i < 4; i++ {
+		s.QueueRateLimit(http.MethodPost, dasgo.EndpointExecuteWebhook, time.Millisecond, false)
+	}
+
+	var errorResponse *dasgo.ErrorResponse
+	if _, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Content: "retry"}); !errors.As(err, &errorResponse) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+
+	if requests := len(s.Requests()); requests != 6 {
+		t.Fatalf("expected 4 attempts, got %d", requests-2)
+	}
+}
+
+func TestWebhookClientBucket(t *testing.T) {
+	s, client, _ := webhookClient(t)
+
+	// every response has 1 remaining request of 2, which resets after 300ms.
+	var mu sync.Mutex
+	var arrivals []time.Time
+	s.Handle(http.MethodPost, dasgo.EndpointExecuteWebhook, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+
+		w.Header().Set(dasgo.FlagRateLimitHeaderLimit, "2")
+		w.Header().Set(dasgo.FlagRateLimitHeaderRemaining, "1")
+		w.Header().Set(dasgo.FlagRateLimitHeaderResetAfter, "0.3")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Content: "first"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// concurrent requests reserve the remaining request, so only one is sent before the bucket resets.
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ExecuteWebhook(context.Background(), &dasgo.ExecuteWebhook{Content: "burst"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(arrivals) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(arrivals))
+	}
+
+	reset := arrivals[0].Add(300 * time.Millisecond)
+	var early int
+	for _, arrival := range arrivals[1:] {
+		if arrival.Before(reset) {
+			early++
+		}
+	}
+
+	if early != 1 {
+		t.Fatalf("expected 1 request before the bucket resets, got %d", early)
+	}
+
+	// a canceled context stops waiting for the bucket, which the burst exhausted.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.ExecuteWebhook(ctx, &dasgo.ExecuteWebhook{Content: "canceled"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}